@@ -1,16 +1,21 @@
 package main
 
 import (
+	"context"
 	"embed"
 	"html/template"
 	"log"
 	"net/http"
 	"os"
 	"path/filepath"
+	"time"
 
 	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/infrastructure/cli"
 	"github.com/aymaneelmaini/moka/internal/infrastructure/persistence/sqlite"
+	"github.com/aymaneelmaini/moka/internal/infrastructure/scheduler"
 	"github.com/aymaneelmaini/moka/internal/infrastructure/web/handlers"
+	"github.com/aymaneelmaini/moka/internal/shared"
 )
 
 //go:embed ../internal/infrastructure/web/templates/*.html
@@ -30,6 +35,23 @@ func main() {
 
 	dbPath := filepath.Join(dataDir, "moka.db")
 
+	baseCurrency := os.Getenv("MOKA_BASE_CURRENCY")
+	if baseCurrency == "" {
+		baseCurrency = shared.DefaultCurrency
+	}
+	baseCurrency, err := shared.NormalizeCurrency(baseCurrency)
+	if err != nil {
+		log.Fatalf("Invalid MOKA_BASE_CURRENCY: %v", err)
+	}
+
+	// MOKA_EXCHANGE_RATES gives the value of one unit of each foreign
+	// currency in the base currency, e.g. "EUR=10.85,USD=9.95". They are
+	// only used for pairs with no rate stored in the database.
+	exchangeRates, err := application.ParseStaticRates(os.Getenv("MOKA_EXCHANGE_RATES"))
+	if err != nil {
+		log.Fatalf("Invalid MOKA_EXCHANGE_RATES: %v", err)
+	}
+
 	log.Println("Initializing database...")
 	db, err := sqlite.NewDB(dbPath)
 	if err != nil {
@@ -47,13 +69,97 @@ func main() {
 	budgetRepo := sqlite.NewBudgetRepository(db)
 	fixedChargeRepo := sqlite.NewFixedChargeRepository(db)
 	loanRepo := sqlite.NewLoanRepository(db)
+	loanPaymentRepo := sqlite.NewLoanPaymentRepository(db)
+	loanInstallmentRepo := sqlite.NewLoanInstallmentRepository(db)
+	exchangeRateRepo := sqlite.NewExchangeRateRepository(db)
+	categoryRepo := sqlite.NewCategoryRepository(db)
+	recurringRuleRepo := sqlite.NewRecurringRuleRepository(db)
+	accountRepo := sqlite.NewAccountRepository(db)
+	reconciliationRepo := sqlite.NewReconciliationRepository(db)
+	goalRepo := sqlite.NewGoalRepository(db)
+	dismissalRepo := sqlite.NewDismissalRepository(db)
+	statementAccountRepo := sqlite.NewStatementAccountRepository(db)
+	categoryRuleRepo := sqlite.NewCategoryRuleRepository(db)
+	unitOfWork := sqlite.NewUnitOfWork(db)
 
 	log.Println("Initializing use cases...")
-	addSalaryUC := application.NewAddSalaryUseCase(transactionRepo, fixedChargeRepo)
-	recordExpenseUC := application.NewRecordExpenseUseCase(transactionRepo, budgetRepo)
-	borrowMoneyUC := application.NewBorrowMoneyUseCase(loanRepo, transactionRepo)
-	payLoanUC := application.NewPayLoanUseCase(loanRepo, transactionRepo)
-	getMonthlySummaryUC := application.NewGetMonthlySummaryUseCase(transactionRepo, budgetRepo, loanRepo, fixedChargeRepo)
+	converter := application.NewRateTableConverter(
+		baseCurrency,
+		exchangeRateRepo,
+		application.NewStaticRateConverter(baseCurrency, exchangeRates),
+	)
+	addSalaryUC := application.NewAddSalaryUseCase(unitOfWork, converter)
+	recordIncomeUC := application.NewRecordIncomeUseCase(unitOfWork, categoryRepo, categoryRuleRepo, converter)
+	recordExpenseUC := application.NewRecordExpenseUseCase(transactionRepo, budgetRepo, categoryRepo, accountRepo, reconciliationRepo, dismissalRepo, categoryRuleRepo, converter)
+	editTransactionUC := application.NewEditTransactionUseCase(unitOfWork, categoryRepo)
+	deleteTransactionUC := application.NewDeleteTransactionUseCase(unitOfWork)
+	borrowMoneyUC := application.NewBorrowMoneyUseCase(unitOfWork)
+	payLoanUC := application.NewPayLoanUseCase(unitOfWork)
+	lendMoneyUC := application.NewLendMoneyUseCase(unitOfWork)
+	collectRepaymentUC := application.NewCollectRepaymentUseCase(unitOfWork)
+	getLoanHistoryUC := application.NewGetLoanHistoryUseCase(loanRepo, loanPaymentRepo, loanInstallmentRepo)
+	editLoanUC := application.NewEditLoanUseCase(unitOfWork)
+	cancelLoanUC := application.NewCancelLoanUseCase(loanRepo)
+	deleteLoanUC := application.NewDeleteLoanUseCase(unitOfWork)
+	undoLoanPaymentUC := application.NewUndoLoanPaymentUseCase(unitOfWork)
+	getPersonLedgerUC := application.NewGetPersonLedgerUseCase(loanRepo, loanPaymentRepo, converter)
+	getMonthlySummaryUC := application.NewGetMonthlySummaryUseCase(
+		transactionRepo,
+		budgetRepo,
+		loanRepo,
+		loanInstallmentRepo,
+		fixedChargeRepo,
+		categoryRepo,
+		accountRepo,
+		converter,
+	)
+	setBudgetUC := application.NewSetBudgetUseCase(budgetRepo, categoryRepo)
+	updateBudgetUC := application.NewUpdateBudgetUseCase(budgetRepo)
+	deleteBudgetUC := application.NewDeleteBudgetUseCase(budgetRepo)
+	addExchangeRateUC := application.NewAddExchangeRateUseCase(exchangeRateRepo)
+	importExchangeRatesUC := application.NewImportExchangeRatesUseCase(exchangeRateRepo)
+	deleteExchangeRateUC := application.NewDeleteExchangeRateUseCase(exchangeRateRepo)
+	createCategoryUC := application.NewCreateCategoryUseCase(categoryRepo)
+	updateCategoryUC := application.NewUpdateCategoryUseCase(categoryRepo)
+	archiveCategoryUC := application.NewArchiveCategoryUseCase(categoryRepo)
+	deleteCategoryUC := application.NewDeleteCategoryUseCase(categoryRepo)
+	addFixedChargeUC := application.NewAddFixedChargeUseCase(fixedChargeRepo)
+	editFixedChargeUC := application.NewEditFixedChargeUseCase(fixedChargeRepo)
+	toggleFixedChargeUC := application.NewToggleFixedChargeUseCase(fixedChargeRepo)
+	deleteFixedChargeUC := application.NewDeleteFixedChargeUseCase(unitOfWork)
+	createRecurringRuleUC := application.NewCreateRecurringRuleUseCase(recurringRuleRepo, categoryRepo)
+	toggleRecurringRuleUC := application.NewToggleRecurringRuleUseCase(recurringRuleRepo)
+	deleteRecurringRuleUC := application.NewDeleteRecurringRuleUseCase(unitOfWork)
+	runRecurringRulesUC := application.NewRunRecurringRulesUseCase(unitOfWork)
+	createAccountUC := application.NewCreateAccountUseCase(accountRepo)
+	updateAccountUC := application.NewUpdateAccountUseCase(accountRepo)
+	transferMoneyUC := application.NewTransferMoneyUseCase(unitOfWork)
+	reconcileAccountUC := application.NewReconcileAccountUseCase(unitOfWork, converter)
+	createGoalUC := application.NewCreateGoalUseCase(goalRepo, accountRepo)
+	contributeToGoalUC := application.NewContributeToGoalUseCase(unitOfWork)
+	deleteGoalUC := application.NewDeleteGoalUseCase(unitOfWork)
+	getGoalProgressUC := application.NewGetGoalProgressUseCase(goalRepo, accountRepo, transactionRepo, converter)
+	importStatementUC := application.NewImportStatementUseCase(transactionRepo, categoryRepo, accountRepo, reconciliationRepo, dismissalRepo, categoryRuleRepo, unitOfWork)
+	importStatementFileUC := application.NewImportStatementFileUseCase(transactionRepo, categoryRepo, accountRepo, reconciliationRepo, dismissalRepo, statementAccountRepo, categoryRuleRepo, unitOfWork)
+	findDuplicatesUC := application.NewFindDuplicatesUseCase(transactionRepo, dismissalRepo)
+	mergeDuplicateUC := application.NewMergeDuplicateUseCase(unitOfWork)
+	dismissDuplicateUC := application.NewDismissDuplicateUseCase(transactionRepo, dismissalRepo)
+	createCategoryRuleUC := application.NewCreateCategoryRuleUseCase(categoryRuleRepo, categoryRepo)
+	setCategoryRulePriorityUC := application.NewSetCategoryRulePriorityUseCase(categoryRuleRepo)
+	deleteCategoryRuleUC := application.NewDeleteCategoryRuleUseCase(categoryRuleRepo)
+	applyCategoryRulesUC := application.NewApplyCategoryRulesUseCase(categoryRuleRepo, categoryRepo, unitOfWork)
+	exportDataUC := application.NewExportDataUseCase(unitOfWork)
+	restoreBackupUC := application.NewRestoreBackupUseCase(unitOfWork)
+
+	// "moka export" and "moka import" back the data up and restore it
+	// instead of starting the server
+	if len(os.Args) > 1 {
+		backupCommands := cli.NewBackupCommands(exportDataUC, restoreBackupUC, os.Stdout)
+		if err := backupCommands.Run(os.Args[1:]); err != nil {
+			log.Fatalf("moka %s: %v", os.Args[1], err)
+		}
+		return
+	}
 
 	log.Println("Loading templates...")
 	tmpl, err := template.ParseFS(templatesFS, "internal/infrastructure/web/templates/*.html")
@@ -62,10 +168,104 @@ func main() {
 	}
 
 	log.Println("Initializing handlers...")
-	dashboardHandler := handlers.NewDashboardHandler(getMonthlySummaryUC, tmpl)
-	transactionHandler := handlers.NewTransactionHandler(addSalaryUC, recordExpenseUC, tmpl)
-	loanHandler := handlers.NewLoanHandler(borrowMoneyUC, payLoanUC, tmpl)
-	fixedChargeHandler := handlers.NewFixedChargeHandler(fixedChargeRepo, tmpl)
+	dashboardHandler := handlers.NewDashboardHandler(getMonthlySummaryUC, getGoalProgressUC, tmpl)
+	transactionHandler := handlers.NewTransactionHandler(
+		addSalaryUC,
+		recordIncomeUC,
+		recordExpenseUC,
+		editTransactionUC,
+		deleteTransactionUC,
+		transactionRepo,
+		tmpl,
+	)
+	loanHandler := handlers.NewLoanHandler(
+		borrowMoneyUC,
+		payLoanUC,
+		lendMoneyUC,
+		collectRepaymentUC,
+		getLoanHistoryUC,
+		editLoanUC,
+		cancelLoanUC,
+		deleteLoanUC,
+		undoLoanPaymentUC,
+		loanRepo,
+		tmpl,
+	)
+	personHandler := handlers.NewPersonHandler(getPersonLedgerUC, tmpl)
+	fixedChargeHandler := handlers.NewFixedChargeHandler(
+		addFixedChargeUC,
+		editFixedChargeUC,
+		toggleFixedChargeUC,
+		deleteFixedChargeUC,
+		fixedChargeRepo,
+		tmpl,
+	)
+	recurringHandler := handlers.NewRecurringHandler(
+		createRecurringRuleUC,
+		toggleRecurringRuleUC,
+		deleteRecurringRuleUC,
+		runRecurringRulesUC,
+		recurringRuleRepo,
+		tmpl,
+	)
+	budgetHandler := handlers.NewBudgetHandler(setBudgetUC, updateBudgetUC, deleteBudgetUC, budgetRepo, tmpl)
+	exchangeRateHandler := handlers.NewExchangeRateHandler(
+		addExchangeRateUC,
+		importExchangeRatesUC,
+		deleteExchangeRateUC,
+		exchangeRateRepo,
+		baseCurrency,
+		tmpl,
+	)
+	categoryHandler := handlers.NewCategoryHandler(
+		createCategoryUC,
+		updateCategoryUC,
+		archiveCategoryUC,
+		deleteCategoryUC,
+		categoryRepo,
+		tmpl,
+	)
+	accountHandler := handlers.NewAccountHandler(
+		createAccountUC,
+		updateAccountUC,
+		transferMoneyUC,
+		reconcileAccountUC,
+		accountRepo,
+		reconciliationRepo,
+		tmpl,
+	)
+	goalHandler := handlers.NewGoalHandler(
+		createGoalUC,
+		contributeToGoalUC,
+		deleteGoalUC,
+		getGoalProgressUC,
+		tmpl,
+	)
+	importHandler := handlers.NewImportHandler(importStatementUC, importStatementFileUC, tmpl)
+	duplicateHandler := handlers.NewDuplicateHandler(
+		findDuplicatesUC,
+		mergeDuplicateUC,
+		dismissDuplicateUC,
+		tmpl,
+	)
+	categoryRuleHandler := handlers.NewCategoryRuleHandler(
+		createCategoryRuleUC,
+		setCategoryRulePriorityUC,
+		deleteCategoryRuleUC,
+		applyCategoryRulesUC,
+		categoryRuleRepo,
+		tmpl,
+	)
+	backupHandler := handlers.NewBackupHandler(exportDataUC, restoreBackupUC, tmpl)
+
+	// Recurring rules are applied once at start-up, catching up on due
+	// dates missed while the server was down, and then every hour
+	log.Println("Starting scheduler...")
+	recurringScheduler := scheduler.NewScheduler("recurring transactions", time.Hour, func(at time.Time) error {
+		_, err := runRecurringRulesUC.Execute(application.RunRecurringRulesInput{At: at})
+		return err
+	})
+	go recurringScheduler.Run(context.Background())
 
 	log.Println("Setting up routes...")
 	mux := http.NewServeMux()
@@ -76,11 +276,68 @@ func main() {
 	mux.HandleFunc("/", dashboardHandler.ShowDashboard)
 
 	mux.HandleFunc("/salary", transactionHandler.AddSalary)
+	mux.HandleFunc("/income", transactionHandler.RecordIncome)
 	mux.HandleFunc("/expense", transactionHandler.RecordExpense)
+	mux.HandleFunc("/transaction/row", transactionHandler.TransactionRow)
+	mux.HandleFunc("/transaction/edit-row", transactionHandler.EditTransactionRow)
+	mux.HandleFunc("/transaction/edit", transactionHandler.EditTransaction)
+	mux.HandleFunc("/transaction/delete", transactionHandler.DeleteTransaction)
 	mux.HandleFunc("/loan/borrow", loanHandler.BorrowMoney)
 	mux.HandleFunc("/loan/pay", loanHandler.PayLoan)
+	mux.HandleFunc("/loan/lend", loanHandler.LendMoney)
+	mux.HandleFunc("/loan/collect", loanHandler.CollectRepayment)
+	mux.HandleFunc("/loans", loanHandler.ListLoans)
+	mux.HandleFunc("/loan/history", loanHandler.LoanHistory)
+	mux.HandleFunc("/loan/edit", loanHandler.EditLoan)
+	mux.HandleFunc("/loan/cancel", loanHandler.CancelLoan)
+	mux.HandleFunc("/loan/delete", loanHandler.DeleteLoan)
+	mux.HandleFunc("/loan/payment/undo", loanHandler.UndoPayment)
+	mux.HandleFunc("/people/{name}", personHandler.ShowPerson)
 	mux.HandleFunc("/fixed-charges", fixedChargeHandler.ListFixedCharges)
 	mux.HandleFunc("/fixed-charge/add", fixedChargeHandler.AddFixedCharge)
+	mux.HandleFunc("/fixed-charge/edit", fixedChargeHandler.EditFixedCharge)
+	mux.HandleFunc("/fixed-charge/toggle", fixedChargeHandler.ToggleFixedCharge)
+	mux.HandleFunc("/fixed-charge/delete", fixedChargeHandler.DeleteFixedCharge)
+	mux.HandleFunc("/recurring", recurringHandler.ListRules)
+	mux.HandleFunc("/recurring/add", recurringHandler.AddRule)
+	mux.HandleFunc("/recurring/toggle", recurringHandler.ToggleRule)
+	mux.HandleFunc("/recurring/delete", recurringHandler.DeleteRule)
+	mux.HandleFunc("/budgets", budgetHandler.ListBudgets)
+	mux.HandleFunc("/budget/set", budgetHandler.SetBudget)
+	mux.HandleFunc("/budget/update", budgetHandler.UpdateBudget)
+	mux.HandleFunc("/budget/delete", budgetHandler.DeleteBudget)
+	mux.HandleFunc("/categories", categoryHandler.ListCategories)
+	mux.HandleFunc("/categories/options", categoryHandler.CategoryOptions)
+	mux.HandleFunc("/category/add", categoryHandler.AddCategory)
+	mux.HandleFunc("/category/update", categoryHandler.UpdateCategory)
+	mux.HandleFunc("/category/archive", categoryHandler.ArchiveCategory)
+	mux.HandleFunc("/category/delete", categoryHandler.DeleteCategory)
+	mux.HandleFunc("/category-rules", categoryRuleHandler.ListRules)
+	mux.HandleFunc("/category-rule/add", categoryRuleHandler.AddRule)
+	mux.HandleFunc("/category-rule/priority", categoryRuleHandler.SetPriority)
+	mux.HandleFunc("/category-rule/delete", categoryRuleHandler.DeleteRule)
+	mux.HandleFunc("/category-rules/apply", categoryRuleHandler.ApplyRules)
+	mux.HandleFunc("/accounts", accountHandler.ListAccounts)
+	mux.HandleFunc("/accounts/options", accountHandler.AccountOptions)
+	mux.HandleFunc("/account/add", accountHandler.AddAccount)
+	mux.HandleFunc("/account/update", accountHandler.UpdateAccount)
+	mux.HandleFunc("/account/reconcile", accountHandler.ReconcileAccount)
+	mux.HandleFunc("/transfer", accountHandler.TransferMoney)
+	mux.HandleFunc("/goals", goalHandler.ListGoals)
+	mux.HandleFunc("/goal/add", goalHandler.AddGoal)
+	mux.HandleFunc("/goal/contribute", goalHandler.Contribute)
+	mux.HandleFunc("/goal/delete", goalHandler.DeleteGoal)
+	mux.HandleFunc("/import/statement", importHandler.ImportStatement)
+	mux.HandleFunc("/import/statement-file", importHandler.ImportStatementFile)
+	mux.HandleFunc("/duplicates", duplicateHandler.ListDuplicates)
+	mux.HandleFunc("/duplicate/merge", duplicateHandler.MergeDuplicate)
+	mux.HandleFunc("/duplicate/dismiss", duplicateHandler.DismissDuplicate)
+	mux.HandleFunc("/exchange-rates", exchangeRateHandler.ListRates)
+	mux.HandleFunc("/exchange-rate/add", exchangeRateHandler.AddRate)
+	mux.HandleFunc("/exchange-rates/import", exchangeRateHandler.ImportRates)
+	mux.HandleFunc("/exchange-rate/delete", exchangeRateHandler.DeleteRate)
+	mux.HandleFunc("/export", backupHandler.Export)
+	mux.HandleFunc("/import/backup", backupHandler.Import)
 
 	port := ":9876"
 	log.Printf("✨ Moka is running on http://moka.local%s", port)
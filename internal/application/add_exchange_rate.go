@@ -0,0 +1,74 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/exchange_rate"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AddExchangeRateUseCase struct {
+	rateRepo exchange_rate.Repository
+}
+
+func NewAddExchangeRateUseCase(rateRepo exchange_rate.Repository) *AddExchangeRateUseCase {
+	return &AddExchangeRateUseCase{
+		rateRepo: rateRepo,
+	}
+}
+
+type AddExchangeRateInput struct {
+	Currency      string
+	QuoteCurrency string
+	Rate          string
+	EffectiveOn   time.Time
+}
+
+type AddExchangeRateOutput struct {
+	Rate exchange_rate.Rate
+}
+
+func (uc *AddExchangeRateUseCase) Execute(input AddExchangeRateInput) (*AddExchangeRateOutput, error) {
+	rateObj, err := newExchangeRate(input.Currency, input.QuoteCurrency, input.Rate, input.EffectiveOn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.rateRepo.Save(rateObj); err != nil {
+		return nil, fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+
+	return &AddExchangeRateOutput{
+		Rate: rateObj,
+	}, nil
+}
+
+// newExchangeRate validates raw rate fields shared by manual entry and import
+func newExchangeRate(currency, quoteCurrency, rate string, effectiveOn time.Time) (exchange_rate.Rate, error) {
+	code, err := shared.NormalizeCurrency(currency)
+	if err != nil {
+		return exchange_rate.Rate{}, fmt.Errorf("invalid currency: %w", err)
+	}
+
+	quote, err := shared.NormalizeCurrency(quoteCurrency)
+	if err != nil {
+		return exchange_rate.Rate{}, fmt.Errorf("invalid quote currency: %w", err)
+	}
+
+	if code == quote {
+		return exchange_rate.Rate{}, fmt.Errorf("currency and quote currency must differ: %w", shared.ErrInvalidInput)
+	}
+
+	if effectiveOn.IsZero() {
+		return exchange_rate.Rate{}, fmt.Errorf("effective date cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	value, err := shared.ParseConversionRate(rate)
+	if err != nil {
+		return exchange_rate.Rate{}, fmt.Errorf("invalid rate %q: %w", rate, err)
+	}
+
+	return exchange_rate.NewRate(uuid.New().String(), code, quote, value, effectiveOn), nil
+}
@@ -0,0 +1,67 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AddFixedChargeUseCase struct {
+	fixedChargeRepo fixed_charge.Repository
+}
+
+func NewAddFixedChargeUseCase(fixedChargeRepo fixed_charge.Repository) *AddFixedChargeUseCase {
+	return &AddFixedChargeUseCase{
+		fixedChargeRepo: fixedChargeRepo,
+	}
+}
+
+type AddFixedChargeInput struct {
+	Name        string
+	Amount      string
+	Currency    string
+	Description string
+	StartMonth  *time.Time
+	EndMonth    *time.Time
+	DueDay      int
+}
+
+type AddFixedChargeOutput struct {
+	FixedCharge fixed_charge.FixedCharge
+}
+
+func (uc *AddFixedChargeUseCase) Execute(input AddFixedChargeInput) (*AddFixedChargeOutput, error) {
+	// Validate input
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	money, err := shared.ParseMoney(input.Amount, currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	charge, err := fixed_charge.NewFixedCharge(
+		uuid.New().String(),
+		name,
+		money,
+		input.Description,
+		true,
+	).WithSchedule(input.StartMonth, input.EndMonth, input.DueDay)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.fixedChargeRepo.Save(charge); err != nil {
+		return nil, fmt.Errorf("failed to save fixed charge: %w", err)
+	}
+
+	return &AddFixedChargeOutput{
+		FixedCharge: charge,
+	}, nil
+}
@@ -2,7 +2,6 @@ package application
 
 import (
 	"fmt"
-	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
 	"github.com/aymaneelmaini/moka/internal/domain/transaction"
 	"github.com/aymaneelmaini/moka/internal/shared"
 	"time"
@@ -11,32 +10,35 @@ import (
 )
 
 type AddSalaryUseCase struct {
-	transactionRepo transaction.Repository  
-	fixedChargeRepo fixed_charge.Repository
+	uow       UnitOfWork
+	converter CurrencyConverter
 }
 
 func NewAddSalaryUseCase(
-	transactionRepo transaction.Repository,
-	fixedChargeRepo fixed_charge.Repository,
+	uow UnitOfWork,
+	converter CurrencyConverter,
 ) *AddSalaryUseCase {
 	return &AddSalaryUseCase{
-		transactionRepo: transactionRepo,
-		fixedChargeRepo: fixedChargeRepo,
+		uow:       uow,
+		converter: converter,
 	}
 }
 
 type AddSalaryInput struct {
-	Amount      float64
+	Amount      string
+	Currency    string
 	Description string
 	Date        time.Time
+	// ApplyFixedCharges pays the month's fixed charges out of this salary
+	ApplyFixedCharges bool
+	// AccountID is where the salary is paid; empty for the default account
+	AccountID string
 }
 
 type AddSalaryOutput struct {
-	SalaryTransaction    transaction.Transaction
-	FixedCharges         []fixed_charge.FixedCharge
-	FixedChargesTotal    shared.Money
-	NetAmount            shared.Money
-	ChargeTransactions   []transaction.Transaction
+	SalaryTransaction transaction.Transaction
+	FixedChargeDeduction
+	NetAmount shared.Money
 }
 
 func (uc *AddSalaryUseCase) Execute(input AddSalaryInput) (*AddSalaryOutput, error) {
@@ -45,7 +47,7 @@ func (uc *AddSalaryUseCase) Execute(input AddSalaryInput) (*AddSalaryOutput, err
 		return nil, shared.ErrInvalidInput
 	}
 
-	money, err := shared.NewMoney(input.Amount)
+	money, err := shared.ParseMoney(input.Amount, currencyOrDefault(input.Currency))
 	if err != nil {
 		return nil, fmt.Errorf("invalid salary amount: %w", err)
 	}
@@ -59,44 +61,46 @@ func (uc *AddSalaryUseCase) Execute(input AddSalaryInput) (*AddSalaryOutput, err
 		input.Date,
 	)
 
-	if err := uc.transactionRepo.Save(salaryTx); err != nil {
-		return nil, fmt.Errorf("failed to save salary transaction: %w", err)
+	deduction := FixedChargeDeduction{
+		FixedChargesTotal: shared.ZeroOf(money.Currency()),
 	}
 
-	activeCharges, err := uc.fixedChargeRepo.FindActive()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get fixed charges: %w", err)
-	}
-
-	totalCharges := fixed_charge.CalculateTotalCharges(activeCharges)
+	// The salary and its fixed-charge deductions are recorded all together
+	// or not at all
+	err = uc.uow.Do(func(repos Repositories) error {
+		accountObj, err := resolveAccount(repos.Accounts, input.AccountID)
+		if err != nil {
+			return err
+		}
+		salaryTx = salaryTx.WithAccountID(accountObj.ID())
 
-	var chargeTransactions []transaction.Transaction
-	for _, charge := range activeCharges {
-		category, _ := shared.NewCategory(charge.Name(), shared.CategoryTypeExpense)
+		if err := ensureOpenPeriod(repos.Reconciliations, salaryTx); err != nil {
+			return err
+		}
 
-		chargeTx := transaction.NewTransaction(
-			uuid.New().String(),
-			charge.Amount(),
-			category,
-			fmt.Sprintf("Fixed charge: %s", charge.Description()),
-			transaction.TransactionTypeExpense,
-			input.Date,
-		)
+		if err := repos.Transactions.Save(salaryTx); err != nil {
+			return fmt.Errorf("failed to save salary transaction: %w", err)
+		}
 
-		if err := uc.transactionRepo.Save(chargeTx); err != nil {
-			return nil, fmt.Errorf("failed to save fixed charge transaction: %w", err)
+		if !input.ApplyFixedCharges {
+			return nil
 		}
 
-		chargeTransactions = append(chargeTransactions, chargeTx)
+		deduction, err = deductFixedCharges(repos, uc.converter, accountObj.ID(), money.Currency(), input.Date)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	netAmount := money.Subtract(totalCharges)
+	netAmount, err := money.Subtract(deduction.FixedChargesTotal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute net amount: %w", err)
+	}
 
 	return &AddSalaryOutput{
-		SalaryTransaction:  salaryTx,
-		FixedCharges:       activeCharges,
-		FixedChargesTotal:  totalCharges,
-		NetAmount:          netAmount,
-		ChargeTransactions: chargeTransactions,
+		SalaryTransaction:    salaryTx,
+		FixedChargeDeduction: deduction,
+		NetAmount:            netAmount,
 	}, nil
 }
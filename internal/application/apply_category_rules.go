@@ -0,0 +1,94 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"time"
+)
+
+// ApplyCategoryRulesUseCase runs the category rules again over past
+// transactions, e.g. after adding a rule. Only plain income and expenses
+// are recategorized: loan entries and fixed charge deductions keep their
+// category, and reconciled transactions are locked.
+type ApplyCategoryRulesUseCase struct {
+	ruleRepo     categorization.Repository
+	categoryRepo category.Repository
+	uow          UnitOfWork
+}
+
+func NewApplyCategoryRulesUseCase(
+	ruleRepo categorization.Repository,
+	categoryRepo category.Repository,
+	uow UnitOfWork,
+) *ApplyCategoryRulesUseCase {
+	return &ApplyCategoryRulesUseCase{
+		ruleRepo:     ruleRepo,
+		categoryRepo: categoryRepo,
+		uow:          uow,
+	}
+}
+
+// ApplyCategoryRulesInput limits the run to the transactions made on or
+// after Since; nil covers the whole history
+type ApplyCategoryRulesInput struct {
+	Since *time.Time
+}
+
+// ApplyCategoryRulesOutput lists the transactions that changed category and
+// counts the reconciled ones a rule would have changed
+type ApplyCategoryRulesOutput struct {
+	Changed []transaction.Transaction
+	Locked  int
+}
+
+func (uc *ApplyCategoryRulesUseCase) Execute(input ApplyCategoryRulesInput) (*ApplyCategoryRulesOutput, error) {
+	rules, err := activeCategoryRules(uc.ruleRepo, uc.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ApplyCategoryRulesOutput{}
+	if len(rules) == 0 {
+		return output, nil
+	}
+
+	err = uc.uow.Do(func(repos Repositories) error {
+		transactions, err := repos.Transactions.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get transactions: %w", err)
+		}
+
+		for _, tx := range transactions {
+			if input.Since != nil && tx.CreatedAt().Before(*input.Since) {
+				continue
+			}
+			if tx.IsLoanRelated() || tx.IsFixedCharge() {
+				continue
+			}
+
+			categorized, ok := categorize(rules, tx)
+			if !ok || categorized.Category() == tx.Category() {
+				continue
+			}
+
+			if tx.IsReconciled() {
+				output.Locked++
+				continue
+			}
+
+			if err := repos.Transactions.Update(categorized); err != nil {
+				return fmt.Errorf("failed to update transaction: %w", err)
+			}
+			output.Changed = append(output.Changed, categorized)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
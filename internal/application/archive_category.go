@@ -0,0 +1,64 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// ArchiveCategoryUseCase hides a category from new entries while keeping it
+// on past transactions, or restores it
+type ArchiveCategoryUseCase struct {
+	categoryRepo category.Repository
+}
+
+func NewArchiveCategoryUseCase(categoryRepo category.Repository) *ArchiveCategoryUseCase {
+	return &ArchiveCategoryUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+type ArchiveCategoryInput struct {
+	CategoryID string
+	Archived   bool
+}
+
+type ArchiveCategoryOutput struct {
+	Category category.Category
+}
+
+func (uc *ArchiveCategoryUseCase) Execute(input ArchiveCategoryInput) (*ArchiveCategoryOutput, error) {
+	// Validate input
+	if input.CategoryID == "" {
+		return nil, fmt.Errorf("category ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	categoryObj, err := uc.categoryRepo.FindByID(input.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+
+	// Salary and loans are recorded under these built-ins automatically
+	if input.Archived && isSystemCategory(categoryObj.Name()) {
+		return nil, fmt.Errorf("category %q is used by the app and cannot be archived: %w", categoryObj.Name(), shared.ErrInvalidInput)
+	}
+
+	updated := categoryObj.Unarchive()
+	if input.Archived {
+		updated = categoryObj.Archive()
+	}
+
+	if err := uc.categoryRepo.Update(updated); err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return &ArchiveCategoryOutput{
+		Category: updated,
+	}, nil
+}
+
+func isSystemCategory(name string) bool {
+	return name == shared.CategorySalary.Name() ||
+		name == shared.CategoryBorrowed.Name() ||
+		name == shared.CategoryLent.Name()
+}
@@ -0,0 +1,649 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/domain/budget"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/domain/exchange_rate"
+	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
+	"github.com/aymaneelmaini/moka/internal/domain/goal"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/domain/recurring"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+// BackupVersion is the version of the backup document written by this
+// build. It is raised whenever a change to the document would make older
+// builds misread it; backups of a later version are refused.
+const BackupVersion = 1
+
+// Backup is the whole of the data as one document, the way it is exported
+// and restored. Amounts are decimal strings in the currency next to them, so
+// the document reads the same in JSON and in CSV.
+type Backup struct {
+	Version              int                         `json:"version"`
+	ExportedAt           time.Time                   `json:"exported_at"`
+	Accounts             []BackupAccount             `json:"accounts"`
+	Categories           []BackupCategory            `json:"categories"`
+	Transactions         []BackupTransaction         `json:"transactions"`
+	Budgets              []BackupBudget              `json:"budgets"`
+	FixedCharges         []BackupFixedCharge         `json:"fixed_charges"`
+	Loans                []BackupLoan                `json:"loans"`
+	LoanPayments         []BackupLoanPayment         `json:"loan_payments"`
+	LoanInstallments     []BackupLoanInstallment     `json:"loan_installments"`
+	Goals                []BackupGoal                `json:"goals"`
+	Reconciliations      []BackupReconciliation      `json:"reconciliations"`
+	RecurringRules       []BackupRecurringRule       `json:"recurring_rules"`
+	RecurringOccurrences []BackupRecurringOccurrence `json:"recurring_occurrences"`
+	CategoryRules        []BackupCategoryRule        `json:"category_rules"`
+	ExchangeRates        []BackupExchangeRate        `json:"exchange_rates"`
+	Dismissals           []BackupDismissal           `json:"duplicate_dismissals"`
+	StatementAccounts    []BackupStatementAccount    `json:"statement_accounts"`
+}
+
+type BackupAccount struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type BackupCategory struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	ParentID string `json:"parent_id,omitempty"`
+	Color    string `json:"color,omitempty"`
+	Icon     string `json:"icon,omitempty"`
+	Archived bool   `json:"archived"`
+	Builtin  bool   `json:"builtin"`
+}
+
+type BackupTransaction struct {
+	ID               string    `json:"id"`
+	Type             string    `json:"type"`
+	Amount           string    `json:"amount"`
+	Currency         string    `json:"currency"`
+	CategoryName     string    `json:"category_name"`
+	CategoryType     string    `json:"category_type"`
+	Description      string    `json:"description"`
+	Date             time.Time `json:"date"`
+	AccountID        string    `json:"account_id"`
+	LoanID           string    `json:"loan_id,omitempty"`
+	FixedChargeID    string    `json:"fixed_charge_id,omitempty"`
+	TransferID       string    `json:"transfer_id,omitempty"`
+	ReconciliationID string    `json:"reconciliation_id,omitempty"`
+	GoalID           string    `json:"goal_id,omitempty"`
+	FITID            string    `json:"fitid,omitempty"`
+}
+
+type BackupBudget struct {
+	ID           string `json:"id"`
+	CategoryName string `json:"category_name"`
+	Limit        string `json:"limit"`
+	Currency     string `json:"currency"`
+	Year         int    `json:"year"`
+	Month        int    `json:"month"`
+}
+
+type BackupFixedCharge struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name"`
+	Amount      string     `json:"amount"`
+	Currency    string     `json:"currency"`
+	Description string     `json:"description"`
+	Active      bool       `json:"active"`
+	StartMonth  *time.Time `json:"start_month,omitempty"`
+	EndMonth    *time.Time `json:"end_month,omitempty"`
+	DueDay      int        `json:"due_day"`
+}
+
+type BackupLoan struct {
+	ID          string     `json:"id"`
+	Person      string     `json:"person"`
+	Direction   string     `json:"direction"`
+	Amount      string     `json:"amount"`
+	AmountPaid  string     `json:"amount_paid"`
+	Currency    string     `json:"currency"`
+	BorrowedAt  time.Time  `json:"borrowed_at"`
+	PaidBackAt  *time.Time `json:"paid_back_at,omitempty"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	Status      string     `json:"status"`
+	Description string     `json:"description"`
+}
+
+type BackupLoanPayment struct {
+	ID            string    `json:"id"`
+	LoanID        string    `json:"loan_id"`
+	TransactionID string    `json:"transaction_id,omitempty"`
+	Amount        string    `json:"amount"`
+	Currency      string    `json:"currency"`
+	PaidAt        time.Time `json:"paid_at"`
+}
+
+type BackupLoanInstallment struct {
+	ID       string    `json:"id"`
+	LoanID   string    `json:"loan_id"`
+	Number   int       `json:"number"`
+	DueDate  time.Time `json:"due_date"`
+	Amount   string    `json:"amount"`
+	Currency string    `json:"currency"`
+}
+
+type BackupGoal struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Target    string     `json:"target"`
+	Currency  string     `json:"currency"`
+	Deadline  *time.Time `json:"deadline,omitempty"`
+	AccountID string     `json:"account_id"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type BackupReconciliation struct {
+	ID                      string    `json:"id"`
+	AccountID               string    `json:"account_id"`
+	StatementDate           time.Time `json:"statement_date"`
+	StatementBalance        string    `json:"statement_balance"`
+	ComputedBalance         string    `json:"computed_balance"`
+	Currency                string    `json:"currency"`
+	AdjustmentTransactionID string    `json:"adjustment_transaction_id,omitempty"`
+	CreatedAt               time.Time `json:"created_at"`
+}
+
+type BackupRecurringRule struct {
+	ID           string     `json:"id"`
+	Description  string     `json:"description"`
+	Amount       string     `json:"amount"`
+	Currency     string     `json:"currency"`
+	CategoryName string     `json:"category_name"`
+	CategoryType string     `json:"category_type"`
+	Frequency    string     `json:"frequency"`
+	Interval     int        `json:"interval"`
+	DayOfMonth   int        `json:"day_of_month"`
+	StartDate    time.Time  `json:"start_date"`
+	EndDate      *time.Time `json:"end_date,omitempty"`
+	Active       bool       `json:"active"`
+}
+
+type BackupRecurringOccurrence struct {
+	ID            string    `json:"id"`
+	RuleID        string    `json:"rule_id"`
+	DueDate       time.Time `json:"due_date"`
+	TransactionID string    `json:"transaction_id,omitempty"`
+}
+
+type BackupCategoryRule struct {
+	ID           string    `json:"id"`
+	Priority     int       `json:"priority"`
+	Contains     string    `json:"contains,omitempty"`
+	Pattern      string    `json:"pattern,omitempty"`
+	Counterparty string    `json:"counterparty,omitempty"`
+	MinAmount    string    `json:"min_amount,omitempty"`
+	MaxAmount    string    `json:"max_amount,omitempty"`
+	Currency     string    `json:"currency,omitempty"`
+	CategoryName string    `json:"category_name"`
+	CategoryType string    `json:"category_type"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type BackupExchangeRate struct {
+	ID            string    `json:"id"`
+	Currency      string    `json:"currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Rate          string    `json:"rate"`
+	EffectiveOn   time.Time `json:"effective_on"`
+}
+
+type BackupDismissal struct {
+	TransactionID string `json:"transaction_id"`
+	OtherID       string `json:"other_id"`
+}
+
+type BackupStatementAccount struct {
+	Number    string `json:"number"`
+	AccountID string `json:"account_id"`
+}
+
+// restoreMoney reads an amount of the backup. Zero and negative amounts are
+// kept, as stored balances and amounts paid can be either.
+func restoreMoney(amount string, currency string) (shared.Money, error) {
+	code, err := shared.NormalizeCurrency(currency)
+	if err != nil {
+		return shared.Money{}, err
+	}
+
+	minorUnits, err := shared.ParseMinorUnits(amount)
+	if err != nil {
+		return shared.Money{}, fmt.Errorf("invalid amount %q: %w", amount, err)
+	}
+
+	return shared.UnsafeNewMoney(minorUnits, code), nil
+}
+
+func backupAccount(a account.Account) BackupAccount {
+	return BackupAccount{
+		ID:   a.ID(),
+		Name: a.Name(),
+		Type: string(a.Type()),
+	}
+}
+
+func (r BackupAccount) restore() (account.Account, error) {
+	return account.NewAccount(r.ID, r.Name, account.AccountType(r.Type))
+}
+
+func backupCategory(c category.Category) BackupCategory {
+	return BackupCategory{
+		ID:       c.ID(),
+		Name:     c.Name(),
+		Type:     string(c.Type()),
+		ParentID: c.ParentID(),
+		Color:    c.Color(),
+		Icon:     c.Icon(),
+		Archived: c.IsArchived(),
+		Builtin:  c.IsBuiltin(),
+	}
+}
+
+func (r BackupCategory) restore() (category.Category, error) {
+	if _, err := shared.NewCategory(r.Name, shared.CategoryType(r.Type)); err != nil {
+		return category.Category{}, fmt.Errorf("%v: %w", err, shared.ErrInvalidInput)
+	}
+
+	return category.NewCategory(r.ID, r.Name, shared.CategoryType(r.Type), r.ParentID, r.Color, r.Icon, r.Archived, r.Builtin), nil
+}
+
+func backupTransaction(tx transaction.Transaction) BackupTransaction {
+	return BackupTransaction{
+		ID:               tx.ID(),
+		Type:             string(tx.Type()),
+		Amount:           tx.Amount().Decimal(),
+		Currency:         tx.Amount().Currency(),
+		CategoryName:     tx.Category().Name(),
+		CategoryType:     string(tx.Category().Type()),
+		Description:      tx.Description(),
+		Date:             tx.CreatedAt(),
+		AccountID:        tx.AccountID(),
+		LoanID:           tx.LoanID(),
+		FixedChargeID:    tx.FixedChargeID(),
+		TransferID:       tx.TransferID(),
+		ReconciliationID: tx.ReconciliationID(),
+		GoalID:           tx.GoalID(),
+		FITID:            tx.FITID(),
+	}
+}
+
+func (r BackupTransaction) restore() (transaction.Transaction, error) {
+	typ := transaction.TransactionType(r.Type)
+	switch typ {
+	case transaction.TransactionTypeIncome, transaction.TransactionTypeExpense,
+		transaction.TransactionTypeTransferIn, transaction.TransactionTypeTransferOut:
+	default:
+		return transaction.Transaction{}, fmt.Errorf("unknown transaction type %q: %w", r.Type, shared.ErrInvalidInput)
+	}
+
+	amount, err := restoreMoney(r.Amount, r.Currency)
+	if err != nil {
+		return transaction.Transaction{}, err
+	}
+
+	txCategory, err := shared.NewCategory(r.CategoryName, shared.CategoryType(r.CategoryType))
+	if err != nil {
+		return transaction.Transaction{}, fmt.Errorf("%v: %w", err, shared.ErrInvalidInput)
+	}
+
+	return transaction.NewTransaction(
+		r.ID,
+		amount,
+		txCategory,
+		r.Description,
+		typ,
+		r.Date,
+	).WithLoanID(r.LoanID).
+		WithFixedChargeID(r.FixedChargeID).
+		WithAccountID(r.AccountID).
+		WithTransferID(r.TransferID).
+		WithReconciliationID(r.ReconciliationID).
+		WithGoalID(r.GoalID).
+		WithFITID(r.FITID), nil
+}
+
+func backupBudget(b budget.Budget) BackupBudget {
+	return BackupBudget{
+		ID:           b.ID(),
+		CategoryName: b.Category().Name(),
+		Limit:        b.Limit().Decimal(),
+		Currency:     b.Limit().Currency(),
+		Year:         b.Year(),
+		Month:        int(b.Month()),
+	}
+}
+
+func (r BackupBudget) restore() (budget.Budget, error) {
+	budgetCategory, err := shared.NewCategory(r.CategoryName, shared.CategoryTypeExpense)
+	if err != nil {
+		return budget.Budget{}, fmt.Errorf("%v: %w", err, shared.ErrInvalidInput)
+	}
+
+	limit, err := restoreMoney(r.Limit, r.Currency)
+	if err != nil {
+		return budget.Budget{}, err
+	}
+
+	if r.Month < 1 || r.Month > 12 {
+		return budget.Budget{}, fmt.Errorf("invalid month %d: %w", r.Month, shared.ErrInvalidInput)
+	}
+
+	return budget.NewBudget(r.ID, budgetCategory, limit, time.Month(r.Month), r.Year), nil
+}
+
+func backupFixedCharge(fc fixed_charge.FixedCharge) BackupFixedCharge {
+	return BackupFixedCharge{
+		ID:          fc.ID(),
+		Name:        fc.Name(),
+		Amount:      fc.Amount().Decimal(),
+		Currency:    fc.Amount().Currency(),
+		Description: fc.Description(),
+		Active:      fc.IsActive(),
+		StartMonth:  fc.StartMonth(),
+		EndMonth:    fc.EndMonth(),
+		DueDay:      fc.DueDay(),
+	}
+}
+
+func (r BackupFixedCharge) restore() (fixed_charge.FixedCharge, error) {
+	amount, err := restoreMoney(r.Amount, r.Currency)
+	if err != nil {
+		return fixed_charge.FixedCharge{}, err
+	}
+
+	return fixed_charge.NewFixedCharge(r.ID, r.Name, amount, r.Description, r.Active).
+		WithSchedule(r.StartMonth, r.EndMonth, r.DueDay)
+}
+
+func backupLoan(l loan.Loan) BackupLoan {
+	return BackupLoan{
+		ID:          l.ID(),
+		Person:      l.LenderName(),
+		Direction:   string(l.Direction()),
+		Amount:      l.Amount().Decimal(),
+		AmountPaid:  l.AmountPaid().Decimal(),
+		Currency:    l.Amount().Currency(),
+		BorrowedAt:  l.BorrowedAt(),
+		PaidBackAt:  l.PaidBackAt(),
+		DueDate:     l.DueDate(),
+		Status:      string(l.Status()),
+		Description: l.Description(),
+	}
+}
+
+func (r BackupLoan) restore() (loan.Loan, error) {
+	direction := loan.LoanDirection(r.Direction)
+	if direction != loan.LoanDirectionBorrowed && direction != loan.LoanDirectionLent {
+		return loan.Loan{}, fmt.Errorf("unknown loan direction %q: %w", r.Direction, shared.ErrInvalidInput)
+	}
+
+	status := loan.LoanStatus(r.Status)
+	switch status {
+	case loan.LoanStatusActive, loan.LoanStatusPaidBack, loan.LoanStatusCancelled:
+	default:
+		return loan.Loan{}, fmt.Errorf("unknown loan status %q: %w", r.Status, shared.ErrInvalidInput)
+	}
+
+	amount, err := restoreMoney(r.Amount, r.Currency)
+	if err != nil {
+		return loan.Loan{}, err
+	}
+	amountPaid, err := restoreMoney(r.AmountPaid, r.Currency)
+	if err != nil {
+		return loan.Loan{}, err
+	}
+
+	return loan.RestoreLoan(
+		r.ID,
+		r.Person,
+		direction,
+		amount,
+		amountPaid,
+		r.BorrowedAt,
+		r.PaidBackAt,
+		r.DueDate,
+		status,
+		r.Description,
+	), nil
+}
+
+func backupLoanPayment(p loan.Payment) BackupLoanPayment {
+	return BackupLoanPayment{
+		ID:            p.ID(),
+		LoanID:        p.LoanID(),
+		TransactionID: p.TransactionID(),
+		Amount:        p.Amount().Decimal(),
+		Currency:      p.Amount().Currency(),
+		PaidAt:        p.PaidAt(),
+	}
+}
+
+func (r BackupLoanPayment) restore() (loan.Payment, error) {
+	amount, err := restoreMoney(r.Amount, r.Currency)
+	if err != nil {
+		return loan.Payment{}, err
+	}
+
+	return loan.NewPayment(r.ID, r.LoanID, r.TransactionID, amount, r.PaidAt), nil
+}
+
+func backupLoanInstallment(i loan.Installment) BackupLoanInstallment {
+	return BackupLoanInstallment{
+		ID:       i.ID(),
+		LoanID:   i.LoanID(),
+		Number:   i.Number(),
+		DueDate:  i.DueDate(),
+		Amount:   i.Amount().Decimal(),
+		Currency: i.Amount().Currency(),
+	}
+}
+
+func (r BackupLoanInstallment) restore() (loan.Installment, error) {
+	amount, err := restoreMoney(r.Amount, r.Currency)
+	if err != nil {
+		return loan.Installment{}, err
+	}
+
+	return loan.NewInstallment(r.ID, r.LoanID, r.Number, r.DueDate, amount), nil
+}
+
+func backupGoal(g goal.Goal) BackupGoal {
+	return BackupGoal{
+		ID:        g.ID(),
+		Name:      g.Name(),
+		Target:    g.Target().Decimal(),
+		Currency:  g.Target().Currency(),
+		Deadline:  g.Deadline(),
+		AccountID: g.AccountID(),
+		CreatedAt: g.CreatedAt(),
+	}
+}
+
+func (r BackupGoal) restore() (goal.Goal, error) {
+	target, err := restoreMoney(r.Target, r.Currency)
+	if err != nil {
+		return goal.Goal{}, err
+	}
+
+	return goal.NewGoal(r.ID, r.Name, target, r.Deadline, r.AccountID, r.CreatedAt)
+}
+
+func backupReconciliation(rec account.Reconciliation) BackupReconciliation {
+	return BackupReconciliation{
+		ID:                      rec.ID(),
+		AccountID:               rec.AccountID(),
+		StatementDate:           rec.StatementDate(),
+		StatementBalance:        rec.StatementBalance().Decimal(),
+		ComputedBalance:         rec.ComputedBalance().Decimal(),
+		Currency:                rec.StatementBalance().Currency(),
+		AdjustmentTransactionID: rec.AdjustmentTransactionID(),
+		CreatedAt:               rec.CreatedAt(),
+	}
+}
+
+func (r BackupReconciliation) restore() (account.Reconciliation, error) {
+	statementBalance, err := restoreMoney(r.StatementBalance, r.Currency)
+	if err != nil {
+		return account.Reconciliation{}, err
+	}
+	computedBalance, err := restoreMoney(r.ComputedBalance, r.Currency)
+	if err != nil {
+		return account.Reconciliation{}, err
+	}
+
+	return account.NewReconciliation(
+		r.ID,
+		r.AccountID,
+		r.StatementDate,
+		statementBalance,
+		computedBalance,
+		r.AdjustmentTransactionID,
+		r.CreatedAt,
+	), nil
+}
+
+func backupRecurringRule(rule recurring.Rule) BackupRecurringRule {
+	return BackupRecurringRule{
+		ID:           rule.ID(),
+		Description:  rule.Description(),
+		Amount:       rule.Amount().Decimal(),
+		Currency:     rule.Amount().Currency(),
+		CategoryName: rule.Category().Name(),
+		CategoryType: string(rule.Category().Type()),
+		Frequency:    string(rule.Frequency()),
+		Interval:     rule.Interval(),
+		DayOfMonth:   rule.DayOfMonth(),
+		StartDate:    rule.StartDate(),
+		EndDate:      rule.EndDate(),
+		Active:       rule.IsActive(),
+	}
+}
+
+func (r BackupRecurringRule) restore() (recurring.Rule, error) {
+	amount, err := restoreMoney(r.Amount, r.Currency)
+	if err != nil {
+		return recurring.Rule{}, err
+	}
+
+	ruleCategory, err := shared.NewCategory(r.CategoryName, shared.CategoryType(r.CategoryType))
+	if err != nil {
+		return recurring.Rule{}, fmt.Errorf("%v: %w", err, shared.ErrInvalidInput)
+	}
+
+	return recurring.NewRule(
+		r.ID,
+		r.Description,
+		amount,
+		ruleCategory,
+		recurring.Frequency(r.Frequency),
+		r.Interval,
+		r.DayOfMonth,
+		r.StartDate,
+		r.EndDate,
+		r.Active,
+	)
+}
+
+func backupRecurringOccurrence(o recurring.Occurrence) BackupRecurringOccurrence {
+	return BackupRecurringOccurrence{
+		ID:            o.ID(),
+		RuleID:        o.RuleID(),
+		DueDate:       o.DueDate(),
+		TransactionID: o.TransactionID(),
+	}
+}
+
+func (r BackupRecurringOccurrence) restore() recurring.Occurrence {
+	return recurring.NewOccurrence(r.ID, r.RuleID, r.DueDate, r.TransactionID)
+}
+
+func backupCategoryRule(rule categorization.Rule) BackupCategoryRule {
+	record := BackupCategoryRule{
+		ID:           rule.ID(),
+		Priority:     rule.Priority(),
+		Contains:     rule.Contains(),
+		Pattern:      rule.Pattern(),
+		Counterparty: rule.Counterparty(),
+		CategoryName: rule.Category().Name(),
+		CategoryType: string(rule.Category().Type()),
+		CreatedAt:    rule.CreatedAt(),
+	}
+	if m := rule.MinAmount(); m != nil {
+		record.MinAmount = m.Decimal()
+		record.Currency = m.Currency()
+	}
+	if m := rule.MaxAmount(); m != nil {
+		record.MaxAmount = m.Decimal()
+		record.Currency = m.Currency()
+	}
+	return record
+}
+
+func (r BackupCategoryRule) restore() (categorization.Rule, error) {
+	var bounds [2]*shared.Money
+	for i, amount := range []string{r.MinAmount, r.MaxAmount} {
+		if amount == "" {
+			continue
+		}
+		money, err := restoreMoney(amount, r.Currency)
+		if err != nil {
+			return categorization.Rule{}, err
+		}
+		bounds[i] = &money
+	}
+
+	ruleCategory, err := shared.NewCategory(r.CategoryName, shared.CategoryType(r.CategoryType))
+	if err != nil {
+		return categorization.Rule{}, fmt.Errorf("%v: %w", err, shared.ErrInvalidInput)
+	}
+
+	return categorization.NewRule(
+		r.ID,
+		r.Priority,
+		r.Contains,
+		r.Pattern,
+		r.Counterparty,
+		bounds[0],
+		bounds[1],
+		ruleCategory,
+		r.CreatedAt,
+	)
+}
+
+func backupExchangeRate(rate exchange_rate.Rate) BackupExchangeRate {
+	return BackupExchangeRate{
+		ID:            rate.ID(),
+		Currency:      rate.Currency(),
+		QuoteCurrency: rate.QuoteCurrency(),
+		Rate:          rate.Rate().String(),
+		EffectiveOn:   rate.EffectiveOn(),
+	}
+}
+
+func (r BackupExchangeRate) restore() (exchange_rate.Rate, error) {
+	currency, err := shared.NormalizeCurrency(r.Currency)
+	if err != nil {
+		return exchange_rate.Rate{}, err
+	}
+	quoteCurrency, err := shared.NormalizeCurrency(r.QuoteCurrency)
+	if err != nil {
+		return exchange_rate.Rate{}, err
+	}
+
+	rate, err := shared.ParseConversionRate(r.Rate)
+	if err != nil {
+		return exchange_rate.Rate{}, fmt.Errorf("invalid rate %q: %w", r.Rate, err)
+	}
+
+	return exchange_rate.NewRate(r.ID, currency, quoteCurrency, rate, r.EffectiveOn), nil
+}
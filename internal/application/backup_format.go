@@ -0,0 +1,328 @@
+package application
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"io"
+	"strconv"
+	"time"
+)
+
+// BackupFormat is a way of writing a backup out
+type BackupFormat string
+
+const (
+	// BackupFormatJSON is the whole backup as one document, the one that is
+	// restored
+	BackupFormatJSON BackupFormat = "json"
+	// BackupFormatCSV is one table of the backup, for spreadsheets
+	BackupFormatCSV BackupFormat = "csv"
+	// BackupFormatArchive is a zip of the JSON document and of every table as
+	// CSV
+	BackupFormatArchive BackupFormat = "zip"
+)
+
+// backupDocumentName is the JSON document inside an archive
+const backupDocumentName = "backup.json"
+
+// BackupTable is one kind of record of a backup as a CSV table. The columns
+// are named after the JSON fields.
+type BackupTable struct {
+	Name   string
+	Header []string
+	rows   func(b *Backup) [][]string
+}
+
+// BackupTables lists the tables of a backup, in the order they are archived
+var BackupTables = []BackupTable{
+	{
+		Name:   "accounts",
+		Header: []string{"id", "name", "type"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.Accounts {
+				rows = append(rows, []string{r.ID, r.Name, r.Type})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "categories",
+		Header: []string{"id", "name", "type", "parent_id", "color", "icon", "archived", "builtin"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.Categories {
+				rows = append(rows, []string{r.ID, r.Name, r.Type, r.ParentID, r.Color, r.Icon, csvBool(r.Archived), csvBool(r.Builtin)})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "transactions",
+		Header: []string{"id", "type", "amount", "currency", "category_name", "category_type", "description", "date", "account_id", "loan_id", "fixed_charge_id", "transfer_id", "reconciliation_id", "goal_id", "fitid"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.Transactions {
+				rows = append(rows, []string{r.ID, r.Type, r.Amount, r.Currency, r.CategoryName, r.CategoryType, r.Description, csvTime(r.Date), r.AccountID, r.LoanID, r.FixedChargeID, r.TransferID, r.ReconciliationID, r.GoalID, r.FITID})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "budgets",
+		Header: []string{"id", "category_name", "limit", "currency", "year", "month"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.Budgets {
+				rows = append(rows, []string{r.ID, r.CategoryName, r.Limit, r.Currency, strconv.Itoa(r.Year), strconv.Itoa(r.Month)})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "fixed_charges",
+		Header: []string{"id", "name", "amount", "currency", "description", "active", "start_month", "end_month", "due_day"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.FixedCharges {
+				rows = append(rows, []string{r.ID, r.Name, r.Amount, r.Currency, r.Description, csvBool(r.Active), csvOptionalTime(r.StartMonth), csvOptionalTime(r.EndMonth), strconv.Itoa(r.DueDay)})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "loans",
+		Header: []string{"id", "person", "direction", "amount", "amount_paid", "currency", "borrowed_at", "paid_back_at", "due_date", "status", "description"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.Loans {
+				rows = append(rows, []string{r.ID, r.Person, r.Direction, r.Amount, r.AmountPaid, r.Currency, csvTime(r.BorrowedAt), csvOptionalTime(r.PaidBackAt), csvOptionalTime(r.DueDate), r.Status, r.Description})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "loan_payments",
+		Header: []string{"id", "loan_id", "transaction_id", "amount", "currency", "paid_at"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.LoanPayments {
+				rows = append(rows, []string{r.ID, r.LoanID, r.TransactionID, r.Amount, r.Currency, csvTime(r.PaidAt)})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "loan_installments",
+		Header: []string{"id", "loan_id", "number", "due_date", "amount", "currency"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.LoanInstallments {
+				rows = append(rows, []string{r.ID, r.LoanID, strconv.Itoa(r.Number), csvTime(r.DueDate), r.Amount, r.Currency})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "goals",
+		Header: []string{"id", "name", "target", "currency", "deadline", "account_id", "created_at"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.Goals {
+				rows = append(rows, []string{r.ID, r.Name, r.Target, r.Currency, csvOptionalTime(r.Deadline), r.AccountID, csvTime(r.CreatedAt)})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "reconciliations",
+		Header: []string{"id", "account_id", "statement_date", "statement_balance", "computed_balance", "currency", "adjustment_transaction_id", "created_at"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.Reconciliations {
+				rows = append(rows, []string{r.ID, r.AccountID, csvTime(r.StatementDate), r.StatementBalance, r.ComputedBalance, r.Currency, r.AdjustmentTransactionID, csvTime(r.CreatedAt)})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "recurring_rules",
+		Header: []string{"id", "description", "amount", "currency", "category_name", "category_type", "frequency", "interval", "day_of_month", "start_date", "end_date", "active"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.RecurringRules {
+				rows = append(rows, []string{r.ID, r.Description, r.Amount, r.Currency, r.CategoryName, r.CategoryType, r.Frequency, strconv.Itoa(r.Interval), strconv.Itoa(r.DayOfMonth), csvTime(r.StartDate), csvOptionalTime(r.EndDate), csvBool(r.Active)})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "recurring_occurrences",
+		Header: []string{"id", "rule_id", "due_date", "transaction_id"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.RecurringOccurrences {
+				rows = append(rows, []string{r.ID, r.RuleID, csvTime(r.DueDate), r.TransactionID})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "category_rules",
+		Header: []string{"id", "priority", "contains", "pattern", "counterparty", "min_amount", "max_amount", "currency", "category_name", "category_type", "created_at"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.CategoryRules {
+				rows = append(rows, []string{r.ID, strconv.Itoa(r.Priority), r.Contains, r.Pattern, r.Counterparty, r.MinAmount, r.MaxAmount, r.Currency, r.CategoryName, r.CategoryType, csvTime(r.CreatedAt)})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "exchange_rates",
+		Header: []string{"id", "currency", "quote_currency", "rate", "effective_on"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.ExchangeRates {
+				rows = append(rows, []string{r.ID, r.Currency, r.QuoteCurrency, r.Rate, csvTime(r.EffectiveOn)})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "duplicate_dismissals",
+		Header: []string{"transaction_id", "other_id"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.Dismissals {
+				rows = append(rows, []string{r.TransactionID, r.OtherID})
+			}
+			return rows
+		},
+	},
+	{
+		Name:   "statement_accounts",
+		Header: []string{"number", "account_id"},
+		rows: func(b *Backup) [][]string {
+			var rows [][]string
+			for _, r := range b.StatementAccounts {
+				rows = append(rows, []string{r.Number, r.AccountID})
+			}
+			return rows
+		},
+	},
+}
+
+// FindBackupTable returns the table of the given name
+func FindBackupTable(name string) (BackupTable, error) {
+	for _, table := range BackupTables {
+		if table.Name == name {
+			return table, nil
+		}
+	}
+	return BackupTable{}, fmt.Errorf("unknown table %q: %w", name, shared.ErrInvalidInput)
+}
+
+// WriteCSV writes the table of the backup with a header line
+func (t BackupTable) WriteCSV(w io.Writer, b *Backup) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(t.Header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", t.Name, err)
+	}
+	if err := writer.WriteAll(t.rows(b)); err != nil {
+		return fmt.Errorf("failed to write %s: %w", t.Name, err)
+	}
+	return nil
+}
+
+// WriteBackupJSON writes the backup as one JSON document
+func WriteBackupJSON(w io.Writer, b *Backup) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(b); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return nil
+}
+
+// WriteBackupArchive writes a zip holding the JSON document, which is what
+// gets restored, and every table as CSV next to it
+func WriteBackupArchive(w io.Writer, b *Backup) error {
+	archive := zip.NewWriter(w)
+
+	file, err := archive.CreateHeader(&zip.FileHeader{Name: backupDocumentName, Method: zip.Deflate, Modified: b.ExportedAt})
+	if err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := WriteBackupJSON(file, b); err != nil {
+		return err
+	}
+
+	for _, table := range BackupTables {
+		file, err := archive.CreateHeader(&zip.FileHeader{Name: table.Name + ".csv", Method: zip.Deflate, Modified: b.ExportedAt})
+		if err != nil {
+			return fmt.Errorf("failed to write backup: %w", err)
+		}
+		if err := table.WriteCSV(file, b); err != nil {
+			return err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return fmt.Errorf("failed to write backup: %w", err)
+	}
+	return nil
+}
+
+// ReadBackup reads a backup written as a JSON document or as an archive,
+// telling the two apart by their content
+func ReadBackup(data []byte) (*Backup, error) {
+	if bytes.HasPrefix(data, []byte("PK\x03\x04")) {
+		return readBackupArchive(data)
+	}
+
+	var b Backup
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("not a moka backup: %v: %w", err, shared.ErrInvalidInput)
+	}
+	return &b, nil
+}
+
+func readBackupArchive(data []byte) (*Backup, error) {
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("not a moka backup: %v: %w", err, shared.ErrInvalidInput)
+	}
+
+	file, err := archive.Open(backupDocumentName)
+	if err != nil {
+		return nil, fmt.Errorf("archive holds no %s: %w", backupDocumentName, shared.ErrInvalidInput)
+	}
+	defer file.Close()
+
+	document, err := io.ReadAll(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", backupDocumentName, err)
+	}
+
+	return ReadBackup(document)
+}
+
+func csvTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
+
+func csvOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return csvTime(*t)
+}
+
+func csvBool(b bool) string {
+	return strconv.FormatBool(b)
+}
@@ -11,25 +11,25 @@ import (
 )
 
 type BorrowMoneyUseCase struct {
-	loanRepo        loan.Repository
-	transactionRepo transaction.Repository
+	uow UnitOfWork
 }
 
-func NewBorrowMoneyUseCase(
-	loanRepo loan.Repository,
-	transactionRepo transaction.Repository,
-) *BorrowMoneyUseCase {
+func NewBorrowMoneyUseCase(uow UnitOfWork) *BorrowMoneyUseCase {
 	return &BorrowMoneyUseCase{
-		loanRepo:        loanRepo,
-		transactionRepo: transactionRepo,
+		uow: uow,
 	}
 }
 
 type BorrowMoneyInput struct {
 	LenderName  string
-	Amount      float64
+	Amount      string
+	Currency    string
 	Description string
 	Date        time.Time
+	// DueDate is when the first, or only, repayment is due (optional)
+	DueDate *time.Time
+	// Installments splits the repayment into that many monthly installments
+	Installments int
 }
 
 type BorrowMoneyOutput struct {
@@ -39,41 +39,48 @@ type BorrowMoneyOutput struct {
 
 func (uc *BorrowMoneyUseCase) Execute(input BorrowMoneyInput) (*BorrowMoneyOutput, error) {
 	// Validate input
-	if input.LenderName == "" {
+	if loan.NormalizeName(input.LenderName) == "" {
 		return nil, fmt.Errorf("lender name cannot be empty: %w", shared.ErrInvalidInput)
 	}
 	if input.Description == "" {
 		return nil, fmt.Errorf("description cannot be empty: %w", shared.ErrInvalidInput)
 	}
 
-	money, err := shared.NewMoney(input.Amount)
+	money, err := shared.ParseMoney(input.Amount, currencyOrDefault(input.Currency))
 	if err != nil {
 		return nil, fmt.Errorf("invalid loan amount: %w", err)
 	}
 
-	loanObj := loan.NewLoan(
-		uuid.New().String(),
-		input.LenderName,
-		money,
-		input.Date,
-		input.Description,
+	var (
+		loanObj loan.Loan
+		tx      transaction.Transaction
 	)
 
-	if err := uc.loanRepo.Save(loanObj); err != nil {
-		return nil, fmt.Errorf("failed to save loan: %w", err)
-	}
+	err = uc.uow.Do(func(repos Repositories) error {
+		name, err := personName(repos, input.LenderName)
+		if err != nil {
+			return err
+		}
 
-	tx := transaction.NewTransaction(
-		uuid.New().String(),
-		money,
-		shared.CategoryBorrowed,
-		fmt.Sprintf("Borrowed from %s: %s", input.LenderName, input.Description),
-		transaction.TransactionTypeIncome,
-		input.Date,
-	)
+		loanObj = loan.NewLoan(
+			uuid.New().String(),
+			name,
+			money,
+			input.Date,
+			input.Description,
+		)
 
-	if err := uc.transactionRepo.Save(tx); err != nil {
-		return nil, fmt.Errorf("failed to save transaction: %w", err)
+		var schedule []loan.Installment
+		loanObj, schedule, err = planSchedule(loanObj, input.DueDate, input.Installments)
+		if err != nil {
+			return err
+		}
+
+		tx, err = openLoan(repos, loanObj, schedule)
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &BorrowMoneyOutput{
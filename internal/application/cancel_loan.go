@@ -0,0 +1,52 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// CancelLoanUseCase marks an active loan as no longer owed, e.g. when the
+// lender forgives the rest. Its history is kept.
+type CancelLoanUseCase struct {
+	loanRepo loan.Repository
+}
+
+func NewCancelLoanUseCase(loanRepo loan.Repository) *CancelLoanUseCase {
+	return &CancelLoanUseCase{
+		loanRepo: loanRepo,
+	}
+}
+
+type CancelLoanInput struct {
+	LoanID string
+}
+
+type CancelLoanOutput struct {
+	Loan loan.Loan
+}
+
+func (uc *CancelLoanUseCase) Execute(input CancelLoanInput) (*CancelLoanOutput, error) {
+	// Validate input
+	if input.LoanID == "" {
+		return nil, fmt.Errorf("loan ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	loanObj, err := uc.loanRepo.FindByID(input.LoanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find loan: %w", err)
+	}
+
+	cancelled, err := loanObj.Cancel()
+	if err != nil {
+		return nil, fmt.Errorf("only active loans can be cancelled: %w", err)
+	}
+
+	if err := uc.loanRepo.Update(cancelled); err != nil {
+		return nil, fmt.Errorf("failed to update loan: %w", err)
+	}
+
+	return &CancelLoanOutput{
+		Loan: cancelled,
+	}, nil
+}
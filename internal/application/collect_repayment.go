@@ -0,0 +1,78 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+// CollectRepaymentUseCase records money paid back to us on a loan we lent
+type CollectRepaymentUseCase struct {
+	uow UnitOfWork
+}
+
+func NewCollectRepaymentUseCase(uow UnitOfWork) *CollectRepaymentUseCase {
+	return &CollectRepaymentUseCase{
+		uow: uow,
+	}
+}
+
+type CollectRepaymentInput struct {
+	LoanID string
+	Amount string
+	Date   time.Time
+}
+
+type CollectRepaymentOutput struct {
+	UpdatedLoan     loan.Loan
+	Transaction     transaction.Transaction
+	RemainingAmount shared.Money
+	FullyPaid       bool
+}
+
+func (uc *CollectRepaymentUseCase) Execute(input CollectRepaymentInput) (*CollectRepaymentOutput, error) {
+	// Validate input
+	if input.LoanID == "" {
+		return nil, fmt.Errorf("loan ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	var (
+		updatedLoan loan.Loan
+		tx          transaction.Transaction
+	)
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		loanObj, err := repos.Loans.FindByID(input.LoanID)
+		if err != nil {
+			return fmt.Errorf("failed to find loan: %w", err)
+		}
+
+		if !loanObj.IsReceivable() {
+			return fmt.Errorf("money borrowed from %s is paid, not collected: %w", loanObj.LenderName(), shared.ErrInvalidInput)
+		}
+		if err := checkRepayable(loanObj); err != nil {
+			return err
+		}
+
+		// Repayments are always made in the currency the money was lent in
+		amount, err := shared.ParseMoney(input.Amount, loanObj.Amount().Currency())
+		if err != nil {
+			return fmt.Errorf("invalid repayment amount: %w", err)
+		}
+
+		updatedLoan, tx, err = recordRepayment(repos, loanObj, amount, input.Date)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &CollectRepaymentOutput{
+		UpdatedLoan:     updatedLoan,
+		Transaction:     tx,
+		RemainingAmount: updatedLoan.RemainingAmount(),
+		FullyPaid:       updatedLoan.IsFullyPaid(),
+	}, nil
+}
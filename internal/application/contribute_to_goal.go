@@ -0,0 +1,105 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/domain/goal"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+// ContributeToGoalUseCase puts money aside for a savings goal by transferring
+// it from another account into the goal's account. Deleting the transfer
+// withdraws the contribution.
+type ContributeToGoalUseCase struct {
+	uow UnitOfWork
+}
+
+func NewContributeToGoalUseCase(uow UnitOfWork) *ContributeToGoalUseCase {
+	return &ContributeToGoalUseCase{
+		uow: uow,
+	}
+}
+
+type ContributeToGoalInput struct {
+	GoalID        string
+	FromAccountID string
+	Amount        string
+	Currency      string
+	Date          time.Time
+}
+
+type ContributeToGoalOutput struct {
+	Goal     goal.Goal
+	From     account.Account
+	To       account.Account
+	Outgoing transaction.Transaction
+	Incoming transaction.Transaction
+}
+
+func (uc *ContributeToGoalUseCase) Execute(input ContributeToGoalInput) (*ContributeToGoalOutput, error) {
+	// Validate input
+	if input.GoalID == "" {
+		return nil, fmt.Errorf("goal ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	if input.FromAccountID == "" {
+		return nil, fmt.Errorf("the account to take the money from is required: %w", shared.ErrInvalidInput)
+	}
+
+	money, err := shared.ParseMoney(input.Amount, currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("invalid contribution amount: %w", err)
+	}
+
+	output := &ContributeToGoalOutput{}
+
+	err = uc.uow.Do(func(repos Repositories) error {
+		goalObj, err := repos.Goals.FindByID(input.GoalID)
+		if err != nil {
+			return fmt.Errorf("failed to find goal: %w", err)
+		}
+
+		from, err := resolveAccount(repos.Accounts, input.FromAccountID)
+		if err != nil {
+			return err
+		}
+		to, err := resolveAccount(repos.Accounts, goalObj.AccountID())
+		if err != nil {
+			return err
+		}
+		if from.ID() == to.ID() {
+			return fmt.Errorf("the money is already on %s, the goal's account: %w", to.Name(), shared.ErrInvalidInput)
+		}
+
+		description := fmt.Sprintf("Saving for %s", goalObj.Name())
+		outgoing, incoming := newTransfer(from, to, money, description, input.Date)
+		outgoing = outgoing.WithGoalID(goalObj.ID())
+		incoming = incoming.WithGoalID(goalObj.ID())
+
+		for _, leg := range []transaction.Transaction{outgoing, incoming} {
+			if err := ensureOpenPeriod(repos.Reconciliations, leg); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Transactions.Save(outgoing); err != nil {
+			return fmt.Errorf("failed to save contribution: %w", err)
+		}
+		if err := repos.Transactions.Save(incoming); err != nil {
+			return fmt.Errorf("failed to save contribution: %w", err)
+		}
+
+		output.Goal = goalObj
+		output.From = from
+		output.To = to
+		output.Outgoing = outgoing
+		output.Incoming = incoming
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,84 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+type CreateAccountUseCase struct {
+	accountRepo account.Repository
+}
+
+func NewCreateAccountUseCase(accountRepo account.Repository) *CreateAccountUseCase {
+	return &CreateAccountUseCase{
+		accountRepo: accountRepo,
+	}
+}
+
+type CreateAccountInput struct {
+	Name string
+	Type account.AccountType
+}
+
+type CreateAccountOutput struct {
+	Account account.Account
+}
+
+func (uc *CreateAccountUseCase) Execute(input CreateAccountInput) (*CreateAccountOutput, error) {
+	accountObj, err := account.NewAccount(uuid.New().String(), input.Name, input.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAccountNameFree(uc.accountRepo, accountObj.Name(), ""); err != nil {
+		return nil, err
+	}
+
+	if err := uc.accountRepo.Save(accountObj); err != nil {
+		return nil, fmt.Errorf("failed to save account: %w", err)
+	}
+
+	return &CreateAccountOutput{
+		Account: accountObj,
+	}, nil
+}
+
+// checkAccountNameFree fails when another account than exceptID already uses
+// the name
+func checkAccountNameFree(accountRepo account.Repository, name string, exceptID string) error {
+	existing, err := accountRepo.FindByName(name)
+	if errors.Is(err, shared.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check account: %w", err)
+	}
+	if existing.ID() != exceptID {
+		return fmt.Errorf("account %q already exists: %w", existing.Name(), shared.ErrDuplicateEntry)
+	}
+	return nil
+}
+
+// resolveAccount looks up the account a transaction is booked on; an empty
+// ID stands for the default account
+func resolveAccount(accountRepo account.Repository, id string) (account.Account, error) {
+	id = strings.TrimSpace(id)
+	if id == "" {
+		id = account.DefaultAccountID
+	}
+
+	accountObj, err := accountRepo.FindByID(id)
+	if errors.Is(err, shared.ErrNotFound) {
+		return account.Account{}, fmt.Errorf("unknown account %q: %w", id, shared.ErrInvalidInput)
+	}
+	if err != nil {
+		return account.Account{}, fmt.Errorf("failed to find account: %w", err)
+	}
+
+	return accountObj, nil
+}
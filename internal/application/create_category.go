@@ -0,0 +1,141 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// maxIconLength bounds the icon to a short emoji or symbol
+const maxIconLength = 8
+
+type CreateCategoryUseCase struct {
+	categoryRepo category.Repository
+}
+
+func NewCreateCategoryUseCase(categoryRepo category.Repository) *CreateCategoryUseCase {
+	return &CreateCategoryUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+type CreateCategoryInput struct {
+	Name string
+	Type shared.CategoryType
+	// ParentName optionally places the category under a top-level category
+	// of the same type, e.g. Groceries under Food
+	ParentName string
+	Color      string
+	Icon       string
+}
+
+type CreateCategoryOutput struct {
+	Category category.Category
+}
+
+func (uc *CreateCategoryUseCase) Execute(input CreateCategoryInput) (*CreateCategoryOutput, error) {
+	// Validate input
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("category name cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	if input.Type != shared.CategoryTypeIncome && input.Type != shared.CategoryTypeExpense {
+		return nil, fmt.Errorf("invalid category type %q: %w", input.Type, shared.ErrInvalidInput)
+	}
+
+	color, icon, err := validateAppearance(input.Color, input.Icon)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = uc.categoryRepo.FindByName(name)
+	if err == nil {
+		return nil, fmt.Errorf("category %q already exists: %w", name, shared.ErrDuplicateEntry)
+	}
+	if !errors.Is(err, shared.ErrNotFound) {
+		return nil, fmt.Errorf("failed to check category: %w", err)
+	}
+
+	parentID := ""
+	if strings.TrimSpace(input.ParentName) != "" {
+		parent, err := uc.categoryRepo.FindByName(strings.TrimSpace(input.ParentName))
+		if errors.Is(err, shared.ErrNotFound) {
+			return nil, fmt.Errorf("unknown parent category %q: %w", input.ParentName, shared.ErrInvalidInput)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to find parent category: %w", err)
+		}
+
+		if parent.Type() != input.Type {
+			return nil, fmt.Errorf("parent category %q is not an %s category: %w", parent.Name(), input.Type, shared.ErrInvalidInput)
+		}
+		// Only one level of nesting is supported
+		if parent.IsSubcategory() {
+			return nil, fmt.Errorf("category %q is already a sub-category: %w", parent.Name(), shared.ErrInvalidInput)
+		}
+
+		parentID = parent.ID()
+	}
+
+	categoryObj := category.NewCategory(uuid.New().String(), name, input.Type, parentID, color, icon, false, false)
+
+	if err := uc.categoryRepo.Save(categoryObj); err != nil {
+		return nil, fmt.Errorf("failed to save category: %w", err)
+	}
+
+	return &CreateCategoryOutput{
+		Category: categoryObj,
+	}, nil
+}
+
+// validateAppearance checks the optional colour (#rrggbb) and icon
+func validateAppearance(color, icon string) (string, string, error) {
+	color = strings.ToLower(strings.TrimSpace(color))
+	if color != "" && !isHexColor(color) {
+		return "", "", fmt.Errorf("invalid colour %q, expected #rrggbb: %w", color, shared.ErrInvalidInput)
+	}
+
+	icon = strings.TrimSpace(icon)
+	if utf8.RuneCountInString(icon) > maxIconLength {
+		return "", "", fmt.Errorf("icon is too long: %w", shared.ErrInvalidInput)
+	}
+
+	return color, icon, nil
+}
+
+func isHexColor(s string) bool {
+	if len(s) != 7 || s[0] != '#' {
+		return false
+	}
+	for _, r := range s[1:] {
+		if !(r >= '0' && r <= '9') && !(r >= 'a' && r <= 'f') {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveCategory looks up a stored, non-archived category of the given type
+func resolveCategory(categoryRepo category.Repository, name string, typ shared.CategoryType) (category.Category, error) {
+	categoryObj, err := categoryRepo.FindByName(strings.TrimSpace(name))
+	if errors.Is(err, shared.ErrNotFound) {
+		return category.Category{}, fmt.Errorf("unknown category %q: %w", name, shared.ErrInvalidInput)
+	}
+	if err != nil {
+		return category.Category{}, fmt.Errorf("failed to find category: %w", err)
+	}
+
+	if categoryObj.Type() != typ {
+		return category.Category{}, fmt.Errorf("category %q is not an %s category: %w", categoryObj.Name(), typ, shared.ErrInvalidInput)
+	}
+	if categoryObj.IsArchived() {
+		return category.Category{}, fmt.Errorf("category %q is archived: %w", categoryObj.Name(), shared.ErrInvalidInput)
+	}
+
+	return categoryObj, nil
+}
@@ -0,0 +1,187 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateCategoryRuleUseCase struct {
+	ruleRepo     categorization.Repository
+	categoryRepo category.Repository
+}
+
+func NewCreateCategoryRuleUseCase(
+	ruleRepo categorization.Repository,
+	categoryRepo category.Repository,
+) *CreateCategoryRuleUseCase {
+	return &CreateCategoryRuleUseCase{
+		ruleRepo:     ruleRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+// CreateCategoryRuleInput leaves a condition out when it is empty. Priority
+// 0 puts the rule after the existing ones; MinAmount and MaxAmount are in
+// Currency.
+type CreateCategoryRuleInput struct {
+	Priority     int
+	Contains     string
+	Pattern      string
+	Counterparty string
+	MinAmount    string
+	MaxAmount    string
+	Currency     string
+	Type         string // income or expense
+	CategoryName string
+}
+
+type CreateCategoryRuleOutput struct {
+	Rule categorization.Rule
+}
+
+func (uc *CreateCategoryRuleUseCase) Execute(input CreateCategoryRuleInput) (*CreateCategoryRuleOutput, error) {
+	// Validate input
+	typ := shared.CategoryType(input.Type)
+	if typ != shared.CategoryTypeIncome && typ != shared.CategoryTypeExpense {
+		return nil, fmt.Errorf("type must be income or expense: %w", shared.ErrInvalidInput)
+	}
+
+	categoryObj, err := resolveCategory(uc.categoryRepo, input.CategoryName, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	minAmount, err := optionalMoney(input.MinAmount, input.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid minimum amount: %w", err)
+	}
+	maxAmount, err := optionalMoney(input.MaxAmount, input.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid maximum amount: %w", err)
+	}
+
+	priority := input.Priority
+	if priority == 0 {
+		rules, err := uc.ruleRepo.FindAll()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get category rules: %w", err)
+		}
+		priority = categorization.NextPriority(rules)
+	}
+
+	rule, err := categorization.NewRule(
+		uuid.New().String(),
+		priority,
+		input.Contains,
+		input.Pattern,
+		input.Counterparty,
+		minAmount,
+		maxAmount,
+		categoryObj.Value(),
+		time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ruleRepo.Save(rule); err != nil {
+		return nil, fmt.Errorf("failed to save category rule: %w", err)
+	}
+
+	return &CreateCategoryRuleOutput{
+		Rule: rule,
+	}, nil
+}
+
+// optionalMoney parses an amount that may be left empty
+func optionalMoney(amount string, currency string) (*shared.Money, error) {
+	if strings.TrimSpace(amount) == "" {
+		return nil, nil
+	}
+
+	money, err := shared.ParseMoney(amount, currencyOrDefault(currency))
+	if err != nil {
+		return nil, err
+	}
+	return &money, nil
+}
+
+// activeCategoryRules returns the rules in evaluation order, leaving out those
+// whose category was since deleted or archived
+func activeCategoryRules(ruleRepo categorization.Repository, categoryRepo category.Repository) ([]categorization.Rule, error) {
+	rules, err := ruleRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category rules: %w", err)
+	}
+
+	categories, err := categoryRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	active := make(map[string]bool)
+	for _, c := range category.FilterActive(categories) {
+		active[strings.ToLower(c.Name())] = true
+	}
+
+	var usable []categorization.Rule
+	for _, rule := range rules {
+		if active[strings.ToLower(rule.Category().Name())] {
+			usable = append(usable, rule)
+		}
+	}
+
+	categorization.SortByPriority(usable)
+	return usable, nil
+}
+
+// categorize puts the transaction in the category of the first rule that
+// matches it, and reports whether one did
+func categorize(rules []categorization.Rule, tx transaction.Transaction) (transaction.Transaction, bool) {
+	rule, ok := categorization.Categorize(rules, tx)
+	if !ok {
+		return tx, false
+	}
+
+	return tx.WithDetails(tx.Amount(), rule.Category(), tx.Description(), tx.CreatedAt()), true
+}
+
+// ruleCategory picks the category of a transaction entered without one: the
+// category of the first rule matching it, or fallback when none does. An
+// empty fallback makes a category required when no rule matches.
+func ruleCategory(
+	ruleRepo categorization.Repository,
+	categoryRepo category.Repository,
+	typ shared.CategoryType,
+	amount shared.Money,
+	description string,
+	fallback string,
+) (category.Category, error) {
+	rules, err := activeCategoryRules(ruleRepo, categoryRepo)
+	if err != nil {
+		return category.Category{}, err
+	}
+
+	txType := transaction.TransactionTypeExpense
+	if typ == shared.CategoryTypeIncome {
+		txType = transaction.TransactionTypeIncome
+	}
+	probe := transaction.NewTransaction("", amount, shared.Category{}, description, txType, time.Time{})
+
+	name := fallback
+	if rule, ok := categorization.Categorize(rules, probe); ok {
+		name = rule.Category().Name()
+	}
+	if name == "" {
+		return category.Category{}, fmt.Errorf("no category rule matches %q, choose a category: %w", description, shared.ErrInvalidInput)
+	}
+
+	return resolveCategory(categoryRepo, name, typ)
+}
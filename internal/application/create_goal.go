@@ -0,0 +1,72 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/domain/goal"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CreateGoalUseCase sets a savings goal: an amount to reach on an account,
+// optionally by a deadline
+type CreateGoalUseCase struct {
+	goalRepo    goal.Repository
+	accountRepo account.Repository
+}
+
+func NewCreateGoalUseCase(goalRepo goal.Repository, accountRepo account.Repository) *CreateGoalUseCase {
+	return &CreateGoalUseCase{
+		goalRepo:    goalRepo,
+		accountRepo: accountRepo,
+	}
+}
+
+type CreateGoalInput struct {
+	Name         string
+	TargetAmount string
+	Currency     string
+	Deadline     *time.Time
+	// AccountID is where the savings are kept; empty for the default account
+	AccountID string
+}
+
+type CreateGoalOutput struct {
+	Goal    goal.Goal
+	Account account.Account
+}
+
+func (uc *CreateGoalUseCase) Execute(input CreateGoalInput) (*CreateGoalOutput, error) {
+	target, err := shared.ParseMoney(input.TargetAmount, currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("invalid goal target: %w", err)
+	}
+
+	accountObj, err := resolveAccount(uc.accountRepo, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	goalObj, err := goal.NewGoal(
+		uuid.New().String(),
+		input.Name,
+		target,
+		input.Deadline,
+		accountObj.ID(),
+		time.Now(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.goalRepo.Save(goalObj); err != nil {
+		return nil, fmt.Errorf("failed to save goal: %w", err)
+	}
+
+	return &CreateGoalOutput{
+		Goal:    goalObj,
+		Account: accountObj,
+	}, nil
+}
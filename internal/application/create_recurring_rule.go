@@ -0,0 +1,96 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/domain/recurring"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CreateRecurringRuleUseCase struct {
+	ruleRepo     recurring.Repository
+	categoryRepo category.Repository
+}
+
+func NewCreateRecurringRuleUseCase(
+	ruleRepo recurring.Repository,
+	categoryRepo category.Repository,
+) *CreateRecurringRuleUseCase {
+	return &CreateRecurringRuleUseCase{
+		ruleRepo:     ruleRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+type CreateRecurringRuleInput struct {
+	Description  string
+	Amount       string
+	Currency     string
+	Type         string // income or expense
+	CategoryName string
+	Frequency    string
+	Interval     int
+	DayOfMonth   int
+	StartDate    time.Time
+	EndDate      *time.Time
+}
+
+type CreateRecurringRuleOutput struct {
+	Rule recurring.Rule
+}
+
+func (uc *CreateRecurringRuleUseCase) Execute(input CreateRecurringRuleInput) (*CreateRecurringRuleOutput, error) {
+	// Validate input
+	description := strings.TrimSpace(input.Description)
+	if description == "" {
+		return nil, fmt.Errorf("description cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	typ := shared.CategoryType(input.Type)
+	if typ != shared.CategoryTypeIncome && typ != shared.CategoryTypeExpense {
+		return nil, fmt.Errorf("type must be income or expense: %w", shared.ErrInvalidInput)
+	}
+
+	categoryObj, err := resolveCategory(uc.categoryRepo, input.CategoryName, typ)
+	if err != nil {
+		return nil, err
+	}
+
+	money, err := shared.ParseMoney(input.Amount, currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	interval := input.Interval
+	if interval == 0 {
+		interval = 1
+	}
+
+	rule, err := recurring.NewRule(
+		uuid.New().String(),
+		description,
+		money,
+		categoryObj.Value(),
+		recurring.Frequency(input.Frequency),
+		interval,
+		input.DayOfMonth,
+		input.StartDate,
+		input.EndDate,
+		true,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ruleRepo.Save(rule); err != nil {
+		return nil, fmt.Errorf("failed to save recurring rule: %w", err)
+	}
+
+	return &CreateRecurringRuleOutput{
+		Rule: rule,
+	}, nil
+}
@@ -0,0 +1,183 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/exchange_rate"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+	"time"
+)
+
+// CurrencyConverter converts amounts between currencies as of a given date (port)
+type CurrencyConverter interface {
+	BaseCurrency() string
+	Convert(amount shared.Money, to string, at time.Time) (shared.Money, error)
+}
+
+// StaticRateConverter converts with a fixed table of rates expressed against
+// the base currency, ignoring the date
+type StaticRateConverter struct {
+	base  string
+	rates map[string]shared.ConversionRate
+}
+
+// NewStaticRateConverter creates a converter where rates[code] is the value of
+// one unit of code in the base currency
+func NewStaticRateConverter(base string, rates map[string]shared.ConversionRate) *StaticRateConverter {
+	return &StaticRateConverter{
+		base:  base,
+		rates: rates,
+	}
+}
+
+func (c *StaticRateConverter) BaseCurrency() string {
+	return c.base
+}
+
+func (c *StaticRateConverter) Convert(amount shared.Money, to string, at time.Time) (shared.Money, error) {
+	return convertThroughBase(amount, to, c.base, func(code string) (shared.ConversionRate, error) {
+		rate, exists := c.rates[code]
+		if !exists {
+			return shared.ConversionRate{}, fmt.Errorf("no exchange rate for %s to %s: %w", code, c.base, shared.ErrNotFound)
+		}
+		return rate, nil
+	})
+}
+
+// RateTableConverter converts with the stored exchange rates, using for each
+// amount the rate effective on its date. Pairs with no stored rate fall back
+// to the given converter, if any.
+type RateTableConverter struct {
+	base     string
+	rateRepo exchange_rate.Repository
+	fallback CurrencyConverter
+}
+
+func NewRateTableConverter(
+	base string,
+	rateRepo exchange_rate.Repository,
+	fallback CurrencyConverter,
+) *RateTableConverter {
+	return &RateTableConverter{
+		base:     base,
+		rateRepo: rateRepo,
+		fallback: fallback,
+	}
+}
+
+func (c *RateTableConverter) BaseCurrency() string {
+	return c.base
+}
+
+func (c *RateTableConverter) Convert(amount shared.Money, to string, at time.Time) (shared.Money, error) {
+	converted, err := convertThroughBase(amount, to, c.base, func(code string) (shared.ConversionRate, error) {
+		return c.rateToBase(code, at)
+	})
+	if errors.Is(err, shared.ErrNotFound) && c.fallback != nil {
+		return c.fallback.Convert(amount, to, at)
+	}
+	return converted, err
+}
+
+// rateToBase looks up code -> base, or the inverse of base -> code
+func (c *RateTableConverter) rateToBase(code string, at time.Time) (shared.ConversionRate, error) {
+	rate, err := c.rateRepo.FindEffective(code, c.base, at)
+	if err == nil {
+		return rate.Rate(), nil
+	}
+	if !errors.Is(err, shared.ErrNotFound) {
+		return shared.ConversionRate{}, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+
+	inverse, err := c.rateRepo.FindEffective(c.base, code, at)
+	if err == nil {
+		return inverse.Rate().Inverse()
+	}
+	if !errors.Is(err, shared.ErrNotFound) {
+		return shared.ConversionRate{}, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+
+	return shared.ConversionRate{}, fmt.Errorf(
+		"no exchange rate for %s to %s on %s: %w", code, c.base, at.Format("2006-01-02"), shared.ErrNotFound,
+	)
+}
+
+// convertThroughBase converts amount into the target currency by going through
+// the base currency, looking up each leg with rateToBase
+func convertThroughBase(
+	amount shared.Money,
+	to string,
+	base string,
+	rateToBase func(code string) (shared.ConversionRate, error),
+) (shared.Money, error) {
+	from := amount.Currency()
+	if from == "" || from == to {
+		return shared.UnsafeNewMoney(amount.MinorUnits(), to), nil
+	}
+
+	inBase := amount
+	if from != base {
+		rate, err := rateToBase(from)
+		if err != nil {
+			return shared.Money{}, err
+		}
+		if inBase, err = amount.Convert(rate, base); err != nil {
+			return shared.Money{}, err
+		}
+	}
+
+	if to == base {
+		return inBase, nil
+	}
+
+	rate, err := rateToBase(to)
+	if err != nil {
+		return shared.Money{}, err
+	}
+
+	inverse, err := rate.Inverse()
+	if err != nil {
+		return shared.Money{}, err
+	}
+
+	return inBase.Convert(inverse, to)
+}
+
+// ParseStaticRates parses a rate table such as "EUR=10.85,USD=9.95"
+func ParseStaticRates(spec string) (map[string]shared.ConversionRate, error) {
+	rates := make(map[string]shared.ConversionRate)
+
+	for _, pair := range strings.Split(spec, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		code, value, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("invalid exchange rate %q: %w", pair, shared.ErrInvalidInput)
+		}
+
+		currency, err := shared.NormalizeCurrency(code)
+		if err != nil {
+			return nil, err
+		}
+
+		rate, err := shared.ParseConversionRate(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exchange rate for %s: %w", currency, err)
+		}
+
+		rates[currency] = rate
+	}
+
+	return rates, nil
+}
+
+// currencyOrDefault falls back to the default currency when none was given
+func currencyOrDefault(code string) string {
+	if strings.TrimSpace(code) == "" {
+		return shared.DefaultCurrency
+	}
+	return code
+}
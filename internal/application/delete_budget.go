@@ -0,0 +1,45 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/budget"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+type DeleteBudgetUseCase struct {
+	budgetRepo budget.Repository
+}
+
+func NewDeleteBudgetUseCase(budgetRepo budget.Repository) *DeleteBudgetUseCase {
+	return &DeleteBudgetUseCase{
+		budgetRepo: budgetRepo,
+	}
+}
+
+type DeleteBudgetInput struct {
+	BudgetID string
+}
+
+type DeleteBudgetOutput struct {
+	Budget budget.Budget
+}
+
+func (uc *DeleteBudgetUseCase) Execute(input DeleteBudgetInput) (*DeleteBudgetOutput, error) {
+	// Validate input
+	if input.BudgetID == "" {
+		return nil, fmt.Errorf("budget ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	budgetObj, err := uc.budgetRepo.FindByID(input.BudgetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find budget: %w", err)
+	}
+
+	if err := uc.budgetRepo.Delete(budgetObj.ID()); err != nil {
+		return nil, fmt.Errorf("failed to delete budget: %w", err)
+	}
+
+	return &DeleteBudgetOutput{
+		Budget: budgetObj,
+	}, nil
+}
@@ -0,0 +1,59 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// DeleteCategoryUseCase removes a user-defined category. Past transactions
+// keep its name; built-in categories can only be archived.
+type DeleteCategoryUseCase struct {
+	categoryRepo category.Repository
+}
+
+func NewDeleteCategoryUseCase(categoryRepo category.Repository) *DeleteCategoryUseCase {
+	return &DeleteCategoryUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+type DeleteCategoryInput struct {
+	CategoryID string
+}
+
+type DeleteCategoryOutput struct {
+	Category category.Category
+}
+
+func (uc *DeleteCategoryUseCase) Execute(input DeleteCategoryInput) (*DeleteCategoryOutput, error) {
+	// Validate input
+	if input.CategoryID == "" {
+		return nil, fmt.Errorf("category ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	categoryObj, err := uc.categoryRepo.FindByID(input.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+
+	if categoryObj.IsBuiltin() {
+		return nil, fmt.Errorf("built-in category %q cannot be deleted: %w", categoryObj.Name(), shared.ErrInvalidInput)
+	}
+
+	categories, err := uc.categoryRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	if children := category.ChildrenOf(categories, categoryObj.ID()); len(children) > 0 {
+		return nil, fmt.Errorf("category %q still has %d sub-categories: %w", categoryObj.Name(), len(children), shared.ErrInvalidInput)
+	}
+
+	if err := uc.categoryRepo.Delete(categoryObj.ID()); err != nil {
+		return nil, fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	return &DeleteCategoryOutput{
+		Category: categoryObj,
+	}, nil
+}
@@ -0,0 +1,47 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// DeleteCategoryRuleUseCase removes a rule. Transactions it categorized keep
+// their category.
+type DeleteCategoryRuleUseCase struct {
+	ruleRepo categorization.Repository
+}
+
+func NewDeleteCategoryRuleUseCase(ruleRepo categorization.Repository) *DeleteCategoryRuleUseCase {
+	return &DeleteCategoryRuleUseCase{
+		ruleRepo: ruleRepo,
+	}
+}
+
+type DeleteCategoryRuleInput struct {
+	RuleID string
+}
+
+type DeleteCategoryRuleOutput struct {
+	Rule categorization.Rule
+}
+
+func (uc *DeleteCategoryRuleUseCase) Execute(input DeleteCategoryRuleInput) (*DeleteCategoryRuleOutput, error) {
+	// Validate input
+	if input.RuleID == "" {
+		return nil, fmt.Errorf("rule ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	rule, err := uc.ruleRepo.FindByID(input.RuleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category rule: %w", err)
+	}
+
+	if err := uc.ruleRepo.Delete(rule.ID()); err != nil {
+		return nil, fmt.Errorf("failed to delete category rule: %w", err)
+	}
+
+	return &DeleteCategoryRuleOutput{
+		Rule: rule,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/exchange_rate"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+type DeleteExchangeRateUseCase struct {
+	rateRepo exchange_rate.Repository
+}
+
+func NewDeleteExchangeRateUseCase(rateRepo exchange_rate.Repository) *DeleteExchangeRateUseCase {
+	return &DeleteExchangeRateUseCase{
+		rateRepo: rateRepo,
+	}
+}
+
+type DeleteExchangeRateInput struct {
+	RateID string
+}
+
+type DeleteExchangeRateOutput struct {
+	Rate exchange_rate.Rate
+}
+
+func (uc *DeleteExchangeRateUseCase) Execute(input DeleteExchangeRateInput) (*DeleteExchangeRateOutput, error) {
+	// Validate input
+	if input.RateID == "" {
+		return nil, fmt.Errorf("rate ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	rateObj, err := uc.rateRepo.FindByID(input.RateID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find exchange rate: %w", err)
+	}
+
+	if err := uc.rateRepo.Delete(rateObj.ID()); err != nil {
+		return nil, fmt.Errorf("failed to delete exchange rate: %w", err)
+	}
+
+	return &DeleteExchangeRateOutput{
+		Rate: rateObj,
+	}, nil
+}
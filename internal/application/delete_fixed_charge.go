@@ -0,0 +1,61 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// DeleteFixedChargeUseCase removes a fixed charge. Charges already deducted
+// from past salaries stay recorded as plain expenses.
+type DeleteFixedChargeUseCase struct {
+	uow UnitOfWork
+}
+
+func NewDeleteFixedChargeUseCase(uow UnitOfWork) *DeleteFixedChargeUseCase {
+	return &DeleteFixedChargeUseCase{
+		uow: uow,
+	}
+}
+
+type DeleteFixedChargeInput struct {
+	FixedChargeID string
+}
+
+type DeleteFixedChargeOutput struct {
+	FixedCharge fixed_charge.FixedCharge
+}
+
+func (uc *DeleteFixedChargeUseCase) Execute(input DeleteFixedChargeInput) (*DeleteFixedChargeOutput, error) {
+	// Validate input
+	if input.FixedChargeID == "" {
+		return nil, fmt.Errorf("fixed charge ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	var charge fixed_charge.FixedCharge
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		var err error
+		charge, err = repos.FixedCharges.FindByID(input.FixedChargeID)
+		if err != nil {
+			return fmt.Errorf("failed to find fixed charge: %w", err)
+		}
+
+		if err := repos.Transactions.UnlinkFixedCharge(charge.ID()); err != nil {
+			return err
+		}
+
+		if err := repos.FixedCharges.Delete(charge.ID()); err != nil {
+			return fmt.Errorf("failed to delete fixed charge: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteFixedChargeOutput{
+		FixedCharge: charge,
+	}, nil
+}
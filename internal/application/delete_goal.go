@@ -0,0 +1,61 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/goal"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// DeleteGoalUseCase removes a savings goal. The money contributed stays on
+// its account and the contributions become plain transfers.
+type DeleteGoalUseCase struct {
+	uow UnitOfWork
+}
+
+func NewDeleteGoalUseCase(uow UnitOfWork) *DeleteGoalUseCase {
+	return &DeleteGoalUseCase{
+		uow: uow,
+	}
+}
+
+type DeleteGoalInput struct {
+	GoalID string
+}
+
+type DeleteGoalOutput struct {
+	Goal goal.Goal
+}
+
+func (uc *DeleteGoalUseCase) Execute(input DeleteGoalInput) (*DeleteGoalOutput, error) {
+	// Validate input
+	if input.GoalID == "" {
+		return nil, fmt.Errorf("goal ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	var goalObj goal.Goal
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		var err error
+		goalObj, err = repos.Goals.FindByID(input.GoalID)
+		if err != nil {
+			return fmt.Errorf("failed to find goal: %w", err)
+		}
+
+		if err := repos.Transactions.UnlinkGoal(goalObj.ID()); err != nil {
+			return err
+		}
+
+		if err := repos.Goals.Delete(goalObj.ID()); err != nil {
+			return fmt.Errorf("failed to delete goal: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteGoalOutput{
+		Goal: goalObj,
+	}, nil
+}
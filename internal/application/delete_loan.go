@@ -0,0 +1,81 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// DeleteLoanUseCase removes a loan entirely, with its payments and the
+// borrowing and repayment transactions, as if it had never been recorded
+type DeleteLoanUseCase struct {
+	uow UnitOfWork
+}
+
+func NewDeleteLoanUseCase(uow UnitOfWork) *DeleteLoanUseCase {
+	return &DeleteLoanUseCase{
+		uow: uow,
+	}
+}
+
+type DeleteLoanInput struct {
+	LoanID string
+}
+
+type DeleteLoanOutput struct {
+	Loan loan.Loan
+}
+
+func (uc *DeleteLoanUseCase) Execute(input DeleteLoanInput) (*DeleteLoanOutput, error) {
+	// Validate input
+	if input.LoanID == "" {
+		return nil, fmt.Errorf("loan ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	output := &DeleteLoanOutput{}
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		loanObj, err := repos.Loans.FindByID(input.LoanID)
+		if err != nil {
+			return fmt.Errorf("failed to find loan: %w", err)
+		}
+
+		payments, err := repos.LoanPayments.FindByLoanID(loanObj.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get loan payments: %w", err)
+		}
+		for _, p := range payments {
+			if err := repos.LoanPayments.Delete(p.ID()); err != nil {
+				return fmt.Errorf("failed to delete loan payment: %w", err)
+			}
+		}
+
+		transactions, err := repos.Transactions.FindByLoanID(loanObj.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get loan transactions: %w", err)
+		}
+		for _, tx := range transactions {
+			if err := ensureUnlocked(tx); err != nil {
+				return err
+			}
+			if err := deleteTransaction(repos, tx.ID()); err != nil {
+				return fmt.Errorf("failed to delete loan transaction: %w", err)
+			}
+		}
+
+		if err := repos.LoanInstallments.DeleteByLoanID(loanObj.ID()); err != nil {
+			return err
+		}
+		if err := repos.Loans.Delete(loanObj.ID()); err != nil {
+			return fmt.Errorf("failed to delete loan: %w", err)
+		}
+
+		output.Loan = loanObj
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,61 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/recurring"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// DeleteRecurringRuleUseCase removes a rule. Transactions it already
+// generated are kept.
+type DeleteRecurringRuleUseCase struct {
+	uow UnitOfWork
+}
+
+func NewDeleteRecurringRuleUseCase(uow UnitOfWork) *DeleteRecurringRuleUseCase {
+	return &DeleteRecurringRuleUseCase{
+		uow: uow,
+	}
+}
+
+type DeleteRecurringRuleInput struct {
+	RuleID string
+}
+
+type DeleteRecurringRuleOutput struct {
+	Rule recurring.Rule
+}
+
+func (uc *DeleteRecurringRuleUseCase) Execute(input DeleteRecurringRuleInput) (*DeleteRecurringRuleOutput, error) {
+	// Validate input
+	if input.RuleID == "" {
+		return nil, fmt.Errorf("rule ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	var rule recurring.Rule
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		var err error
+		rule, err = repos.RecurringRules.FindByID(input.RuleID)
+		if err != nil {
+			return fmt.Errorf("failed to find recurring rule: %w", err)
+		}
+
+		if err := repos.RecurringOccurrences.DeleteByRuleID(rule.ID()); err != nil {
+			return err
+		}
+
+		if err := repos.RecurringRules.Delete(rule.ID()); err != nil {
+			return fmt.Errorf("failed to delete recurring rule: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeleteRecurringRuleOutput{
+		Rule: rule,
+	}, nil
+}
@@ -0,0 +1,141 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// DeleteTransactionUseCase removes a transaction. Deleting a repayment undoes
+// the payment; deleting what was borrowed or lent deletes the loan, which is
+// only allowed while it has no repayments. Deleting either side of a transfer
+// deletes both.
+type DeleteTransactionUseCase struct {
+	uow UnitOfWork
+}
+
+func NewDeleteTransactionUseCase(uow UnitOfWork) *DeleteTransactionUseCase {
+	return &DeleteTransactionUseCase{
+		uow: uow,
+	}
+}
+
+type DeleteTransactionInput struct {
+	TransactionID string
+}
+
+type DeleteTransactionOutput struct {
+	Transaction transaction.Transaction
+	// Loan is the adjusted loan after deleting a repayment, or the deleted
+	// loan after deleting what was borrowed or lent
+	Loan        *loan.Loan
+	LoanDeleted bool
+}
+
+func (uc *DeleteTransactionUseCase) Execute(input DeleteTransactionInput) (*DeleteTransactionOutput, error) {
+	// Validate input
+	if input.TransactionID == "" {
+		return nil, fmt.Errorf("transaction ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	output := &DeleteTransactionOutput{}
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		tx, err := repos.Transactions.FindByID(input.TransactionID)
+		if err != nil {
+			return fmt.Errorf("failed to find transaction: %w", err)
+		}
+		output.Transaction = tx
+
+		if err := ensureUnlocked(tx); err != nil {
+			return err
+		}
+
+		if tx.IsTransfer() {
+			legs, err := repos.Transactions.FindByTransferID(tx.TransferID())
+			if err != nil {
+				return fmt.Errorf("failed to find transfer: %w", err)
+			}
+			for _, leg := range legs {
+				if err := ensureUnlocked(leg); err != nil {
+					return err
+				}
+				if err := deleteTransaction(repos, leg.ID()); err != nil {
+					return fmt.Errorf("failed to delete transfer: %w", err)
+				}
+			}
+			return nil
+		}
+
+		if !tx.IsLoanRelated() {
+			// A deleted recurring transaction stays applied so the
+			// scheduler does not generate it again
+			if err := repos.RecurringOccurrences.UnlinkTransaction(tx.ID()); err != nil {
+				return err
+			}
+			if err := deleteTransaction(repos, tx.ID()); err != nil {
+				return err
+			}
+			return nil
+		}
+
+		loanObj, err := repos.Loans.FindByID(tx.LoanID())
+		if err != nil {
+			return fmt.Errorf("failed to find loan: %w", err)
+		}
+
+		if isPrincipal(loanObj, tx) {
+			payments, err := repos.LoanPayments.FindByLoanID(loanObj.ID())
+			if err != nil {
+				return fmt.Errorf("failed to get loan payments: %w", err)
+			}
+			if len(payments) > 0 {
+				return fmt.Errorf(
+					"%d repayment(s) of this loan were recorded, delete the loan instead: %w",
+					len(payments), shared.ErrInvalidInput,
+				)
+			}
+
+			if err := deleteTransaction(repos, tx.ID()); err != nil {
+				return err
+			}
+			if err := repos.LoanInstallments.DeleteByLoanID(loanObj.ID()); err != nil {
+				return err
+			}
+			if err := repos.Loans.Delete(loanObj.ID()); err != nil {
+				return fmt.Errorf("failed to delete loan: %w", err)
+			}
+			output.Loan = &loanObj
+			output.LoanDeleted = true
+			return nil
+		}
+
+		payment, err := repos.LoanPayments.FindByTransactionID(tx.ID())
+		if err != nil {
+			return fmt.Errorf("failed to find loan payment: %w", err)
+		}
+
+		updatedLoan, err := undoPayment(repos, loanObj, payment)
+		if err != nil {
+			return err
+		}
+		output.Loan = &updatedLoan
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// deleteTransaction deletes a transaction along with the duplicate
+// dismissals that refer to it
+func deleteTransaction(repos Repositories, id string) error {
+	if err := repos.Dismissals.DeleteByTransactionID(id); err != nil {
+		return err
+	}
+	return repos.Transactions.Delete(id)
+}
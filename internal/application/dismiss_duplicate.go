@@ -0,0 +1,58 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// DismissDuplicateUseCase records that two transactions that look alike are
+// both genuine, e.g. two identical coffees, so they are no longer flagged
+type DismissDuplicateUseCase struct {
+	transactionRepo transaction.Repository
+	dismissalRepo   transaction.DismissalRepository
+}
+
+func NewDismissDuplicateUseCase(
+	transactionRepo transaction.Repository,
+	dismissalRepo transaction.DismissalRepository,
+) *DismissDuplicateUseCase {
+	return &DismissDuplicateUseCase{
+		transactionRepo: transactionRepo,
+		dismissalRepo:   dismissalRepo,
+	}
+}
+
+type DismissDuplicateInput struct {
+	TransactionID string
+	OtherID       string
+}
+
+type DismissDuplicateOutput struct {
+	Dismissal transaction.Dismissal
+}
+
+func (uc *DismissDuplicateUseCase) Execute(input DismissDuplicateInput) (*DismissDuplicateOutput, error) {
+	// Validate input
+	if input.TransactionID == "" || input.OtherID == "" {
+		return nil, fmt.Errorf("both transactions are required: %w", shared.ErrInvalidInput)
+	}
+	if input.TransactionID == input.OtherID {
+		return nil, fmt.Errorf("a transaction cannot duplicate itself: %w", shared.ErrInvalidInput)
+	}
+
+	for _, id := range []string{input.TransactionID, input.OtherID} {
+		if _, err := uc.transactionRepo.FindByID(id); err != nil {
+			return nil, fmt.Errorf("failed to find transaction: %w", err)
+		}
+	}
+
+	dismissal := transaction.NewDismissal(input.TransactionID, input.OtherID)
+	if err := uc.dismissalRepo.Save(dismissal); err != nil {
+		return nil, fmt.Errorf("failed to save dismissal: %w", err)
+	}
+
+	return &DismissDuplicateOutput{
+		Dismissal: dismissal,
+	}, nil
+}
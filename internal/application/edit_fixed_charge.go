@@ -0,0 +1,77 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+	"time"
+)
+
+// EditFixedChargeUseCase corrects a fixed charge. Charges already deducted
+// from past salaries are left as they were.
+type EditFixedChargeUseCase struct {
+	fixedChargeRepo fixed_charge.Repository
+}
+
+func NewEditFixedChargeUseCase(fixedChargeRepo fixed_charge.Repository) *EditFixedChargeUseCase {
+	return &EditFixedChargeUseCase{
+		fixedChargeRepo: fixedChargeRepo,
+	}
+}
+
+type EditFixedChargeInput struct {
+	FixedChargeID string
+	Name          string
+	Amount        string
+	Currency      string
+	Description   string
+	StartMonth    *time.Time
+	EndMonth      *time.Time
+	DueDay        int
+}
+
+type EditFixedChargeOutput struct {
+	FixedCharge fixed_charge.FixedCharge
+}
+
+func (uc *EditFixedChargeUseCase) Execute(input EditFixedChargeInput) (*EditFixedChargeOutput, error) {
+	// Validate input
+	if input.FixedChargeID == "" {
+		return nil, fmt.Errorf("fixed charge ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	charge, err := uc.fixedChargeRepo.FindByID(input.FixedChargeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find fixed charge: %w", err)
+	}
+
+	currency := input.Currency
+	if currency == "" {
+		currency = charge.Amount().Currency()
+	}
+
+	money, err := shared.ParseMoney(input.Amount, currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid amount: %w", err)
+	}
+
+	charge, err = charge.
+		WithDetails(name, money, input.Description).
+		WithSchedule(input.StartMonth, input.EndMonth, input.DueDay)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.fixedChargeRepo.Update(charge); err != nil {
+		return nil, fmt.Errorf("failed to update fixed charge: %w", err)
+	}
+
+	return &EditFixedChargeOutput{
+		FixedCharge: charge,
+	}, nil
+}
@@ -0,0 +1,131 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+	"time"
+)
+
+// EditLoanUseCase corrects a loan and keeps its principal and repayment
+// transactions in line with it
+type EditLoanUseCase struct {
+	uow UnitOfWork
+}
+
+func NewEditLoanUseCase(uow UnitOfWork) *EditLoanUseCase {
+	return &EditLoanUseCase{
+		uow: uow,
+	}
+}
+
+type EditLoanInput struct {
+	LoanID      string
+	LenderName  string
+	Amount      string
+	Description string
+	// BorrowedAt is the corrected day; the original time of day is kept
+	BorrowedAt time.Time
+}
+
+type EditLoanOutput struct {
+	Loan loan.Loan
+}
+
+func (uc *EditLoanUseCase) Execute(input EditLoanInput) (*EditLoanOutput, error) {
+	// Validate input
+	if input.LoanID == "" {
+		return nil, fmt.Errorf("loan ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	lenderName := loan.NormalizeName(input.LenderName)
+	if lenderName == "" {
+		return nil, fmt.Errorf("lender name cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	if input.BorrowedAt.IsZero() {
+		return nil, fmt.Errorf("borrowed date cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	output := &EditLoanOutput{}
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		loanObj, err := repos.Loans.FindByID(input.LoanID)
+		if err != nil {
+			return fmt.Errorf("failed to find loan: %w", err)
+		}
+
+		// The currency of a loan is fixed once repayments exist in it
+		amount, err := shared.ParseMoney(input.Amount, loanObj.Amount().Currency())
+		if err != nil {
+			return fmt.Errorf("invalid loan amount: %w", err)
+		}
+		tooLow, err := amount.LessThan(loanObj.AmountPaid())
+		if err != nil {
+			return err
+		}
+		if tooLow {
+			return fmt.Errorf("the loan cannot be less than the %s already repaid: %w", loanObj.AmountPaid(), shared.ErrInvalidInput)
+		}
+
+		borrowedAt := onDay(input.BorrowedAt, loanObj.BorrowedAt())
+		edited, err := loanObj.WithDetails(lenderName, amount, borrowedAt, strings.TrimSpace(input.Description))
+		if err != nil {
+			return fmt.Errorf("failed to update loan: %w", err)
+		}
+
+		updatedLoan, err := recomputeLoan(repos, edited)
+		if err != nil {
+			return err
+		}
+
+		if err := reschedule(repos, updatedLoan); err != nil {
+			return err
+		}
+
+		transactions, err := repos.Transactions.FindByLoanID(updatedLoan.ID())
+		if err != nil {
+			return fmt.Errorf("failed to get loan transactions: %w", err)
+		}
+
+		for _, tx := range transactions {
+			if err := ensureUnlocked(tx); err != nil {
+				return err
+			}
+
+			corrected := tx
+			if isPrincipal(updatedLoan, tx) {
+				principal := principalTransaction(updatedLoan, tx.ID())
+				corrected = tx.WithDetails(
+					principal.Amount(),
+					principal.Category(),
+					principal.Description(),
+					principal.CreatedAt(),
+				)
+				if !corrected.CreatedAt().Equal(tx.CreatedAt()) {
+					if err := ensureOpenPeriod(repos.Reconciliations, corrected); err != nil {
+						return err
+					}
+				}
+			} else if lenderName != loanObj.LenderName() {
+				corrected = tx.WithDetails(
+					tx.Amount(),
+					repaymentCategory(updatedLoan),
+					repaymentDescription(updatedLoan, tx.Amount()),
+					tx.CreatedAt(),
+				)
+			}
+
+			if err := repos.Transactions.Update(corrected); err != nil {
+				return fmt.Errorf("failed to update loan transaction: %w", err)
+			}
+		}
+
+		output.Loan = updatedLoan
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -0,0 +1,204 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+	"time"
+)
+
+// EditTransactionUseCase corrects a recorded transaction. When it is a
+// loan or a repayment, the loan is adjusted in the same unit of work.
+type EditTransactionUseCase struct {
+	uow          UnitOfWork
+	categoryRepo category.Repository
+}
+
+func NewEditTransactionUseCase(uow UnitOfWork, categoryRepo category.Repository) *EditTransactionUseCase {
+	return &EditTransactionUseCase{
+		uow:          uow,
+		categoryRepo: categoryRepo,
+	}
+}
+
+type EditTransactionInput struct {
+	TransactionID string
+	Amount        string
+	Currency      string
+	CategoryName  string
+	Description   string
+	// Date is the corrected day; the original time of day is kept
+	Date time.Time
+}
+
+type EditTransactionOutput struct {
+	Transaction transaction.Transaction
+	Loan        *loan.Loan
+}
+
+func (uc *EditTransactionUseCase) Execute(input EditTransactionInput) (*EditTransactionOutput, error) {
+	// Validate input
+	if input.TransactionID == "" {
+		return nil, fmt.Errorf("transaction ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	if strings.TrimSpace(input.Description) == "" {
+		return nil, fmt.Errorf("description cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	if input.Date.IsZero() {
+		return nil, fmt.Errorf("date cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	output := &EditTransactionOutput{}
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		tx, err := repos.Transactions.FindByID(input.TransactionID)
+		if err != nil {
+			return fmt.Errorf("failed to find transaction: %w", err)
+		}
+		if err := ensureUnlocked(tx); err != nil {
+			return err
+		}
+		if tx.IsTransfer() {
+			return fmt.Errorf("a transfer cannot be edited, delete it and transfer again: %w", shared.ErrInvalidInput)
+		}
+
+		date := onDay(input.Date, tx.CreatedAt())
+
+		currency := tx.Amount().Currency()
+		if input.Currency != "" {
+			if currency, err = shared.NormalizeCurrency(input.Currency); err != nil {
+				return err
+			}
+		}
+
+		txCategory := tx.Category()
+		if input.CategoryName != "" && input.CategoryName != txCategory.Name() {
+			if tx.IsLoanRelated() {
+				return fmt.Errorf("the category of a loan transaction cannot change: %w", shared.ErrInvalidInput)
+			}
+
+			categoryType := shared.CategoryTypeExpense
+			if tx.IsIncome() {
+				categoryType = shared.CategoryTypeIncome
+			}
+
+			categoryObj, err := resolveCategory(uc.categoryRepo, input.CategoryName, categoryType)
+			if err != nil {
+				return err
+			}
+			txCategory = categoryObj.Value()
+		}
+
+		amount, err := shared.ParseMoney(input.Amount, currency)
+		if err != nil {
+			return fmt.Errorf("invalid amount: %w", err)
+		}
+
+		if tx.IsLoanRelated() {
+			updatedLoan, err := uc.adjustLoan(repos, tx, amount, date)
+			if err != nil {
+				return err
+			}
+			output.Loan = &updatedLoan
+		}
+
+		output.Transaction = tx.WithDetails(amount, txCategory, strings.TrimSpace(input.Description), date)
+
+		// Moving a transaction to another day must not move it into a
+		// reconciled period
+		if !date.Equal(tx.CreatedAt()) {
+			if err := ensureOpenPeriod(repos.Reconciliations, output.Transaction); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Transactions.Update(output.Transaction); err != nil {
+			return fmt.Errorf("failed to update transaction: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// adjustLoan applies the corrected amount to the loan the transaction belongs
+// to: the principal changes the amount borrowed or lent, a repayment replaces
+// the payment it recorded
+func (uc *EditTransactionUseCase) adjustLoan(
+	repos Repositories,
+	tx transaction.Transaction,
+	amount shared.Money,
+	date time.Time,
+) (loan.Loan, error) {
+	loanObj, err := repos.Loans.FindByID(tx.LoanID())
+	if err != nil {
+		return loan.Loan{}, fmt.Errorf("failed to find loan: %w", err)
+	}
+
+	if amount.Currency() != loanObj.Amount().Currency() {
+		return loan.Loan{}, fmt.Errorf(
+			"loan transactions stay in the loan currency %s: %w", loanObj.Amount().Currency(), shared.ErrInvalidInput,
+		)
+	}
+
+	if isPrincipal(loanObj, tx) {
+		tooLow, err := amount.LessThan(loanObj.AmountPaid())
+		if err != nil {
+			return loan.Loan{}, err
+		}
+		if tooLow {
+			return loan.Loan{}, fmt.Errorf(
+				"the loan cannot be less than the %s already repaid: %w", loanObj.AmountPaid(), shared.ErrInvalidInput,
+			)
+		}
+
+		loanObj, err = loanObj.WithDetails(loanObj.LenderName(), amount, date, loanObj.Description())
+		if err != nil {
+			return loan.Loan{}, fmt.Errorf("failed to update loan: %w", err)
+		}
+
+		if err := reschedule(repos, loanObj); err != nil {
+			return loan.Loan{}, err
+		}
+	} else {
+		payment, err := repos.LoanPayments.FindByTransactionID(tx.ID())
+		if err != nil {
+			return loan.Loan{}, fmt.Errorf("failed to find loan payment: %w", err)
+		}
+
+		// The corrected payment may take up at most what it left remaining
+		available, err := loanObj.RemainingAmount().Add(payment.Amount())
+		if err != nil {
+			return loan.Loan{}, err
+		}
+		overpaid, err := amount.GreaterThan(available)
+		if err != nil {
+			return loan.Loan{}, err
+		}
+		if overpaid {
+			return loan.Loan{}, &loan.OverpaymentError{Payment: amount, Remaining: available}
+		}
+
+		if err := repos.LoanPayments.Update(payment.WithAmount(amount, date)); err != nil {
+			return loan.Loan{}, fmt.Errorf("failed to update loan payment: %w", err)
+		}
+	}
+
+	return recomputeLoan(repos, loanObj)
+}
+
+// onDay moves t to the given day, keeping its time of day
+func onDay(day time.Time, t time.Time) time.Time {
+	return time.Date(
+		day.Year(), day.Month(), day.Day(),
+		t.Hour(), t.Minute(), t.Second(), t.Nanosecond(),
+		t.Location(),
+	)
+}
@@ -0,0 +1,180 @@
+package application
+
+import (
+	"fmt"
+	"time"
+)
+
+// ExportDataUseCase gathers the whole of the data into a Backup. Everything
+// is read in one unit of work, so the backup is consistent even while the
+// server keeps running.
+type ExportDataUseCase struct {
+	uow UnitOfWork
+}
+
+func NewExportDataUseCase(uow UnitOfWork) *ExportDataUseCase {
+	return &ExportDataUseCase{
+		uow: uow,
+	}
+}
+
+// ExportDataInput stamps the backup with the time it was taken at
+type ExportDataInput struct {
+	At time.Time
+}
+
+type ExportDataOutput struct {
+	Backup *Backup
+}
+
+func (uc *ExportDataUseCase) Execute(input ExportDataInput) (*ExportDataOutput, error) {
+	backup := &Backup{
+		Version:    BackupVersion,
+		ExportedAt: input.At,
+	}
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		accounts, err := repos.Accounts.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get accounts: %w", err)
+		}
+		for _, a := range accounts {
+			backup.Accounts = append(backup.Accounts, backupAccount(a))
+		}
+
+		categories, err := repos.Categories.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get categories: %w", err)
+		}
+		for _, c := range categories {
+			backup.Categories = append(backup.Categories, backupCategory(c))
+		}
+
+		transactions, err := repos.Transactions.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get transactions: %w", err)
+		}
+		for _, tx := range transactions {
+			backup.Transactions = append(backup.Transactions, backupTransaction(tx))
+		}
+
+		budgets, err := repos.Budgets.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get budgets: %w", err)
+		}
+		for _, b := range budgets {
+			backup.Budgets = append(backup.Budgets, backupBudget(b))
+		}
+
+		charges, err := repos.FixedCharges.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get fixed charges: %w", err)
+		}
+		for _, fc := range charges {
+			backup.FixedCharges = append(backup.FixedCharges, backupFixedCharge(fc))
+		}
+
+		loans, err := repos.Loans.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get loans: %w", err)
+		}
+		for _, l := range loans {
+			backup.Loans = append(backup.Loans, backupLoan(l))
+
+			payments, err := repos.LoanPayments.FindByLoanID(l.ID())
+			if err != nil {
+				return fmt.Errorf("failed to get loan payments: %w", err)
+			}
+			for _, p := range payments {
+				backup.LoanPayments = append(backup.LoanPayments, backupLoanPayment(p))
+			}
+
+			installments, err := repos.LoanInstallments.FindByLoanID(l.ID())
+			if err != nil {
+				return fmt.Errorf("failed to get loan schedule: %w", err)
+			}
+			for _, i := range installments {
+				backup.LoanInstallments = append(backup.LoanInstallments, backupLoanInstallment(i))
+			}
+		}
+
+		goals, err := repos.Goals.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get goals: %w", err)
+		}
+		for _, g := range goals {
+			backup.Goals = append(backup.Goals, backupGoal(g))
+		}
+
+		reconciliations, err := repos.Reconciliations.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get reconciliations: %w", err)
+		}
+		for _, rec := range reconciliations {
+			backup.Reconciliations = append(backup.Reconciliations, backupReconciliation(rec))
+		}
+
+		rules, err := repos.RecurringRules.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get recurring rules: %w", err)
+		}
+		for _, rule := range rules {
+			backup.RecurringRules = append(backup.RecurringRules, backupRecurringRule(rule))
+
+			occurrences, err := repos.RecurringOccurrences.FindByRuleID(rule.ID())
+			if err != nil {
+				return fmt.Errorf("failed to get recurring occurrences: %w", err)
+			}
+			for _, o := range occurrences {
+				backup.RecurringOccurrences = append(backup.RecurringOccurrences, backupRecurringOccurrence(o))
+			}
+		}
+
+		categoryRules, err := repos.CategoryRules.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get category rules: %w", err)
+		}
+		for _, rule := range categoryRules {
+			backup.CategoryRules = append(backup.CategoryRules, backupCategoryRule(rule))
+		}
+
+		rates, err := repos.ExchangeRates.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get exchange rates: %w", err)
+		}
+		for _, rate := range rates {
+			backup.ExchangeRates = append(backup.ExchangeRates, backupExchangeRate(rate))
+		}
+
+		dismissals, err := repos.Dismissals.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get duplicate dismissals: %w", err)
+		}
+		for _, d := range dismissals {
+			backup.Dismissals = append(backup.Dismissals, BackupDismissal{
+				TransactionID: d.TransactionID(),
+				OtherID:       d.OtherID(),
+			})
+		}
+
+		statementAccounts, err := repos.StatementAccounts.FindAll()
+		if err != nil {
+			return fmt.Errorf("failed to get statement accounts: %w", err)
+		}
+		for _, s := range statementAccounts {
+			backup.StatementAccounts = append(backup.StatementAccounts, BackupStatementAccount{
+				Number:    s.Number(),
+				AccountID: s.AccountID(),
+			})
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExportDataOutput{
+		Backup: backup,
+	}, nil
+}
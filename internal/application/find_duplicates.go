@@ -0,0 +1,97 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"time"
+)
+
+// FindDuplicatesUseCase lists the transactions of a month that look like the
+// same one entered twice, e.g. by two members of the household
+type FindDuplicatesUseCase struct {
+	transactionRepo transaction.Repository
+	dismissalRepo   transaction.DismissalRepository
+}
+
+func NewFindDuplicatesUseCase(
+	transactionRepo transaction.Repository,
+	dismissalRepo transaction.DismissalRepository,
+) *FindDuplicatesUseCase {
+	return &FindDuplicatesUseCase{
+		transactionRepo: transactionRepo,
+		dismissalRepo:   dismissalRepo,
+	}
+}
+
+type FindDuplicatesInput struct {
+	Year  int
+	Month time.Month
+}
+
+type FindDuplicatesOutput struct {
+	Year  int
+	Month time.Month
+	Pairs []transaction.DuplicatePair
+}
+
+func (uc *FindDuplicatesUseCase) Execute(input FindDuplicatesInput) (*FindDuplicatesOutput, error) {
+	startOfMonth := time.Date(input.Year, input.Month, 1, 0, 0, 0, 0, time.UTC)
+	endOfMonth := startOfMonth.AddDate(0, 1, 0).Add(-time.Second)
+
+	// Look a few days past the month so that a pair straddling its edges is
+	// still found
+	transactions, err := uc.transactionRepo.FindByDateRange(
+		startOfMonth.AddDate(0, 0, -transaction.DuplicateWindowDays),
+		endOfMonth.AddDate(0, 0, transaction.DuplicateWindowDays),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	dismissals, err := uc.dismissalRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dismissals: %w", err)
+	}
+
+	var pairs []transaction.DuplicatePair
+	for _, pair := range transaction.FindDuplicatePairs(transactions, dismissals) {
+		date := pair.Duplicate.CreatedAt()
+		if !date.Before(startOfMonth) && !date.After(endOfMonth) {
+			pairs = append(pairs, pair)
+		}
+	}
+
+	return &FindDuplicatesOutput{
+		Year:  input.Year,
+		Month: input.Month,
+		Pairs: pairs,
+	}, nil
+}
+
+// findDuplicatesOf returns the recorded transactions that look like tx
+// entered twice
+func findDuplicatesOf(
+	transactionRepo transaction.Repository,
+	dismissalRepo transaction.DismissalRepository,
+	tx transaction.Transaction,
+) ([]transaction.Transaction, error) {
+	if !tx.CanBeDuplicate() {
+		return nil, nil
+	}
+
+	day := time.Date(tx.CreatedAt().Year(), tx.CreatedAt().Month(), tx.CreatedAt().Day(), 0, 0, 0, 0, time.UTC)
+	candidates, err := transactionRepo.FindByDateRange(
+		day.AddDate(0, 0, -transaction.DuplicateWindowDays),
+		day.AddDate(0, 0, transaction.DuplicateWindowDays+1).Add(-time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	dismissals, err := dismissalRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dismissals: %w", err)
+	}
+
+	return transaction.FindDuplicates(tx, candidates, dismissals), nil
+}
@@ -0,0 +1,90 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FixedChargeDeduction reports the fixed charges paid out of an income.
+// FixedChargesTotal is expressed in the income currency.
+type FixedChargeDeduction struct {
+	FixedCharges       []fixed_charge.FixedCharge
+	FixedChargesTotal  shared.Money
+	ChargeTransactions []transaction.Transaction
+	// AlreadyApplied lists the charges skipped because an earlier income
+	// already paid them this month
+	AlreadyApplied []fixed_charge.FixedCharge
+}
+
+// deductFixedCharges records an expense on the account for every fixed
+// charge due in the month of paidAt that has not been deducted yet, so that
+// two salaries landing in the same month never pay the same charge twice
+func deductFixedCharges(
+	repos Repositories,
+	converter CurrencyConverter,
+	accountID string,
+	currency string,
+	paidAt time.Time,
+) (FixedChargeDeduction, error) {
+	deduction := FixedChargeDeduction{
+		FixedChargesTotal: shared.ZeroOf(currency),
+	}
+
+	charges, err := repos.FixedCharges.FindActive()
+	if err != nil {
+		return deduction, fmt.Errorf("failed to get fixed charges: %w", err)
+	}
+
+	monthTransactions, err := repos.Transactions.FindByMonth(paidAt.Year(), paidAt.Month())
+	if err != nil {
+		return deduction, fmt.Errorf("failed to get transactions: %w", err)
+	}
+	applied := make(map[string]bool)
+	for _, tx := range monthTransactions {
+		if tx.IsFixedCharge() {
+			applied[tx.FixedChargeID()] = true
+		}
+	}
+
+	for _, charge := range fixed_charge.FilterForMonth(charges, paidAt.Year(), paidAt.Month()) {
+		if applied[charge.ID()] {
+			deduction.AlreadyApplied = append(deduction.AlreadyApplied, charge)
+			continue
+		}
+
+		// Charges may be priced in another currency than the income, so the
+		// deducted total is expressed in the income currency
+		converted, err := converter.Convert(charge.Amount(), currency, paidAt)
+		if err != nil {
+			return deduction, fmt.Errorf("failed to convert fixed charge %s: %w", charge.Name(), err)
+		}
+		if deduction.FixedChargesTotal, err = deduction.FixedChargesTotal.Add(converted); err != nil {
+			return deduction, fmt.Errorf("failed to total fixed charges: %w", err)
+		}
+
+		category, _ := shared.NewCategory(charge.Name(), shared.CategoryTypeExpense)
+
+		chargeTx := transaction.NewTransaction(
+			uuid.New().String(),
+			charge.Amount(),
+			category,
+			fmt.Sprintf("Fixed charge: %s", charge.Description()),
+			transaction.TransactionTypeExpense,
+			charge.DueDate(paidAt),
+		).WithFixedChargeID(charge.ID()).WithAccountID(accountID)
+
+		if err := repos.Transactions.Save(chargeTx); err != nil {
+			return deduction, fmt.Errorf("failed to save fixed charge transaction: %w", err)
+		}
+
+		deduction.FixedCharges = append(deduction.FixedCharges, charge)
+		deduction.ChargeTransactions = append(deduction.ChargeTransactions, chargeTx)
+	}
+
+	return deduction, nil
+}
@@ -0,0 +1,212 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/domain/goal"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+// savingsLookbackMonths is how many complete months the average monthly net
+// savings is taken over
+const savingsLookbackMonths = 6
+
+// GetGoalProgressUseCase reports how far each savings goal is and when it
+// should be reached if the average monthly net savings of the last months
+// keeps going to it
+type GetGoalProgressUseCase struct {
+	goalRepo        goal.Repository
+	accountRepo     account.Repository
+	transactionRepo transaction.Repository
+	converter       CurrencyConverter
+}
+
+func NewGetGoalProgressUseCase(
+	goalRepo goal.Repository,
+	accountRepo account.Repository,
+	transactionRepo transaction.Repository,
+	converter CurrencyConverter,
+) *GetGoalProgressUseCase {
+	return &GetGoalProgressUseCase{
+		goalRepo:        goalRepo,
+		accountRepo:     accountRepo,
+		transactionRepo: transactionRepo,
+		converter:       converter,
+	}
+}
+
+type GetGoalProgressInput struct {
+	Today time.Time
+}
+
+// GoalProgress holds Saved and Remaining in the goal currency.
+// ProjectedCompletion is nil once the goal is reached or when nothing is
+// being saved. A goal is OnTrack when it is projected to be reached by its
+// deadline, if it has one.
+type GoalProgress struct {
+	Goal                goal.Goal
+	Account             account.Account
+	Saved               shared.Money
+	Remaining           shared.Money
+	PercentageSaved     float64
+	Reached             bool
+	ProjectedCompletion *time.Time
+	OnTrack             bool
+}
+
+// GetGoalProgressOutput holds AverageMonthlySavings in BaseCurrency, averaged
+// over MonthsAveraged complete months before the current one
+type GetGoalProgressOutput struct {
+	BaseCurrency          string
+	AverageMonthlySavings shared.Money
+	MonthsAveraged        int
+	Goals                 []GoalProgress
+}
+
+func (uc *GetGoalProgressUseCase) Execute(input GetGoalProgressInput) (*GetGoalProgressOutput, error) {
+	base := uc.converter.BaseCurrency()
+
+	goals, err := uc.goalRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get goals: %w", err)
+	}
+
+	accounts, err := uc.accountRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+	accountMap := make(map[string]account.Account)
+	for _, a := range accounts {
+		accountMap[a.ID()] = a
+	}
+
+	average, months, err := uc.averageMonthlySavings(input.Today)
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make([]GoalProgress, 0, len(goals))
+	for _, g := range goals {
+		saved, err := uc.saved(g)
+		if err != nil {
+			return nil, err
+		}
+
+		remaining, err := g.RemainingAmount(saved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute remaining amount of goal %s: %w", g.Name(), err)
+		}
+
+		percentage, err := g.PercentageSaved(saved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute progress of goal %s: %w", g.Name(), err)
+		}
+		reached, err := g.IsReached(saved)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute progress of goal %s: %w", g.Name(), err)
+		}
+
+		p := GoalProgress{
+			Goal:            g,
+			Account:         accountMap[g.AccountID()],
+			Saved:           saved,
+			Remaining:       remaining,
+			PercentageSaved: percentage,
+			Reached:         reached,
+		}
+
+		if p.Reached {
+			p.OnTrack = true
+		} else {
+			remainingInBase, err := uc.converter.Convert(remaining, base, input.Today)
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert goal %s: %w", g.Name(), err)
+			}
+			p.ProjectedCompletion = goal.ProjectCompletion(remainingInBase, average, input.Today)
+			p.OnTrack = p.ProjectedCompletion != nil &&
+				(g.Deadline() == nil || !p.ProjectedCompletion.After(*g.Deadline()))
+		}
+
+		progress = append(progress, p)
+	}
+
+	return &GetGoalProgressOutput{
+		BaseCurrency:          base,
+		AverageMonthlySavings: average,
+		MonthsAveraged:        months,
+		Goals:                 progress,
+	}, nil
+}
+
+// saved totals the contributions to a goal in its currency
+func (uc *GetGoalProgressUseCase) saved(g goal.Goal) (shared.Money, error) {
+	contributions, err := uc.transactionRepo.FindByGoalID(g.ID())
+	if err != nil {
+		return shared.Money{}, fmt.Errorf("failed to get contributions: %w", err)
+	}
+
+	saved := shared.ZeroOf(g.Target().Currency())
+	for _, tx := range contributions {
+		if !tx.IsInflow() {
+			continue
+		}
+		amount, err := uc.converter.Convert(tx.Amount(), saved.Currency(), tx.CreatedAt())
+		if err != nil {
+			return shared.Money{}, fmt.Errorf("failed to convert contribution %s: %w", tx.ID(), err)
+		}
+		if saved, err = saved.Add(amount); err != nil {
+			return shared.Money{}, fmt.Errorf("failed to total contributions: %w", err)
+		}
+	}
+
+	return saved, nil
+}
+
+// averageMonthlySavings averages the net savings, income minus expenses, of
+// the complete months before today's, starting from the first month with any
+// transaction so that a new user is not penalised for months without data
+func (uc *GetGoalProgressUseCase) averageMonthlySavings(today time.Time) (shared.Money, int, error) {
+	base := uc.converter.BaseCurrency()
+	thisMonth := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+	start := thisMonth.AddDate(0, -savingsLookbackMonths, 0)
+
+	transactions, err := uc.transactionRepo.FindByDateRange(start, thisMonth.Add(-time.Second))
+	if err != nil {
+		return shared.Money{}, 0, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	net := shared.ZeroOf(base)
+	firstMonth := thisMonth
+	for _, tx := range transactions {
+		month := time.Date(tx.CreatedAt().Year(), tx.CreatedAt().Month(), 1, 0, 0, 0, 0, time.UTC)
+		if month.Before(firstMonth) {
+			firstMonth = month
+		}
+
+		if !tx.IsIncome() && !tx.IsExpense() {
+			continue
+		}
+
+		amount, err := uc.converter.Convert(tx.Amount(), base, tx.CreatedAt())
+		if err != nil {
+			return shared.Money{}, 0, fmt.Errorf("failed to convert transaction %s: %w", tx.ID(), err)
+		}
+		if tx.IsIncome() {
+			net, err = net.Add(amount)
+		} else {
+			net, err = net.Subtract(amount)
+		}
+		if err != nil {
+			return shared.Money{}, 0, fmt.Errorf("failed to total net savings: %w", err)
+		}
+	}
+
+	months := (thisMonth.Year()-firstMonth.Year())*12 + int(thisMonth.Month()) - int(firstMonth.Month())
+	if months == 0 {
+		return shared.ZeroOf(base), 0, nil
+	}
+
+	return shared.UnsafeNewMoney(net.MinorUnits()/int64(months), base), months, nil
+}
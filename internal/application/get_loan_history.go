@@ -0,0 +1,66 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+type GetLoanHistoryUseCase struct {
+	loanRepo        loan.Repository
+	paymentRepo     loan.PaymentRepository
+	installmentRepo loan.InstallmentRepository
+}
+
+func NewGetLoanHistoryUseCase(
+	loanRepo loan.Repository,
+	paymentRepo loan.PaymentRepository,
+	installmentRepo loan.InstallmentRepository,
+) *GetLoanHistoryUseCase {
+	return &GetLoanHistoryUseCase{
+		loanRepo:        loanRepo,
+		paymentRepo:     paymentRepo,
+		installmentRepo: installmentRepo,
+	}
+}
+
+type GetLoanHistoryInput struct {
+	LoanID string
+	// At is the day installments are checked for being overdue
+	At time.Time
+}
+
+type GetLoanHistoryOutput struct {
+	Loan     loan.Loan
+	Payments []loan.Payment
+	Schedule []loan.InstallmentStatus
+}
+
+func (uc *GetLoanHistoryUseCase) Execute(input GetLoanHistoryInput) (*GetLoanHistoryOutput, error) {
+	// Validate input
+	if input.LoanID == "" {
+		return nil, fmt.Errorf("loan ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	loanObj, err := uc.loanRepo.FindByID(input.LoanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find loan: %w", err)
+	}
+
+	payments, err := uc.paymentRepo.FindByLoanID(loanObj.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan payments: %w", err)
+	}
+
+	installments, err := uc.installmentRepo.FindByLoanID(loanObj.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get installments: %w", err)
+	}
+
+	return &GetLoanHistoryOutput{
+		Loan:     loanObj,
+		Payments: payments,
+		Schedule: loan.ScheduleStatus(loanObj, installments, input.At),
+	}, nil
+}
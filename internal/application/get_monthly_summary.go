@@ -2,11 +2,14 @@ package application
 
 import (
 	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
 	"github.com/aymaneelmaini/moka/internal/domain/budget"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
 	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
 	"github.com/aymaneelmaini/moka/internal/domain/loan"
 	"github.com/aymaneelmaini/moka/internal/domain/transaction"
 	"github.com/aymaneelmaini/moka/internal/shared"
+	"sort"
 	"time"
 )
 
@@ -14,20 +17,32 @@ type GetMonthlySummaryUseCase struct {
 	transactionRepo transaction.Repository
 	budgetRepo      budget.Repository
 	loanRepo        loan.Repository
+	installmentRepo loan.InstallmentRepository
 	fixedChargeRepo fixed_charge.Repository
+	categoryRepo    category.Repository
+	accountRepo     account.Repository
+	converter       CurrencyConverter
 }
 
 func NewGetMonthlySummaryUseCase(
 	transactionRepo transaction.Repository,
 	budgetRepo budget.Repository,
 	loanRepo loan.Repository,
+	installmentRepo loan.InstallmentRepository,
 	fixedChargeRepo fixed_charge.Repository,
+	categoryRepo category.Repository,
+	accountRepo account.Repository,
+	converter CurrencyConverter,
 ) *GetMonthlySummaryUseCase {
 	return &GetMonthlySummaryUseCase{
 		transactionRepo: transactionRepo,
 		budgetRepo:      budgetRepo,
 		loanRepo:        loanRepo,
+		installmentRepo: installmentRepo,
 		fixedChargeRepo: fixedChargeRepo,
+		categoryRepo:    categoryRepo,
+		accountRepo:     accountRepo,
+		converter:       converter,
 	}
 }
 
@@ -36,28 +51,75 @@ type GetMonthlySummaryInput struct {
 	Month time.Month
 }
 
+// CategorySummary describes the spending of a category. Spent includes the
+// spending of its Children, the sub-categories with spending this month.
 type CategorySummary struct {
 	CategoryName   string
+	Color          string
+	Icon           string
 	Spent          shared.Money
 	Budget         *shared.Money
 	Remaining      *shared.Money
 	PercentageUsed *float64
 	BudgetExceeded bool
+	Children       []CategorySummary
 }
 
+// PersonPosition sums the active loans with one person. Net is positive when
+// they owe us more than we owe them.
+type PersonPosition struct {
+	Name       string
+	Owed       shared.Money
+	Receivable shared.Money
+	Net        shared.Money
+}
+
+// InstallmentDue is an unpaid installment of an active loan, due in the month
+// or already overdue
+type InstallmentDue struct {
+	Loan loan.Loan
+	loan.InstallmentStatus
+}
+
+// FixedChargeStatus tells whether a fixed charge was deducted in the month.
+// Amount, in the base currency, is what was actually deducted for an applied
+// charge and the current price of a pending one.
+type FixedChargeStatus struct {
+	Charge  fixed_charge.FixedCharge
+	Applied bool
+	Amount  shared.Money
+}
+
+// AccountBalance is what an account holds at the end of the month, in the
+// base currency
+type AccountBalance struct {
+	Account account.Account
+	Balance shared.Money
+}
+
+// GetMonthlySummaryOutput holds every total in BaseCurrency. Transactions,
+// ActiveLoans and ActiveReceivables keep their original currencies.
 type GetMonthlySummaryOutput struct {
-	Year              int
-	Month             time.Month
-	TotalIncome       shared.Money
-	TotalExpenses     shared.Money
-	NetSavings        shared.Money
-	Balance           shared.Money
-	CategorySummaries []CategorySummary
-	TotalLoansOwed    shared.Money
-	ActiveLoans       []loan.Loan
-	FixedCharges      []fixed_charge.FixedCharge
-	FixedChargesTotal shared.Money
-	Transactions      []transaction.Transaction
+	Year                int
+	Month               time.Month
+	BaseCurrency        string
+	TotalIncome         shared.Money
+	TotalExpenses       shared.Money
+	NetSavings          shared.Money
+	Balance             shared.Money
+	ProjectedBalance    shared.Money // Balance once the pending fixed charges are paid
+	CategorySummaries   []CategorySummary
+	TotalLoansOwed      shared.Money
+	ActiveLoans         []loan.Loan
+	TotalReceivables    shared.Money
+	ActiveReceivables   []loan.Loan
+	NetPositions        []PersonPosition
+	InstallmentsDue     []InstallmentDue
+	FixedCharges        []FixedChargeStatus
+	FixedChargesApplied shared.Money
+	FixedChargesPending shared.Money
+	AccountBalances     []AccountBalance
+	Transactions        []transaction.Transaction
 }
 
 func (uc *GetMonthlySummaryUseCase) Execute(input GetMonthlySummaryInput) (*GetMonthlySummaryOutput, error) {
@@ -66,30 +128,76 @@ func (uc *GetMonthlySummaryUseCase) Execute(input GetMonthlySummaryInput) (*GetM
 		return nil, fmt.Errorf("failed to get transactions: %w", err)
 	}
 
-	totalIncome := transaction.CalculateMonthlyTotal(transactions, transaction.TransactionTypeIncome)
-	totalExpenses := transaction.CalculateMonthlyTotal(transactions, transaction.TransactionTypeExpense)
-	balance := transaction.CalculateBalance(transactions)
+	base := uc.converter.BaseCurrency()
+	startOfMonth := time.Date(input.Year, input.Month, 1, 0, 0, 0, 0, time.UTC)
+
+	converted := make([]transaction.Transaction, 0, len(transactions))
+	for _, tx := range transactions {
+		amount, err := uc.converter.Convert(tx.Amount(), base, tx.CreatedAt())
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert transaction %s: %w", tx.ID(), err)
+		}
+		converted = append(converted, tx.WithAmount(amount))
+	}
+
+	totalIncome, err := transaction.CalculateMonthlyTotal(converted, transaction.TransactionTypeIncome)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total income: %w", err)
+	}
+	totalExpenses, err := transaction.CalculateMonthlyTotal(converted, transaction.TransactionTypeExpense)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total expenses: %w", err)
+	}
+	balance, err := transaction.CalculateBalance(converted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute balance: %w", err)
+	}
+
+	categories, err := uc.categoryRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	parents := category.ParentNames(categories)
+	categoryMap := make(map[string]category.Category)
+	for _, c := range categories {
+		categoryMap[c.Name()] = c
+	}
 
-	categoryTotals := transaction.CalculateCategoryTotal(transactions)
+	categoryTotals, err := transaction.CalculateCategoryTotal(converted, parents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to total categories: %w", err)
+	}
 
 	budgets, _ := uc.budgetRepo.FindByMonthAndYear(input.Month, input.Year)
 	budgetMap := make(map[string]budget.Budget)
 	for _, b := range budgets {
-		budgetMap[b.Category().Name()] = b
+		limit, err := uc.converter.Convert(b.Limit(), base, startOfMonth)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert budget %s: %w", b.Category().Name(), err)
+		}
+		budgetMap[b.Category().Name()] = b.WithLimit(limit)
 	}
 
-	var categorySummaries []CategorySummary
+	summaries := make(map[string]CategorySummary)
 	for categoryName, spent := range categoryTotals {
 		summary := CategorySummary{
 			CategoryName: categoryName,
+			Color:        categoryMap[categoryName].Color(),
+			Icon:         categoryMap[categoryName].Icon(),
 			Spent:        spent,
 		}
 
 		if b, exists := budgetMap[categoryName]; exists {
 			limit := b.Limit()
-			remaining := b.RemainingAmount(spent)
+			remaining, err := b.RemainingAmount(spent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compute remaining budget: %w", err)
+			}
 			percentage := b.PercentageUsed(spent)
-			exceeded := b.IsExceeded(spent)
+			exceeded, err := b.IsExceeded(spent)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compare spending with budget: %w", err)
+			}
 
 			summary.Budget = &limit
 			summary.Remaining = &remaining
@@ -97,31 +205,279 @@ func (uc *GetMonthlySummaryUseCase) Execute(input GetMonthlySummaryInput) (*GetM
 			summary.BudgetExceeded = exceeded
 		}
 
-		categorySummaries = append(categorySummaries, summary)
+		summaries[categoryName] = summary
+	}
+
+	categorySummaries := buildCategoryTree(summaries, parents)
+
+	now := time.Now()
+
+	allActiveLoans, _ := uc.loanRepo.FindActive()
+	activeLoans := loan.FilterByDirection(allActiveLoans, loan.LoanDirectionBorrowed)
+	activeReceivables := loan.FilterByDirection(allActiveLoans, loan.LoanDirectionLent)
+
+	totalLoansOwed := shared.ZeroOf(base)
+	totalReceivables := shared.ZeroOf(base)
+	positions := make(map[string]PersonPosition)
+	for _, l := range allActiveLoans {
+		remaining, err := uc.converter.Convert(l.RemainingAmount(), base, now)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert loan %s: %w", l.ID(), err)
+		}
+
+		key := loan.PersonKey(l.LenderName())
+		position, exists := positions[key]
+		if !exists {
+			position = PersonPosition{
+				Name:       l.LenderName(),
+				Owed:       shared.ZeroOf(base),
+				Receivable: shared.ZeroOf(base),
+			}
+		}
+
+		if l.IsReceivable() {
+			if totalReceivables, err = totalReceivables.Add(remaining); err != nil {
+				return nil, fmt.Errorf("failed to total receivables: %w", err)
+			}
+			position.Receivable, _ = position.Receivable.Add(remaining)
+		} else {
+			if totalLoansOwed, err = totalLoansOwed.Add(remaining); err != nil {
+				return nil, fmt.Errorf("failed to total loans: %w", err)
+			}
+			position.Owed, _ = position.Owed.Add(remaining)
+		}
+
+		positions[key] = position
+	}
+
+	netPositions := make([]PersonPosition, 0, len(positions))
+	for _, position := range positions {
+		position.Net, _ = position.Receivable.Subtract(position.Owed)
+		netPositions = append(netPositions, position)
+	}
+	sort.Slice(netPositions, func(i, j int) bool {
+		return netPositions[i].Name < netPositions[j].Name
+	})
+
+	installmentsDue, err := uc.installmentsDue(allActiveLoans, input.Year, input.Month, now)
+	if err != nil {
+		return nil, err
+	}
+
+	charges, err := uc.fixedChargeRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get fixed charges: %w", err)
 	}
 
-	activeLoans, _ := uc.loanRepo.FindActive()
-	totalLoansOwed := loan.CalculateTotalOwed(activeLoans)
+	fixedCharges, err := uc.fixedChargeStatuses(charges, converted, input.Year, input.Month)
+	if err != nil {
+		return nil, err
+	}
+
+	// Applied charges are already part of the month's expenses
+	fixedChargesApplied := shared.ZeroOf(base)
+	fixedChargesPending := shared.ZeroOf(base)
+	for _, status := range fixedCharges {
+		if status.Applied {
+			fixedChargesApplied, err = fixedChargesApplied.Add(status.Amount)
+		} else {
+			fixedChargesPending, err = fixedChargesPending.Add(status.Amount)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to total fixed charges: %w", err)
+		}
+	}
 
-	fixedCharges, _ := uc.fixedChargeRepo.FindActive()
-	fixedChargesTotal := fixed_charge.CalculateTotalCharges(fixedCharges)
+	accountBalances, err := uc.accountBalances(startOfMonth.AddDate(0, 1, 0).Add(-time.Second))
+	if err != nil {
+		return nil, err
+	}
 
-	totalExpensesWithFixed := totalExpenses.Add(fixedChargesTotal)
-	netSavingsWithFixed := totalIncome.Subtract(totalExpensesWithFixed)
-	balanceWithFixed := balance.Subtract(fixedChargesTotal)
+	netSavings, err := totalIncome.Subtract(totalExpenses)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute net savings: %w", err)
+	}
+	projectedBalance, err := balance.Subtract(fixedChargesPending)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute projected balance: %w", err)
+	}
 
 	return &GetMonthlySummaryOutput{
-		Year:              input.Year,
-		Month:             input.Month,
-		TotalIncome:       totalIncome,
-		TotalExpenses:     totalExpensesWithFixed,
-		NetSavings:        netSavingsWithFixed,
-		Balance:           balanceWithFixed,
-		CategorySummaries: categorySummaries,
-		TotalLoansOwed:    totalLoansOwed,
-		ActiveLoans:       activeLoans,
-		FixedCharges:      fixedCharges,
-		FixedChargesTotal: fixedChargesTotal,
-		Transactions:      transactions,
+		Year:                input.Year,
+		Month:               input.Month,
+		BaseCurrency:        base,
+		TotalIncome:         totalIncome,
+		TotalExpenses:       totalExpenses,
+		NetSavings:          netSavings,
+		Balance:             balance,
+		ProjectedBalance:    projectedBalance,
+		CategorySummaries:   categorySummaries,
+		TotalLoansOwed:      totalLoansOwed,
+		ActiveLoans:         activeLoans,
+		TotalReceivables:    totalReceivables,
+		ActiveReceivables:   activeReceivables,
+		NetPositions:        netPositions,
+		InstallmentsDue:     installmentsDue,
+		FixedCharges:        fixedCharges,
+		FixedChargesApplied: fixedChargesApplied,
+		FixedChargesPending: fixedChargesPending,
+		AccountBalances:     accountBalances,
+		Transactions:        transactions,
 	}, nil
 }
+
+// accountBalances totals every transaction of each account up to the end of
+// the month, transfers included
+func (uc *GetMonthlySummaryUseCase) accountBalances(endOfMonth time.Time) ([]AccountBalance, error) {
+	base := uc.converter.BaseCurrency()
+
+	accounts, err := uc.accountRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get accounts: %w", err)
+	}
+
+	transactions, err := uc.transactionRepo.FindByDateRange(time.Time{}, endOfMonth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transactions: %w", err)
+	}
+
+	converted := make([]transaction.Transaction, 0, len(transactions))
+	for _, tx := range transactions {
+		amount, err := uc.converter.Convert(tx.Amount(), base, tx.CreatedAt())
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert transaction %s: %w", tx.ID(), err)
+		}
+		converted = append(converted, tx.WithAmount(amount))
+	}
+
+	balances, err := transaction.CalculateBalanceByAccount(converted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute account balances: %w", err)
+	}
+
+	accountBalances := make([]AccountBalance, 0, len(accounts))
+	for _, a := range accounts {
+		balance, exists := balances[a.ID()]
+		if !exists {
+			balance = shared.ZeroOf(base)
+		}
+		accountBalances = append(accountBalances, AccountBalance{Account: a, Balance: balance})
+	}
+
+	return accountBalances, nil
+}
+
+// fixedChargeStatuses reports each fixed charge deducted in the month,
+// whatever its status today, followed by those that apply to the month but
+// have not been deducted yet
+func (uc *GetMonthlySummaryUseCase) fixedChargeStatuses(
+	charges []fixed_charge.FixedCharge,
+	converted []transaction.Transaction,
+	year int,
+	month time.Month,
+) ([]FixedChargeStatus, error) {
+	base := uc.converter.BaseCurrency()
+
+	deducted := make(map[string]shared.Money)
+	for _, tx := range converted {
+		if !tx.IsFixedCharge() || !tx.IsExpense() {
+			continue
+		}
+		total, exists := deducted[tx.FixedChargeID()]
+		if !exists {
+			total = shared.ZeroOf(base)
+		}
+		total, err := total.Add(tx.Amount())
+		if err != nil {
+			return nil, fmt.Errorf("failed to total fixed charge deductions: %w", err)
+		}
+		deducted[tx.FixedChargeID()] = total
+	}
+
+	var applied, pending []FixedChargeStatus
+
+	for _, charge := range charges {
+		if amount, exists := deducted[charge.ID()]; exists {
+			applied = append(applied, FixedChargeStatus{Charge: charge, Applied: true, Amount: amount})
+			continue
+		}
+
+		if !charge.AppliesTo(year, month) {
+			continue
+		}
+
+		amount, err := uc.converter.Convert(charge.Amount(), base, time.Date(year, month, 1, 0, 0, 0, 0, time.UTC))
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert fixed charge %s: %w", charge.Name(), err)
+		}
+		pending = append(pending, FixedChargeStatus{Charge: charge, Amount: amount})
+	}
+
+	return append(applied, pending...), nil
+}
+
+// installmentsDue lists the unpaid installments due in the month, and those
+// overdue from before it, soonest first
+func (uc *GetMonthlySummaryUseCase) installmentsDue(
+	loans []loan.Loan,
+	year int,
+	month time.Month,
+	now time.Time,
+) ([]InstallmentDue, error) {
+	var due []InstallmentDue
+
+	for _, l := range loans {
+		installments, err := uc.installmentRepo.FindByLoanID(l.ID())
+		if err != nil {
+			return nil, fmt.Errorf("failed to get installments: %w", err)
+		}
+
+		for _, status := range loan.ScheduleStatus(l, installments, now) {
+			dueDate := status.Installment.DueDate()
+			inMonth := dueDate.Year() == year && dueDate.Month() == month
+			if !status.Paid && (inMonth || status.Overdue) {
+				due = append(due, InstallmentDue{Loan: l, InstallmentStatus: status})
+			}
+		}
+	}
+
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].Installment.DueDate().Before(due[j].Installment.DueDate())
+	})
+
+	return due, nil
+}
+
+// buildCategoryTree nests each sub-category summary under its parent and
+// orders every level by spending, highest first
+func buildCategoryTree(summaries map[string]CategorySummary, parents map[string]string) []CategorySummary {
+	children := make(map[string][]CategorySummary)
+	var roots []CategorySummary
+
+	for name, summary := range summaries {
+		if parentName, exists := parents[name]; exists {
+			if _, hasParent := summaries[parentName]; hasParent {
+				children[parentName] = append(children[parentName], summary)
+				continue
+			}
+		}
+		roots = append(roots, summary)
+	}
+
+	for i := range roots {
+		roots[i].Children = children[roots[i].CategoryName]
+		sortBySpending(roots[i].Children)
+	}
+	sortBySpending(roots)
+
+	return roots
+}
+
+func sortBySpending(summaries []CategorySummary) {
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Spent.MinorUnits() != summaries[j].Spent.MinorUnits() {
+			return summaries[i].Spent.MinorUnits() > summaries[j].Spent.MinorUnits()
+		}
+		return summaries[i].CategoryName < summaries[j].CategoryName
+	})
+}
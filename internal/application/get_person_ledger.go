@@ -0,0 +1,191 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"sort"
+	"time"
+)
+
+// GetPersonLedgerUseCase gathers every loan with one person, borrowed or lent,
+// into a single timeline with the running balance between us
+type GetPersonLedgerUseCase struct {
+	loanRepo    loan.Repository
+	paymentRepo loan.PaymentRepository
+	converter   CurrencyConverter
+}
+
+func NewGetPersonLedgerUseCase(
+	loanRepo loan.Repository,
+	paymentRepo loan.PaymentRepository,
+	converter CurrencyConverter,
+) *GetPersonLedgerUseCase {
+	return &GetPersonLedgerUseCase{
+		loanRepo:    loanRepo,
+		paymentRepo: paymentRepo,
+		converter:   converter,
+	}
+}
+
+type GetPersonLedgerInput struct {
+	Name string
+	// At is the date amounts are converted at
+	At time.Time
+}
+
+// LedgerEntry is one movement between us and the person. Change and Balance
+// are in the base currency and positive when the person owes us.
+type LedgerEntry struct {
+	Date        time.Time
+	LoanID      string
+	Description string
+	Amount      shared.Money
+	Change      shared.Money
+	Balance     shared.Money
+}
+
+// GetPersonLedgerOutput lists the entries oldest first. Owed, Receivable and
+// Net are the current position in BaseCurrency, Net being positive when the
+// person owes us.
+type GetPersonLedgerOutput struct {
+	Name         string
+	BaseCurrency string
+	Loans        []loan.Loan
+	Entries      []LedgerEntry
+	Owed         shared.Money
+	Receivable   shared.Money
+	Net          shared.Money
+}
+
+func (uc *GetPersonLedgerUseCase) Execute(input GetPersonLedgerInput) (*GetPersonLedgerOutput, error) {
+	// Validate input
+	name := loan.NormalizeName(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	allLoans, err := uc.loanRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loans: %w", err)
+	}
+
+	loans := loan.FilterByLender(allLoans, name)
+	if len(loans) > 0 {
+		name = loans[0].LenderName()
+	}
+
+	base := uc.converter.BaseCurrency()
+	output := &GetPersonLedgerOutput{
+		Name:         name,
+		BaseCurrency: base,
+		Loans:        loans,
+		Owed:         shared.ZeroOf(base),
+		Receivable:   shared.ZeroOf(base),
+	}
+
+	var entries []LedgerEntry
+	for _, l := range loans {
+		loanEntries, err := uc.loanEntries(l, input.At)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, loanEntries...)
+
+		if !l.IsActive() {
+			continue
+		}
+
+		remaining, err := uc.converter.Convert(l.RemainingAmount(), base, input.At)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert loan %s: %w", l.ID(), err)
+		}
+		if l.IsReceivable() {
+			output.Receivable, _ = output.Receivable.Add(remaining)
+		} else {
+			output.Owed, _ = output.Owed.Add(remaining)
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Date.Before(entries[j].Date)
+	})
+
+	balance := shared.ZeroOf(base)
+	for i := range entries {
+		balance, _ = balance.Add(entries[i].Change)
+		entries[i].Balance = balance
+	}
+
+	output.Entries = entries
+	output.Net, _ = output.Receivable.Subtract(output.Owed)
+
+	return output, nil
+}
+
+// loanEntries lists the principal, the payments and, for a cancelled loan,
+// the amount written off. Everything is converted at the same date so that a
+// settled loan nets to exactly zero.
+func (uc *GetPersonLedgerUseCase) loanEntries(l loan.Loan, at time.Time) ([]LedgerEntry, error) {
+	payments, err := uc.paymentRepo.FindByLoanID(l.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get loan payments: %w", err)
+	}
+
+	// The principal raises what the person owes us when we lend, and what we
+	// owe them when we borrow; repayments do the opposite
+	sign := int64(-1)
+	principal := fmt.Sprintf("Borrowed: %s", l.Description())
+	if l.IsReceivable() {
+		sign = 1
+		principal = fmt.Sprintf("Lent: %s", l.Description())
+	}
+
+	entry := func(date time.Time, description string, amount shared.Money, sign int64) (LedgerEntry, error) {
+		converted, err := uc.converter.Convert(amount, uc.converter.BaseCurrency(), at)
+		if err != nil {
+			return LedgerEntry{}, fmt.Errorf("failed to convert loan %s: %w", l.ID(), err)
+		}
+		return LedgerEntry{
+			Date:        date,
+			LoanID:      l.ID(),
+			Description: description,
+			Amount:      amount,
+			Change:      shared.UnsafeNewMoney(sign*converted.MinorUnits(), converted.Currency()),
+		}, nil
+	}
+
+	first, err := entry(l.BorrowedAt(), principal, l.Amount(), sign)
+	if err != nil {
+		return nil, err
+	}
+	entries := []LedgerEntry{first}
+	lastDate := l.BorrowedAt()
+
+	for _, p := range payments {
+		description := "Paid back"
+		if l.IsReceivable() {
+			description = "Collected"
+		}
+
+		e, err := entry(p.PaidAt(), description, p.Amount(), -sign)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+
+		if p.PaidAt().After(lastDate) {
+			lastDate = p.PaidAt()
+		}
+	}
+
+	if l.IsCancelled() && l.RemainingAmount().IsPositive() {
+		e, err := entry(lastDate, "Cancelled, no longer owed", l.RemainingAmount(), -sign)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, e)
+	}
+
+	return entries, nil
+}
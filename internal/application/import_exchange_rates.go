@@ -0,0 +1,105 @@
+package application
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/exchange_rate"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"io"
+	"strings"
+	"time"
+)
+
+type ImportExchangeRatesUseCase struct {
+	rateRepo exchange_rate.Repository
+}
+
+func NewImportExchangeRatesUseCase(rateRepo exchange_rate.Repository) *ImportExchangeRatesUseCase {
+	return &ImportExchangeRatesUseCase{
+		rateRepo: rateRepo,
+	}
+}
+
+// ImportExchangeRatesInput reads CSV rows of "date,currency,rate" or
+// "date,currency,rate,quote_currency", with dates as YYYY-MM-DD. A header
+// row is skipped and a missing quote currency defaults to QuoteCurrency.
+type ImportExchangeRatesInput struct {
+	CSV           io.Reader
+	QuoteCurrency string
+}
+
+type ImportExchangeRatesOutput struct {
+	Imported []exchange_rate.Rate
+	Errors   []ImportRowError
+}
+
+// ImportRowError reports why a single line of an imported file was rejected
+type ImportRowError struct {
+	Line int
+	Err  error
+}
+
+func (e ImportRowError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (uc *ImportExchangeRatesUseCase) Execute(input ImportExchangeRatesInput) (*ImportExchangeRatesOutput, error) {
+	if input.CSV == nil {
+		return nil, fmt.Errorf("csv file cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	reader := csv.NewReader(input.CSV)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	output := &ImportExchangeRatesOutput{}
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			output.Errors = append(output.Errors, ImportRowError{Line: line, Err: err})
+			continue
+		}
+
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+
+		rateObj, err := parseExchangeRateRecord(record, input.QuoteCurrency)
+		if err != nil {
+			output.Errors = append(output.Errors, ImportRowError{Line: line, Err: err})
+			continue
+		}
+
+		if err := uc.rateRepo.Save(rateObj); err != nil {
+			output.Errors = append(output.Errors, ImportRowError{Line: line, Err: err})
+			continue
+		}
+
+		output.Imported = append(output.Imported, rateObj)
+	}
+
+	return output, nil
+}
+
+func parseExchangeRateRecord(record []string, defaultQuote string) (exchange_rate.Rate, error) {
+	if len(record) < 3 || len(record) > 4 {
+		return exchange_rate.Rate{}, fmt.Errorf("expected 3 or 4 columns, got %d: %w", len(record), shared.ErrInvalidInput)
+	}
+
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(record[0]))
+	if err != nil {
+		return exchange_rate.Rate{}, fmt.Errorf("invalid date %q: %w", record[0], shared.ErrInvalidInput)
+	}
+
+	quote := defaultQuote
+	if len(record) == 4 && strings.TrimSpace(record[3]) != "" {
+		quote = record[3]
+	}
+
+	return newExchangeRate(record[1], quote, record[2], date)
+}
@@ -0,0 +1,441 @@
+package application
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// SignConvention tells how a bank statement tells money going out from money
+// coming in
+type SignConvention string
+
+const (
+	// SignNegativeIsExpense reads -12.50 as a payment, as most bank accounts do
+	SignNegativeIsExpense SignConvention = "negative_expense"
+	// SignPositiveIsExpense reads 12.50 as a payment, as credit card
+	// statements often do
+	SignPositiveIsExpense SignConvention = "positive_expense"
+	// SignDebitCredit reads payments from a debit column and money received
+	// from a credit column
+	SignDebitCredit SignConvention = "debit_credit"
+)
+
+// StatementDateFormat is a way of writing dates found in bank statements
+type StatementDateFormat struct {
+	Label  string
+	Layout string
+}
+
+// StatementDateFormats lists the date formats offered in the UI
+var StatementDateFormats = []StatementDateFormat{
+	{Label: "YYYY-MM-DD", Layout: "2006-01-02"},
+	{Label: "DD/MM/YYYY", Layout: "02/01/2006"},
+	{Label: "MM/DD/YYYY", Layout: "01/02/2006"},
+	{Label: "DD.MM.YYYY", Layout: "02.01.2006"},
+	{Label: "DD-MM-YYYY", Layout: "02-01-2006"},
+}
+
+// StatementMapping tells which column, counted from 1, holds each field of a
+// bank statement and how values are written. AmountColumn is read unless
+// the sign convention is SignDebitCredit, which reads DebitColumn and
+// CreditColumn instead.
+type StatementMapping struct {
+	DateColumn        int
+	DescriptionColumn int
+	AmountColumn      int
+	DebitColumn       int
+	CreditColumn      int
+	SignConvention    SignConvention
+	DateFormat        string // a StatementDateFormats label
+	DecimalComma      bool   // "1.234,56" rather than "1,234.56"
+	Delimiter         string // a single character, "," when empty
+	SkipHeader        bool
+}
+
+// ImportStatementUseCase loads the history of an account from a CSV bank
+// statement. Without Commit it only previews how each row would be read.
+// Rows that cannot be read are reported and skipped; the others are
+// imported together, so a storage failure leaves none of them behind.
+type ImportStatementUseCase struct {
+	transactionRepo    transaction.Repository
+	categoryRepo       category.Repository
+	accountRepo        account.Repository
+	reconciliationRepo account.ReconciliationRepository
+	dismissalRepo      transaction.DismissalRepository
+	ruleRepo           categorization.Repository
+	uow                UnitOfWork
+}
+
+func NewImportStatementUseCase(
+	transactionRepo transaction.Repository,
+	categoryRepo category.Repository,
+	accountRepo account.Repository,
+	reconciliationRepo account.ReconciliationRepository,
+	dismissalRepo transaction.DismissalRepository,
+	ruleRepo categorization.Repository,
+	uow UnitOfWork,
+) *ImportStatementUseCase {
+	return &ImportStatementUseCase{
+		transactionRepo:    transactionRepo,
+		categoryRepo:       categoryRepo,
+		accountRepo:        accountRepo,
+		reconciliationRepo: reconciliationRepo,
+		dismissalRepo:      dismissalRepo,
+		ruleRepo:           ruleRepo,
+		uow:                uow,
+	}
+}
+
+// ImportStatementInput books the rows no category rule matches under
+// ExpenseCategory for payments and IncomeCategory for money received, in
+// Currency, on the account AccountID
+// (empty for the default account). SkipDuplicates rejects the rows that look
+// like transactions already recorded instead of importing them flagged.
+type ImportStatementInput struct {
+	CSV             io.Reader
+	Mapping         StatementMapping
+	AccountID       string
+	Currency        string
+	ExpenseCategory string
+	IncomeCategory  string
+	SkipDuplicates  bool
+	Commit          bool
+}
+
+// StatementRow is a statement line read as a transaction booked on Account.
+// Duplicates are the recorded transactions it looks like.
+type StatementRow struct {
+	Line        int
+	Account     account.Account
+	Transaction transaction.Transaction
+	Duplicates  []transaction.Transaction
+}
+
+// ImportStatementOutput lists the rows read, which were saved when
+// Committed, and the rows rejected
+type ImportStatementOutput struct {
+	Account   account.Account
+	Rows      []StatementRow
+	Errors    []ImportRowError
+	Committed bool
+}
+
+func (uc *ImportStatementUseCase) Execute(input ImportStatementInput) (*ImportStatementOutput, error) {
+	// Validate input
+	if input.CSV == nil {
+		return nil, fmt.Errorf("csv file cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	mapping := input.Mapping
+	layout, err := mapping.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	currency, err := shared.NormalizeCurrency(currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, err
+	}
+
+	expenseCategory, err := resolveCategory(uc.categoryRepo, input.ExpenseCategory, shared.CategoryTypeExpense)
+	if err != nil {
+		return nil, err
+	}
+	incomeCategory, err := resolveCategory(uc.categoryRepo, input.IncomeCategory, shared.CategoryTypeIncome)
+	if err != nil {
+		return nil, err
+	}
+
+	accountObj, err := resolveAccount(uc.accountRepo, input.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	reconciledUntil, err := reconciledUntil(uc.reconciliationRepo, accountObj.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := activeCategoryRules(uc.ruleRepo, uc.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(input.CSV)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if mapping.Delimiter != "" {
+		reader.Comma, _ = utf8.DecodeRuneInString(mapping.Delimiter)
+	}
+
+	output := &ImportStatementOutput{
+		Account:   accountObj,
+		Committed: input.Commit,
+	}
+
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			output.Errors = append(output.Errors, ImportRowError{Line: line, Err: err})
+			continue
+		}
+
+		if line == 1 && mapping.SkipHeader {
+			continue
+		}
+
+		entry, err := mapping.parseRecord(record, layout, currency)
+		if err != nil {
+			output.Errors = append(output.Errors, ImportRowError{Line: line, Err: err})
+			continue
+		}
+
+		if reconciledUntil != nil && isReconciledDay(entry.date, *reconciledUntil) {
+			err := fmt.Errorf("%s was reconciled up to %s: %w", accountObj.Name(), reconciledUntil.Format("2006-01-02"), transaction.ErrReconciled)
+			output.Errors = append(output.Errors, ImportRowError{Line: line, Err: err})
+			continue
+		}
+
+		txCategory := expenseCategory.Value()
+		if entry.typ == transaction.TransactionTypeIncome {
+			txCategory = incomeCategory.Value()
+		}
+
+		tx := transaction.NewTransaction(
+			uuid.New().String(),
+			entry.amount,
+			txCategory,
+			entry.description,
+			entry.typ,
+			entry.date,
+		).WithAccountID(accountObj.ID())
+		tx, _ = categorize(rules, tx)
+
+		// Nothing is saved before every row is read, so identical rows of
+		// one statement stay separate payments and only what was recorded
+		// before the import counts
+		duplicates, err := findDuplicatesOf(uc.transactionRepo, uc.dismissalRepo, tx)
+		if err != nil {
+			return nil, err
+		}
+		if len(duplicates) > 0 && input.SkipDuplicates {
+			err := fmt.Errorf("%s %s on %s: %w", tx.Amount(), tx.Description(), tx.CreatedAt().Format("2006-01-02"), transaction.ErrPossibleDuplicate)
+			output.Errors = append(output.Errors, ImportRowError{Line: line, Err: err})
+			continue
+		}
+
+		output.Rows = append(output.Rows, StatementRow{Line: line, Account: accountObj, Transaction: tx, Duplicates: duplicates})
+	}
+
+	if !input.Commit || len(output.Rows) == 0 {
+		return output, nil
+	}
+
+	err = uc.uow.Do(func(repos Repositories) error {
+		for _, row := range output.Rows {
+			if err := repos.Transactions.Save(row.Transaction); err != nil {
+				return fmt.Errorf("failed to save line %d: %w", row.Line, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// reconciledUntil returns the statement date of the account's latest
+// reconciliation, or nil when it was never reconciled
+func reconciledUntil(reconciliationRepo account.ReconciliationRepository, accountID string) (*time.Time, error) {
+	latest, err := reconciliationRepo.FindLatest()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reconciliations: %w", err)
+	}
+
+	for _, rec := range latest {
+		if rec.AccountID() == accountID {
+			date := rec.StatementDate()
+			return &date, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// statementEntry is what a statement line says, before it is booked
+type statementEntry struct {
+	date        time.Time
+	description string
+	amount      shared.Money
+	typ         transaction.TransactionType
+}
+
+// validate checks the mapping and returns the layout of its date format
+func (m StatementMapping) validate() (string, error) {
+	if m.DateColumn < 1 || m.DescriptionColumn < 1 {
+		return "", fmt.Errorf("date and description columns are required: %w", shared.ErrInvalidInput)
+	}
+
+	switch m.SignConvention {
+	case SignNegativeIsExpense, SignPositiveIsExpense:
+		if m.AmountColumn < 1 {
+			return "", fmt.Errorf("amount column is required: %w", shared.ErrInvalidInput)
+		}
+	case SignDebitCredit:
+		if m.DebitColumn < 1 || m.CreditColumn < 1 {
+			return "", fmt.Errorf("debit and credit columns are required: %w", shared.ErrInvalidInput)
+		}
+	default:
+		return "", fmt.Errorf("unknown sign convention %q: %w", m.SignConvention, shared.ErrInvalidInput)
+	}
+
+	if utf8.RuneCountInString(m.Delimiter) > 1 {
+		return "", fmt.Errorf("delimiter must be a single character: %w", shared.ErrInvalidInput)
+	}
+
+	for _, format := range StatementDateFormats {
+		if format.Label == m.DateFormat {
+			return format.Layout, nil
+		}
+	}
+
+	return "", fmt.Errorf("unknown date format %q: %w", m.DateFormat, shared.ErrInvalidInput)
+}
+
+func (m StatementMapping) parseRecord(record []string, layout string, currency string) (statementEntry, error) {
+	column := func(n int) (string, error) {
+		if n > len(record) {
+			return "", fmt.Errorf("expected at least %d columns, got %d: %w", n, len(record), shared.ErrInvalidInput)
+		}
+		return strings.TrimSpace(record[n-1]), nil
+	}
+
+	dateValue, err := column(m.DateColumn)
+	if err != nil {
+		return statementEntry{}, err
+	}
+	date, err := time.Parse(layout, dateValue)
+	if err != nil {
+		return statementEntry{}, fmt.Errorf("invalid date %q, expected %s: %w", dateValue, m.DateFormat, shared.ErrInvalidInput)
+	}
+
+	description, err := column(m.DescriptionColumn)
+	if err != nil {
+		return statementEntry{}, err
+	}
+	if description == "" {
+		return statementEntry{}, fmt.Errorf("description cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	var signed int64
+	if m.SignConvention == SignDebitCredit {
+		debit, err := m.columnAmount(column, m.DebitColumn)
+		if err != nil {
+			return statementEntry{}, err
+		}
+		credit, err := m.columnAmount(column, m.CreditColumn)
+		if err != nil {
+			return statementEntry{}, err
+		}
+		signed = absMinorUnits(credit) - absMinorUnits(debit)
+	} else {
+		signed, err = m.columnAmount(column, m.AmountColumn)
+		if err != nil {
+			return statementEntry{}, err
+		}
+		if m.SignConvention == SignPositiveIsExpense {
+			signed = -signed
+		}
+	}
+
+	if signed == 0 {
+		return statementEntry{}, fmt.Errorf("amount cannot be zero: %w", shared.ErrInvalidInput)
+	}
+
+	typ := transaction.TransactionTypeIncome
+	if signed < 0 {
+		typ = transaction.TransactionTypeExpense
+	}
+
+	amount, err := shared.NewMoney(absMinorUnits(signed), currency)
+	if err != nil {
+		return statementEntry{}, err
+	}
+
+	return statementEntry{
+		date:        date,
+		description: description,
+		amount:      amount,
+		typ:         typ,
+	}, nil
+}
+
+// columnAmount reads a signed amount in minor units from a column. An empty
+// cell reads as zero.
+func (m StatementMapping) columnAmount(column func(int) (string, error), n int) (int64, error) {
+	value, err := column(n)
+	if err != nil {
+		return 0, err
+	}
+	if value == "" {
+		return 0, nil
+	}
+
+	return parseStatementAmount(value, m.DecimalComma)
+}
+
+// parseStatementAmount reads a signed amount in minor units as banks write
+// them. Thousands separators are dropped, and "(12.50)" and "12.50-" count
+// as negative.
+func parseStatementAmount(value string, decimalComma bool) (int64, error) {
+	cleaned := value
+	negative := false
+	if strings.HasPrefix(cleaned, "(") && strings.HasSuffix(cleaned, ")") {
+		negative = true
+		cleaned = cleaned[1 : len(cleaned)-1]
+	}
+	if strings.HasSuffix(cleaned, "-") {
+		negative = true
+		cleaned = strings.TrimSuffix(cleaned, "-")
+	}
+
+	if decimalComma {
+		cleaned = strings.ReplaceAll(cleaned, ".", "")
+	} else {
+		cleaned = strings.ReplaceAll(cleaned, ",", "")
+	}
+
+	minorUnits, err := shared.ParseMinorUnits(cleaned)
+	if err != nil {
+		return 0, fmt.Errorf("invalid amount %q: %w", value, err)
+	}
+
+	if negative {
+		minorUnits = -minorUnits
+	}
+	return minorUnits, nil
+}
+
+func absMinorUnits(n int64) int64 {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
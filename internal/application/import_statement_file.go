@@ -0,0 +1,356 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// StatementFileFormat is a bank statement format that, unlike CSV, says
+// which field is which
+type StatementFileFormat string
+
+const (
+	// StatementFileOFX is Open Financial Exchange, including Quicken's QFX
+	StatementFileOFX StatementFileFormat = "ofx"
+	// StatementFileQIF is the Quicken Interchange Format
+	StatementFileQIF StatementFileFormat = "qif"
+)
+
+// StatementFileFormatOf tells the format of a statement file from its name
+func StatementFileFormatOf(filename string) (StatementFileFormat, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".ofx", ".qfx":
+		return StatementFileOFX, nil
+	case ".qif":
+		return StatementFileQIF, nil
+	default:
+		return "", fmt.Errorf("unknown statement file type %q, expected .ofx, .qfx or .qif: %w", filename, shared.ErrInvalidInput)
+	}
+}
+
+// StatementBooking tells how the entries of a statement file become
+// transactions. Currency is used when the file does not say; DateFormat, a
+// StatementDateFormats label, and DecimalComma only matter for QIF, whose
+// dates and amounts are written as the bank pleases.
+type StatementBooking struct {
+	Currency        string
+	ExpenseCategory shared.Category
+	IncomeCategory  shared.Category
+	DateFormat      string
+	DecimalComma    bool
+}
+
+// StatementFileEntry is a transaction read from a statement file, not yet
+// booked on an account. StatementAccount is the number or name of the bank
+// account it was listed under, empty when the file does not say.
+type StatementFileEntry struct {
+	Line             int
+	StatementAccount string
+	Transaction      transaction.Transaction
+}
+
+// ImportStatementFileUseCase loads the history of accounts from an OFX or
+// QIF bank statement. Each bank account found in the file is imported into
+// the account it was linked to, and the links are remembered for the next
+// import. OFX entries whose FITID was already imported are skipped, so
+// overlapping statements can be imported one after the other. The entries
+// and links are saved together, so a storage failure leaves none of them
+// behind.
+type ImportStatementFileUseCase struct {
+	transactionRepo      transaction.Repository
+	categoryRepo         category.Repository
+	accountRepo          account.Repository
+	reconciliationRepo   account.ReconciliationRepository
+	dismissalRepo        transaction.DismissalRepository
+	statementAccountRepo account.StatementAccountRepository
+	ruleRepo             categorization.Repository
+	uow                  UnitOfWork
+}
+
+func NewImportStatementFileUseCase(
+	transactionRepo transaction.Repository,
+	categoryRepo category.Repository,
+	accountRepo account.Repository,
+	reconciliationRepo account.ReconciliationRepository,
+	dismissalRepo transaction.DismissalRepository,
+	statementAccountRepo account.StatementAccountRepository,
+	ruleRepo categorization.Repository,
+	uow UnitOfWork,
+) *ImportStatementFileUseCase {
+	return &ImportStatementFileUseCase{
+		transactionRepo:      transactionRepo,
+		categoryRepo:         categoryRepo,
+		accountRepo:          accountRepo,
+		reconciliationRepo:   reconciliationRepo,
+		dismissalRepo:        dismissalRepo,
+		statementAccountRepo: statementAccountRepo,
+		ruleRepo:             ruleRepo,
+		uow:                  uow,
+	}
+}
+
+// ImportStatementFileInput books the entries no category rule matches under
+// ExpenseCategory for payments and IncomeCategory for money received. It
+// links statement accounts to accounts through
+// Accounts, by statement account number, over the links remembered from
+// earlier imports. Entries of a statement account linked to nothing, or
+// listed under no account, go to AccountID (empty for the default account).
+type ImportStatementFileInput struct {
+	File            io.Reader
+	Format          StatementFileFormat
+	DateFormat      string
+	DecimalComma    bool
+	Currency        string
+	ExpenseCategory string
+	IncomeCategory  string
+	Accounts        map[string]string
+	AccountID       string
+	SkipDuplicates  bool
+	Commit          bool
+}
+
+// StatementAccountLink is a bank account found in a statement file and the
+// account its entries go to. Number is empty for the entries listed under
+// no account.
+type StatementAccountLink struct {
+	Number  string
+	Account account.Account
+}
+
+// ImportStatementFileOutput lists the rows read, which were saved when
+// Committed, the rows rejected, and how many entries were skipped because
+// they were already imported
+type ImportStatementFileOutput struct {
+	Format          StatementFileFormat
+	Accounts        []StatementAccountLink
+	Rows            []StatementRow
+	AlreadyImported int
+	Errors          []ImportRowError
+	Committed       bool
+}
+
+func (uc *ImportStatementFileUseCase) Execute(input ImportStatementFileInput) (*ImportStatementFileOutput, error) {
+	// Validate input
+	if input.File == nil {
+		return nil, fmt.Errorf("statement file cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	if input.DateFormat != "" && !isStatementDateFormat(input.DateFormat) {
+		return nil, fmt.Errorf("unknown date format %q: %w", input.DateFormat, shared.ErrInvalidInput)
+	}
+
+	currency, err := shared.NormalizeCurrency(currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, err
+	}
+
+	expenseCategory, err := resolveCategory(uc.categoryRepo, input.ExpenseCategory, shared.CategoryTypeExpense)
+	if err != nil {
+		return nil, err
+	}
+	incomeCategory, err := resolveCategory(uc.categoryRepo, input.IncomeCategory, shared.CategoryTypeIncome)
+	if err != nil {
+		return nil, err
+	}
+
+	booking := StatementBooking{
+		Currency:        currency,
+		ExpenseCategory: expenseCategory.Value(),
+		IncomeCategory:  incomeCategory.Value(),
+		DateFormat:      input.DateFormat,
+		DecimalComma:    input.DecimalComma,
+	}
+
+	var (
+		entries   []StatementFileEntry
+		rowErrors []ImportRowError
+	)
+	switch input.Format {
+	case StatementFileOFX:
+		entries, rowErrors, err = ParseOFX(input.File, booking)
+	case StatementFileQIF:
+		entries, rowErrors, err = ParseQIF(input.File, booking)
+	default:
+		return nil, fmt.Errorf("unknown statement file format %q: %w", input.Format, shared.ErrInvalidInput)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	links, err := uc.linkAccounts(entries, input)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := activeCategoryRules(uc.ruleRepo, uc.categoryRepo)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ImportStatementFileOutput{
+		Format:    input.Format,
+		Accounts:  links,
+		Errors:    rowErrors,
+		Committed: input.Commit,
+	}
+
+	accounts := make(map[string]account.Account)
+	reconciled := make(map[string]*time.Time)
+	for _, link := range links {
+		accounts[link.Number] = link.Account
+		if _, ok := reconciled[link.Account.ID()]; ok {
+			continue
+		}
+		until, err := reconciledUntil(uc.reconciliationRepo, link.Account.ID())
+		if err != nil {
+			return nil, err
+		}
+		reconciled[link.Account.ID()] = until
+	}
+
+	// seenFITIDs catches the entries a file lists twice, by account
+	seenFITIDs := make(map[string]bool)
+
+	for _, entry := range entries {
+		accountObj := accounts[entry.StatementAccount]
+		tx, _ := categorize(rules, entry.Transaction.WithAccountID(accountObj.ID()))
+
+		if fitID := tx.FITID(); fitID != "" {
+			key := accountObj.ID() + "\x00" + fitID
+			if seenFITIDs[key] {
+				output.AlreadyImported++
+				continue
+			}
+			seenFITIDs[key] = true
+
+			_, err := uc.transactionRepo.FindByFITID(accountObj.ID(), fitID)
+			if err == nil {
+				output.AlreadyImported++
+				continue
+			}
+			if !errors.Is(err, shared.ErrNotFound) {
+				return nil, fmt.Errorf("failed to find transaction: %w", err)
+			}
+		}
+
+		if until := reconciled[accountObj.ID()]; until != nil && isReconciledDay(tx.CreatedAt(), *until) {
+			err := fmt.Errorf("%s was reconciled up to %s: %w", accountObj.Name(), until.Format("2006-01-02"), transaction.ErrReconciled)
+			output.Errors = append(output.Errors, ImportRowError{Line: entry.Line, Err: err})
+			continue
+		}
+
+		// As with CSV statements, nothing is saved before every entry is
+		// read, so only what was recorded before the import counts
+		duplicates, err := findDuplicatesOf(uc.transactionRepo, uc.dismissalRepo, tx)
+		if err != nil {
+			return nil, err
+		}
+		if len(duplicates) > 0 && input.SkipDuplicates {
+			err := fmt.Errorf("%s %s on %s: %w", tx.Amount(), tx.Description(), tx.CreatedAt().Format("2006-01-02"), transaction.ErrPossibleDuplicate)
+			output.Errors = append(output.Errors, ImportRowError{Line: entry.Line, Err: err})
+			continue
+		}
+
+		output.Rows = append(output.Rows, StatementRow{
+			Line:        entry.Line,
+			Account:     accountObj,
+			Transaction: tx,
+			Duplicates:  duplicates,
+		})
+	}
+
+	sort.SliceStable(output.Errors, func(i, j int) bool {
+		return output.Errors[i].Line < output.Errors[j].Line
+	})
+
+	if !input.Commit {
+		return output, nil
+	}
+
+	err = uc.uow.Do(func(repos Repositories) error {
+		for _, link := range links {
+			if link.Number == "" {
+				continue
+			}
+			statementAccount, err := account.NewStatementAccount(link.Number, link.Account.ID())
+			if err != nil {
+				return err
+			}
+			if err := repos.StatementAccounts.Save(statementAccount); err != nil {
+				return fmt.Errorf("failed to save statement account: %w", err)
+			}
+		}
+
+		for _, row := range output.Rows {
+			if err := repos.Transactions.Save(row.Transaction); err != nil {
+				return fmt.Errorf("failed to save line %d: %w", row.Line, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// linkAccounts finds the account each statement account of the entries goes
+// to, in the order they appear in the file
+func (uc *ImportStatementFileUseCase) linkAccounts(entries []StatementFileEntry, input ImportStatementFileInput) ([]StatementAccountLink, error) {
+	saved, err := uc.statementAccountRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get statement accounts: %w", err)
+	}
+
+	remembered := make(map[string]string)
+	for _, s := range saved {
+		remembered[s.Number()] = s.AccountID()
+	}
+
+	var links []StatementAccountLink
+	linked := make(map[string]bool)
+
+	for _, entry := range entries {
+		number := entry.StatementAccount
+		if linked[number] {
+			continue
+		}
+		linked[number] = true
+
+		accountID := input.Accounts[number]
+		if accountID == "" {
+			accountID = remembered[number]
+		}
+		if accountID == "" {
+			accountID = input.AccountID
+		}
+
+		accountObj, err := resolveAccount(uc.accountRepo, accountID)
+		if err != nil {
+			return nil, err
+		}
+
+		links = append(links, StatementAccountLink{Number: number, Account: accountObj})
+	}
+
+	return links, nil
+}
+
+func isStatementDateFormat(label string) bool {
+	for _, format := range StatementDateFormats {
+		if format.Label == label {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package application
+
+import (
+	"errors"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"testing"
+)
+
+func TestParseStatementAmount(t *testing.T) {
+	tests := []struct {
+		value        string
+		decimalComma bool
+		want         int64
+	}{
+		{"12.50", false, 1250},
+		{"-12.50", false, -1250},
+		{"+12.50", false, 1250},
+		{"1,234.56", false, 123456},
+		{"(1,234.56)", false, -123456},
+		{"12.50-", false, -1250},
+		{"12", false, 1200},
+		{".5", false, 50},
+		{"1.234,56", true, 123456},
+		{"-1.234,56", true, -123456},
+		{"1 234,56", true, 123456},
+		{"(12,50)", true, -1250},
+		{"12,50-", true, -1250},
+	}
+
+	for _, tt := range tests {
+		got, err := parseStatementAmount(tt.value, tt.decimalComma)
+		if err != nil {
+			t.Errorf("parseStatementAmount(%q, %v): %v", tt.value, tt.decimalComma, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("parseStatementAmount(%q, %v) = %d, want %d", tt.value, tt.decimalComma, got, tt.want)
+		}
+	}
+
+	for _, value := range []string{"", "abc", "12.50 MAD", "1.2.3", "--12"} {
+		if _, err := parseStatementAmount(value, false); !errors.Is(err, shared.ErrInvalidAmount) {
+			t.Errorf("parseStatementAmount(%q) = %v, want ErrInvalidAmount", value, err)
+		}
+	}
+}
+
+func TestStatementMappingValidate(t *testing.T) {
+	valid := StatementMapping{
+		DateColumn:        1,
+		DescriptionColumn: 2,
+		AmountColumn:      3,
+		SignConvention:    SignNegativeIsExpense,
+		DateFormat:        "DD/MM/YYYY",
+	}
+
+	tests := []struct {
+		name    string
+		edit    func(m *StatementMapping)
+		wantErr bool
+	}{
+		{"valid", func(m *StatementMapping) {}, false},
+		{"positive is expense", func(m *StatementMapping) { m.SignConvention = SignPositiveIsExpense }, false},
+		{"debit and credit", func(m *StatementMapping) {
+			m.SignConvention, m.AmountColumn, m.DebitColumn, m.CreditColumn = SignDebitCredit, 0, 3, 4
+		}, false},
+		{"semicolon delimiter", func(m *StatementMapping) { m.Delimiter = ";" }, false},
+		{"no date column", func(m *StatementMapping) { m.DateColumn = 0 }, true},
+		{"no description column", func(m *StatementMapping) { m.DescriptionColumn = 0 }, true},
+		{"no amount column", func(m *StatementMapping) { m.AmountColumn = 0 }, true},
+		{"debit and credit without a credit column", func(m *StatementMapping) {
+			m.SignConvention, m.DebitColumn = SignDebitCredit, 3
+		}, true},
+		{"unknown sign convention", func(m *StatementMapping) { m.SignConvention = "sometimes" }, true},
+		{"long delimiter", func(m *StatementMapping) { m.Delimiter = ";;" }, true},
+		{"unknown date format", func(m *StatementMapping) { m.DateFormat = "YY/MM/DD" }, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapping := valid
+			tt.edit(&mapping)
+
+			_, err := mapping.validate()
+			if tt.wantErr && !errors.Is(err, shared.ErrInvalidInput) {
+				t.Errorf("got %v, want ErrInvalidInput", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestStatementMappingParseRecord(t *testing.T) {
+	amountMapping := StatementMapping{
+		DateColumn:        1,
+		DescriptionColumn: 2,
+		AmountColumn:      3,
+		SignConvention:    SignNegativeIsExpense,
+		DateFormat:        "DD/MM/YYYY",
+	}
+	creditCardMapping := amountMapping
+	creditCardMapping.SignConvention = SignPositiveIsExpense
+	europeanMapping := amountMapping
+	europeanMapping.DateFormat = "DD.MM.YYYY"
+	europeanMapping.DecimalComma = true
+	debitCreditMapping := StatementMapping{
+		DateColumn:        1,
+		DescriptionColumn: 2,
+		DebitColumn:       3,
+		CreditColumn:      4,
+		SignConvention:    SignDebitCredit,
+		DateFormat:        "YYYY-MM-DD",
+	}
+
+	tests := []struct {
+		name    string
+		mapping StatementMapping
+		record  []string
+		want    *wantEntry
+	}{
+		{
+			name:    "negative amount is an expense",
+			mapping: amountMapping,
+			record:  []string{"02/10/2026", "Supermarket", "-86.40"},
+			want:    &wantEntry{date: "2026-10-02", description: "Supermarket", typ: transaction.TransactionTypeExpense, minorUnits: 8640, currency: "MAD"},
+		},
+		{
+			name:    "positive amount is income",
+			mapping: amountMapping,
+			record:  []string{"05/10/2026", " Acme Corp ", "2,500.00"},
+			want:    &wantEntry{date: "2026-10-05", description: "Acme Corp", typ: transaction.TransactionTypeIncome, minorUnits: 250000, currency: "MAD"},
+		},
+		{
+			name:    "credit card reads positive as an expense",
+			mapping: creditCardMapping,
+			record:  []string{"08/10/2026", "Streaming subscription", "19.99"},
+			want:    &wantEntry{date: "2026-10-08", description: "Streaming subscription", typ: transaction.TransactionTypeExpense, minorUnits: 1999, currency: "MAD"},
+		},
+		{
+			name:    "credit card reads negative as a refund",
+			mapping: creditCardMapping,
+			record:  []string{"09/10/2026", "Refund", "-19.99"},
+			want:    &wantEntry{date: "2026-10-09", description: "Refund", typ: transaction.TransactionTypeIncome, minorUnits: 1999, currency: "MAD"},
+		},
+		{
+			name:    "decimal comma",
+			mapping: europeanMapping,
+			record:  []string{"02.10.2026", "Loyer", "-1.234,56"},
+			want:    &wantEntry{date: "2026-10-02", description: "Loyer", typ: transaction.TransactionTypeExpense, minorUnits: 123456, currency: "MAD"},
+		},
+		{
+			name:    "debit column",
+			mapping: debitCreditMapping,
+			record:  []string{"2026-10-02", "Landlord", "1,234.56", ""},
+			want:    &wantEntry{date: "2026-10-02", description: "Landlord", typ: transaction.TransactionTypeExpense, minorUnits: 123456, currency: "MAD"},
+		},
+		{
+			name:    "credit column",
+			mapping: debitCreditMapping,
+			record:  []string{"2026-10-05", "Acme Corp", "", "2500"},
+			want:    &wantEntry{date: "2026-10-05", description: "Acme Corp", typ: transaction.TransactionTypeIncome, minorUnits: 250000, currency: "MAD"},
+		},
+		{
+			name:    "signed debit column",
+			mapping: debitCreditMapping,
+			record:  []string{"2026-10-07", "Supermarket", "-86.40", ""},
+			want:    &wantEntry{date: "2026-10-07", description: "Supermarket", typ: transaction.TransactionTypeExpense, minorUnits: 8640, currency: "MAD"},
+		},
+		{name: "missing columns", mapping: amountMapping, record: []string{"02/10/2026", "Supermarket"}},
+		{name: "date in another format", mapping: amountMapping, record: []string{"2026-10-02", "Supermarket", "-86.40"}},
+		{name: "impossible date", mapping: amountMapping, record: []string{"31/02/2026", "Supermarket", "-86.40"}},
+		{name: "empty description", mapping: amountMapping, record: []string{"02/10/2026", " ", "-86.40"}},
+		{name: "malformed amount", mapping: amountMapping, record: []string{"02/10/2026", "Supermarket", "86,40 MAD"}},
+		{name: "empty amount", mapping: amountMapping, record: []string{"02/10/2026", "Supermarket", ""}},
+		{name: "zero amount", mapping: amountMapping, record: []string{"02/10/2026", "Supermarket", "0.00"}},
+		{name: "empty debit and credit", mapping: debitCreditMapping, record: []string{"2026-10-02", "Landlord", "", ""}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, err := tt.mapping.validate()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			entry, err := tt.mapping.parseRecord(tt.record, layout, "MAD")
+			if tt.want == nil {
+				if err == nil {
+					t.Errorf("expected an error, got %s %s", entry.typ, entry.amount)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := entry.date.Format("2006-01-02"); got != tt.want.date {
+				t.Errorf("date = %s, want %s", got, tt.want.date)
+			}
+			if entry.description != tt.want.description {
+				t.Errorf("description = %q, want %q", entry.description, tt.want.description)
+			}
+			if entry.typ != tt.want.typ {
+				t.Errorf("type = %s, want %s", entry.typ, tt.want.typ)
+			}
+			if entry.amount.MinorUnits() != tt.want.minorUnits || entry.amount.Currency() != tt.want.currency {
+				t.Errorf("amount = %d %s, want %d %s", entry.amount.MinorUnits(), entry.amount.Currency(), tt.want.minorUnits, tt.want.currency)
+			}
+		})
+	}
+}
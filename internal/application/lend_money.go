@@ -0,0 +1,91 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LendMoneyUseCase records money we lent to someone, to be collected later
+type LendMoneyUseCase struct {
+	uow UnitOfWork
+}
+
+func NewLendMoneyUseCase(uow UnitOfWork) *LendMoneyUseCase {
+	return &LendMoneyUseCase{
+		uow: uow,
+	}
+}
+
+type LendMoneyInput struct {
+	BorrowerName string
+	Amount       string
+	Currency     string
+	Description  string
+	Date         time.Time
+	// DueDate is when the first, or only, repayment is due (optional)
+	DueDate *time.Time
+	// Installments splits the repayment into that many monthly installments
+	Installments int
+}
+
+type LendMoneyOutput struct {
+	Loan        loan.Loan
+	Transaction transaction.Transaction
+}
+
+func (uc *LendMoneyUseCase) Execute(input LendMoneyInput) (*LendMoneyOutput, error) {
+	// Validate input
+	if loan.NormalizeName(input.BorrowerName) == "" {
+		return nil, fmt.Errorf("borrower name cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	if input.Description == "" {
+		return nil, fmt.Errorf("description cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	money, err := shared.ParseMoney(input.Amount, currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("invalid loan amount: %w", err)
+	}
+
+	var (
+		loanObj loan.Loan
+		tx      transaction.Transaction
+	)
+
+	err = uc.uow.Do(func(repos Repositories) error {
+		name, err := personName(repos, input.BorrowerName)
+		if err != nil {
+			return err
+		}
+
+		loanObj = loan.NewReceivable(
+			uuid.New().String(),
+			name,
+			money,
+			input.Date,
+			input.Description,
+		)
+
+		var schedule []loan.Installment
+		loanObj, schedule, err = planSchedule(loanObj, input.DueDate, input.Installments)
+		if err != nil {
+			return err
+		}
+
+		tx, err = openLoan(repos, loanObj, schedule)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &LendMoneyOutput{
+		Loan:        loanObj,
+		Transaction: tx,
+	}, nil
+}
@@ -0,0 +1,204 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// A loan is mirrored by transactions: the principal (income when we borrow,
+// expense when we lend) and one repayment per payment, the other way round.
+
+// isPrincipal reports whether tx opened the loan, as opposed to repaying it
+func isPrincipal(l loan.Loan, tx transaction.Transaction) bool {
+	return tx.IsIncome() != l.IsReceivable()
+}
+
+func principalTransaction(l loan.Loan, id string) transaction.Transaction {
+	category := shared.CategoryBorrowed
+	description := fmt.Sprintf("Borrowed from %s: %s", l.LenderName(), l.Description())
+	typ := transaction.TransactionTypeIncome
+
+	if l.IsReceivable() {
+		category = shared.CategoryLent
+		description = fmt.Sprintf("Lent to %s: %s", l.LenderName(), l.Description())
+		typ = transaction.TransactionTypeExpense
+	}
+
+	return transaction.NewTransaction(id, l.Amount(), category, description, typ, l.BorrowedAt()).WithLoanID(l.ID())
+}
+
+func repaymentCategory(l loan.Loan) shared.Category {
+	name := fmt.Sprintf("Loan Payment - %s", l.LenderName())
+	typ := shared.CategoryTypeExpense
+
+	if l.IsReceivable() {
+		name = fmt.Sprintf("Loan Repayment - %s", l.LenderName())
+		typ = shared.CategoryTypeIncome
+	}
+
+	category, _ := shared.NewCategory(name, typ)
+	return category
+}
+
+func repaymentDescription(l loan.Loan, amount shared.Money) string {
+	if l.IsReceivable() {
+		return fmt.Sprintf("Collected %s from %s", amount.String(), l.LenderName())
+	}
+	return fmt.Sprintf("Paid %s to %s", amount.String(), l.LenderName())
+}
+
+func repaymentTransaction(l loan.Loan, id string, amount shared.Money, paidAt time.Time) transaction.Transaction {
+	typ := transaction.TransactionTypeExpense
+	if l.IsReceivable() {
+		typ = transaction.TransactionTypeIncome
+	}
+
+	return transaction.NewTransaction(
+		id,
+		amount,
+		repaymentCategory(l),
+		repaymentDescription(l, amount),
+		typ,
+		paidAt,
+	).WithLoanID(l.ID())
+}
+
+// personName normalizes a name and reuses the spelling already on record for
+// the same person, so that "hamza " joins the loans of "Hamza"
+func personName(repos Repositories, name string) (string, error) {
+	normalized := loan.NormalizeName(name)
+
+	loans, err := repos.Loans.FindAll()
+	if err != nil {
+		return "", fmt.Errorf("failed to get loans: %w", err)
+	}
+
+	if matches := loan.FilterByLender(loans, normalized); len(matches) > 0 {
+		return matches[0].LenderName(), nil
+	}
+
+	return normalized, nil
+}
+
+// planSchedule splits a new loan into count monthly installments starting on
+// firstDue, and makes the last one the due date of the loan. A loan with
+// neither a due date nor several installments gets no schedule.
+func planSchedule(l loan.Loan, firstDue *time.Time, count int) (loan.Loan, []loan.Installment, error) {
+	if count < 0 {
+		return loan.Loan{}, nil, fmt.Errorf("installments cannot be negative: %w", shared.ErrInvalidInput)
+	}
+	if firstDue == nil && count <= 1 {
+		return l, nil, nil
+	}
+	if count == 0 {
+		count = 1
+	}
+
+	start := loan.AddMonths(l.BorrowedAt(), 1)
+	if firstDue != nil {
+		start = *firstDue
+	}
+	borrowedAt := l.BorrowedAt()
+	if start.Before(time.Date(borrowedAt.Year(), borrowedAt.Month(), borrowedAt.Day(), 0, 0, 0, 0, start.Location())) {
+		return loan.Loan{}, nil, fmt.Errorf("due date cannot be before the loan date: %w", shared.ErrInvalidInput)
+	}
+
+	schedule, err := loan.GenerateSchedule(l, count, start, func() string { return uuid.New().String() })
+	if err != nil {
+		return loan.Loan{}, nil, fmt.Errorf("cannot split %s into %d installments: %w", l.Amount(), count, err)
+	}
+
+	dueDate := schedule[len(schedule)-1].DueDate()
+	return l.WithDueDate(&dueDate), schedule, nil
+}
+
+// openLoan saves a new loan with its principal transaction and schedule
+func openLoan(repos Repositories, l loan.Loan, schedule []loan.Installment) (transaction.Transaction, error) {
+	tx := principalTransaction(l, uuid.New().String())
+
+	if err := ensureOpenPeriod(repos.Reconciliations, tx); err != nil {
+		return transaction.Transaction{}, err
+	}
+
+	if err := repos.Loans.Save(l); err != nil {
+		return transaction.Transaction{}, fmt.Errorf("failed to save loan: %w", err)
+	}
+
+	if err := repos.Transactions.Save(tx); err != nil {
+		return transaction.Transaction{}, fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	for _, installment := range schedule {
+		if err := repos.LoanInstallments.Save(installment); err != nil {
+			return transaction.Transaction{}, fmt.Errorf("failed to save installment: %w", err)
+		}
+	}
+
+	return tx, nil
+}
+
+// reschedule spreads a corrected loan amount over the installments it
+// already had, keeping their number and due dates
+func reschedule(repos Repositories, l loan.Loan) error {
+	installments, err := repos.LoanInstallments.FindByLoanID(l.ID())
+	if err != nil {
+		return fmt.Errorf("failed to get installments: %w", err)
+	}
+	if len(installments) == 0 {
+		return nil
+	}
+
+	schedule, err := loan.GenerateSchedule(l, len(installments), installments[0].DueDate(), func() string { return uuid.New().String() })
+	if err != nil {
+		return fmt.Errorf("cannot split %s into %d installments: %w", l.Amount(), len(installments), err)
+	}
+
+	if err := repos.LoanInstallments.DeleteByLoanID(l.ID()); err != nil {
+		return err
+	}
+	for _, installment := range schedule {
+		if err := repos.LoanInstallments.Save(installment); err != nil {
+			return fmt.Errorf("failed to save installment: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// recordRepayment applies a payment to a loan and saves it with its
+// repayment transaction
+func recordRepayment(
+	repos Repositories,
+	loanObj loan.Loan,
+	amount shared.Money,
+	paidAt time.Time,
+) (loan.Loan, transaction.Transaction, error) {
+	updatedLoan, err := loanObj.RecordPayment(amount, paidAt)
+	if err != nil {
+		return loan.Loan{}, transaction.Transaction{}, fmt.Errorf("failed to record payment: %w", err)
+	}
+
+	if err := repos.Loans.Update(updatedLoan); err != nil {
+		return loan.Loan{}, transaction.Transaction{}, fmt.Errorf("failed to update loan: %w", err)
+	}
+
+	tx := repaymentTransaction(updatedLoan, uuid.New().String(), amount, paidAt)
+	if err := ensureOpenPeriod(repos.Reconciliations, tx); err != nil {
+		return loan.Loan{}, transaction.Transaction{}, err
+	}
+	if err := repos.Transactions.Save(tx); err != nil {
+		return loan.Loan{}, transaction.Transaction{}, fmt.Errorf("failed to save transaction: %w", err)
+	}
+
+	payment := loan.NewPayment(uuid.New().String(), updatedLoan.ID(), tx.ID(), amount, paidAt)
+	if err := repos.LoanPayments.Save(payment); err != nil {
+		return loan.Loan{}, transaction.Transaction{}, fmt.Errorf("failed to save loan payment: %w", err)
+	}
+
+	return updatedLoan, tx, nil
+}
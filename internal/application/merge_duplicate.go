@@ -0,0 +1,78 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// MergeDuplicateUseCase resolves a transaction entered twice by keeping one
+// entry and deleting the other. The deleted entry must be a plain income or
+// expense that is not locked by a reconciliation.
+type MergeDuplicateUseCase struct {
+	uow UnitOfWork
+}
+
+func NewMergeDuplicateUseCase(uow UnitOfWork) *MergeDuplicateUseCase {
+	return &MergeDuplicateUseCase{
+		uow: uow,
+	}
+}
+
+type MergeDuplicateInput struct {
+	KeepID   string
+	RemoveID string
+}
+
+type MergeDuplicateOutput struct {
+	Kept    transaction.Transaction
+	Removed transaction.Transaction
+}
+
+func (uc *MergeDuplicateUseCase) Execute(input MergeDuplicateInput) (*MergeDuplicateOutput, error) {
+	// Validate input
+	if input.KeepID == "" || input.RemoveID == "" {
+		return nil, fmt.Errorf("both transactions are required: %w", shared.ErrInvalidInput)
+	}
+
+	output := &MergeDuplicateOutput{}
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		kept, err := repos.Transactions.FindByID(input.KeepID)
+		if err != nil {
+			return fmt.Errorf("failed to find transaction: %w", err)
+		}
+		removed, err := repos.Transactions.FindByID(input.RemoveID)
+		if err != nil {
+			return fmt.Errorf("failed to find transaction: %w", err)
+		}
+
+		if !transaction.IsPossibleDuplicate(kept, removed) {
+			return fmt.Errorf("the two transactions do not look alike: %w", shared.ErrInvalidInput)
+		}
+		if removed.IsFixedCharge() {
+			return fmt.Errorf("the fixed charge deduction must be kept, merge the other way round: %w", shared.ErrInvalidInput)
+		}
+		if err := ensureUnlocked(removed); err != nil {
+			return err
+		}
+
+		// Like a deleted recurring transaction, a merged one stays applied
+		// so the scheduler does not generate it again
+		if err := repos.RecurringOccurrences.UnlinkTransaction(removed.ID()); err != nil {
+			return err
+		}
+		if err := deleteTransaction(repos, removed.ID()); err != nil {
+			return fmt.Errorf("failed to delete duplicate: %w", err)
+		}
+
+		output.Kept = kept
+		output.Removed = removed
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
@@ -11,31 +11,31 @@ import (
 )
 
 type PayLoanUseCase struct {
-	loanRepo        loan.Repository
-	transactionRepo transaction.Repository
+	uow UnitOfWork
 }
 
-func NewPayLoanUseCase(
-	loanRepo loan.Repository,
-	transactionRepo transaction.Repository,
-) *PayLoanUseCase {
+func NewPayLoanUseCase(uow UnitOfWork) *PayLoanUseCase {
 	return &PayLoanUseCase{
-		loanRepo:        loanRepo,
-		transactionRepo: transactionRepo,
+		uow: uow,
 	}
 }
 
 type PayLoanInput struct {
-	LoanID  string
-	Amount  float64
-	Date    time.Time
+	LoanID string
+	Amount string
+	Date   time.Time
+	// RefundSurplus settles the loan with an overpayment and records the
+	// surplus as money the lender owes back, instead of rejecting it
+	RefundSurplus bool
 }
 
 type PayLoanOutput struct {
-	UpdatedLoan       loan.Loan
-	Transaction       transaction.Transaction
-	RemainingAmount   shared.Money
-	FullyPaid         bool
+	UpdatedLoan     loan.Loan
+	Transaction     transaction.Transaction
+	RemainingAmount shared.Money
+	FullyPaid       bool
+	// Refund is the receivable opened for the surplus of an overpayment
+	Refund *loan.Loan
 }
 
 func (uc *PayLoanUseCase) Execute(input PayLoanInput) (*PayLoanOutput, error) {
@@ -44,38 +44,63 @@ func (uc *PayLoanUseCase) Execute(input PayLoanInput) (*PayLoanOutput, error) {
 		return nil, fmt.Errorf("loan ID cannot be empty: %w", shared.ErrInvalidInput)
 	}
 
-	payment, err := shared.NewMoney(input.Amount)
-	if err != nil {
-		return nil, fmt.Errorf("invalid payment amount: %w", err)
-	}
+	var (
+		updatedLoan loan.Loan
+		tx          transaction.Transaction
+		refund      *loan.Loan
+	)
 
-	loanObj, err := uc.loanRepo.FindByID(input.LoanID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to find loan: %w", err)
-	}
+	// The loan balance and the payment transaction are written together
+	err := uc.uow.Do(func(repos Repositories) error {
+		loanObj, err := repos.Loans.FindByID(input.LoanID)
+		if err != nil {
+			return fmt.Errorf("failed to find loan: %w", err)
+		}
 
-	updatedLoan := loanObj.RecordPayment(payment, input.Date)
+		if loanObj.IsReceivable() {
+			return fmt.Errorf("money lent to %s is collected, not paid: %w", loanObj.LenderName(), shared.ErrInvalidInput)
+		}
+		if err := checkRepayable(loanObj); err != nil {
+			return err
+		}
 
-	if err := uc.loanRepo.Update(updatedLoan); err != nil {
-		return nil, fmt.Errorf("failed to update loan: %w", err)
-	}
+		// Repayments are always made in the currency the money was borrowed in
+		payment, err := shared.ParseMoney(input.Amount, loanObj.Amount().Currency())
+		if err != nil {
+			return fmt.Errorf("invalid payment amount: %w", err)
+		}
 
-	category, _ := shared.NewCategory(
-		fmt.Sprintf("Loan Payment - %s", updatedLoan.LenderName()),
-		shared.CategoryTypeExpense,
-	)
+		settled, surplus, err := loanObj.SplitPayment(payment)
+		if err != nil {
+			return err
+		}
+		if surplus.IsPositive() && input.RefundSurplus {
+			payment = settled
+		}
 
-	tx := transaction.NewTransaction(
-		uuid.New().String(),
-		payment,
-		category,
-		fmt.Sprintf("Paid %s to %s", payment.String(), updatedLoan.LenderName()),
-		transaction.TransactionTypeExpense,
-		input.Date,
-	)
+		updatedLoan, tx, err = recordRepayment(repos, loanObj, payment, input.Date)
+		if err != nil {
+			return err
+		}
+
+		if surplus.IsPositive() && input.RefundSurplus {
+			receivable := loan.NewReceivable(
+				uuid.New().String(),
+				updatedLoan.LenderName(),
+				surplus,
+				input.Date,
+				"Refund of an overpaid loan",
+			)
+			if _, err := openLoan(repos, receivable, nil); err != nil {
+				return fmt.Errorf("failed to record refund: %w", err)
+			}
+			refund = &receivable
+		}
 
-	if err := uc.transactionRepo.Save(tx); err != nil {
-		return nil, fmt.Errorf("failed to save transaction: %w", err)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return &PayLoanOutput{
@@ -83,5 +108,17 @@ func (uc *PayLoanUseCase) Execute(input PayLoanInput) (*PayLoanOutput, error) {
 		Transaction:     tx,
 		RemainingAmount: updatedLoan.RemainingAmount(),
 		FullyPaid:       updatedLoan.IsFullyPaid(),
+		Refund:          refund,
 	}, nil
 }
+
+// checkRepayable explains why a loan cannot take further payments, if so
+func checkRepayable(l loan.Loan) error {
+	switch {
+	case l.IsCancelled():
+		return fmt.Errorf("loan with %s was cancelled: %w", l.LenderName(), loan.ErrLoanNotActive)
+	case l.IsFullyPaid():
+		return fmt.Errorf("loan with %s was already paid back: %w", l.LenderName(), loan.ErrLoanNotActive)
+	}
+	return nil
+}
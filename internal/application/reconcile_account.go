@@ -0,0 +1,232 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReconcileAccountUseCase compares an account with the balance the bank
+// reports on a date. When they agree, or once an adjustment transaction
+// makes up the difference, the transactions up to that date are locked.
+type ReconcileAccountUseCase struct {
+	uow       UnitOfWork
+	converter CurrencyConverter
+}
+
+func NewReconcileAccountUseCase(uow UnitOfWork, converter CurrencyConverter) *ReconcileAccountUseCase {
+	return &ReconcileAccountUseCase{
+		uow:       uow,
+		converter: converter,
+	}
+}
+
+type ReconcileAccountInput struct {
+	AccountID string
+	// Date is the day of the statement; transactions made that day count
+	Date     time.Time
+	Balance  string
+	Currency string
+	// PostAdjustment records the difference as an income or expense so the
+	// account matches the bank
+	PostAdjustment bool
+}
+
+type ReconcileAccountOutput struct {
+	Account          account.Account
+	StatementBalance shared.Money
+	ComputedBalance  shared.Money
+	Difference       shared.Money
+	Adjustment       *transaction.Transaction
+	// Reconciled is false when a difference remains; nothing is locked then
+	Reconciled  bool
+	LockedCount int
+}
+
+func (uc *ReconcileAccountUseCase) Execute(input ReconcileAccountInput) (*ReconcileAccountOutput, error) {
+	// Validate input
+	if input.Date.IsZero() {
+		return nil, fmt.Errorf("statement date cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	currency, err := shared.NormalizeCurrency(currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, err
+	}
+
+	// The bank balance may be zero or overdrawn
+	minorUnits, err := shared.ParseMinorUnits(input.Balance)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance: %w", err)
+	}
+	statementBalance := shared.UnsafeNewMoney(minorUnits, currency)
+
+	until := endOfDay(input.Date)
+
+	output := &ReconcileAccountOutput{
+		StatementBalance: statementBalance,
+	}
+
+	err = uc.uow.Do(func(repos Repositories) error {
+		accountObj, err := resolveAccount(repos.Accounts, input.AccountID)
+		if err != nil {
+			return err
+		}
+		output.Account = accountObj
+
+		transactions, err := repos.Transactions.FindByAccountID(accountObj.ID(), until)
+		if err != nil {
+			return fmt.Errorf("failed to get account transactions: %w", err)
+		}
+
+		converted := make([]transaction.Transaction, 0, len(transactions))
+		for _, tx := range transactions {
+			amount, err := uc.converter.Convert(tx.Amount(), currency, tx.CreatedAt())
+			if err != nil {
+				return fmt.Errorf("failed to convert transaction %s: %w", tx.ID(), err)
+			}
+			converted = append(converted, tx.WithAmount(amount))
+		}
+
+		balance, err := transaction.CalculateBalance(converted)
+		if err != nil {
+			return fmt.Errorf("failed to compute balance: %w", err)
+		}
+		if output.ComputedBalance, err = shared.ZeroOf(currency).Add(balance); err != nil {
+			return err
+		}
+		if output.Difference, err = statementBalance.Subtract(output.ComputedBalance); err != nil {
+			return err
+		}
+
+		reconciliationID := uuid.New().String()
+		adjustmentID := ""
+
+		if !output.Difference.IsZero() {
+			if !input.PostAdjustment {
+				return nil
+			}
+
+			adjustment, err := adjustmentTransaction(output.Difference, until)
+			if err != nil {
+				return err
+			}
+			adjustment = adjustment.WithAccountID(accountObj.ID())
+
+			if err := repos.Transactions.Save(adjustment); err != nil {
+				return fmt.Errorf("failed to save adjustment: %w", err)
+			}
+			output.Adjustment = &adjustment
+			adjustmentID = adjustment.ID()
+		}
+
+		reconciliation := account.NewReconciliation(
+			reconciliationID,
+			accountObj.ID(),
+			until,
+			statementBalance,
+			output.ComputedBalance,
+			adjustmentID,
+			time.Now(),
+		)
+		if err := repos.Reconciliations.Save(reconciliation); err != nil {
+			return err
+		}
+
+		if output.LockedCount, err = repos.Transactions.MarkReconciled(accountObj.ID(), until, reconciliationID); err != nil {
+			return err
+		}
+		output.Reconciled = true
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// adjustmentTransaction records a difference with the bank: money the bank
+// has on top is an income, missing money an expense
+func adjustmentTransaction(difference shared.Money, date time.Time) (transaction.Transaction, error) {
+	category := shared.CategoryAdjustmentIn
+	typ := transaction.TransactionTypeIncome
+	minorUnits := difference.MinorUnits()
+
+	if difference.IsNegative() {
+		category = shared.CategoryAdjustmentOut
+		typ = transaction.TransactionTypeExpense
+		minorUnits = -minorUnits
+	}
+
+	amount, err := shared.NewMoney(minorUnits, difference.Currency())
+	if err != nil {
+		return transaction.Transaction{}, fmt.Errorf("invalid adjustment: %w", err)
+	}
+
+	return transaction.NewTransaction(
+		uuid.New().String(),
+		amount,
+		category,
+		"Reconciliation with the bank statement",
+		typ,
+		date,
+	), nil
+}
+
+// ensureUnlocked refuses changes to a transaction locked by a reconciliation
+func ensureUnlocked(tx transaction.Transaction) error {
+	if tx.IsReconciled() {
+		return fmt.Errorf(
+			"%s of %s on %s: %w",
+			tx.Category().Name(), tx.Amount(), tx.CreatedAt().Format("Jan 02, 2006"), transaction.ErrReconciled,
+		)
+	}
+	return nil
+}
+
+// ensureOpenPeriod refuses to book a transaction on or before the day its
+// account was last reconciled up to, which would change a balance the bank
+// already confirmed
+func ensureOpenPeriod(reconciliationRepo account.ReconciliationRepository, tx transaction.Transaction) error {
+	accountID := tx.AccountID()
+	if accountID == "" {
+		accountID = account.DefaultAccountID
+	}
+
+	until, err := reconciledUntil(reconciliationRepo, accountID)
+	if err != nil {
+		return err
+	}
+
+	if until != nil && isReconciledDay(tx.CreatedAt(), *until) {
+		return fmt.Errorf(
+			"%s of %s on %s: the account was reconciled up to %s, book it after that date: %w",
+			tx.Category().Name(), tx.Amount(), tx.CreatedAt().Format("Jan 02, 2006"), until.Format("Jan 02, 2006"), shared.ErrInvalidInput,
+		)
+	}
+	return nil
+}
+
+// isReconciledDay reports whether a transaction dated at falls on or before
+// the statement date of a reconciliation. Both are compared as calendar days
+// in the location they were entered in, so a transaction recorded just after
+// local midnight belongs to the next day wherever the server runs.
+func isReconciledDay(at time.Time, statementDate time.Time) bool {
+	year, month, day := at.Date()
+	statementYear, statementMonth, statementDay := statementDate.Date()
+	return !time.Date(year, month, day, 0, 0, 0, 0, time.UTC).
+		After(time.Date(statementYear, statementMonth, statementDay, 0, 0, 0, 0, time.UTC))
+}
+
+// endOfDay is the last second of the day of date, which a reconciliation is
+// stored with
+func endOfDay(date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC).
+		AddDate(0, 0, 1).Add(-time.Second)
+}
@@ -0,0 +1,34 @@
+package application
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIsReconciledDay(t *testing.T) {
+	casablanca := time.FixedZone("Africa/Casablanca", 1*60*60)
+	newYork := time.FixedZone("America/New_York", -4*60*60)
+	statementDate := endOfDay(time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name string
+		at   time.Time
+		want bool
+	}{
+		{"earlier day", time.Date(2026, 10, 9, 12, 0, 0, 0, time.UTC), true},
+		{"statement day, imported", time.Date(2026, 10, 10, 0, 0, 0, 0, time.UTC), true},
+		{"statement day, late local evening ahead of UTC", time.Date(2026, 10, 10, 23, 30, 0, 0, casablanca), true},
+		{"statement day, late local evening behind UTC", time.Date(2026, 10, 10, 22, 0, 0, 0, newYork), true},
+		{"next day, just after local midnight ahead of UTC", time.Date(2026, 10, 11, 0, 30, 0, 0, casablanca), false},
+		{"next day, just after local midnight behind UTC", time.Date(2026, 10, 11, 0, 30, 0, 0, newYork), false},
+		{"next day, imported", time.Date(2026, 10, 11, 0, 0, 0, 0, time.UTC), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isReconciledDay(tt.at, statementDate); got != tt.want {
+				t.Errorf("isReconciledDay(%s) = %v, want %v", tt.at, got, tt.want)
+			}
+		})
+	}
+}
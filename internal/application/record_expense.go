@@ -2,7 +2,10 @@ package application
 
 import (
 	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
 	"github.com/aymaneelmaini/moka/internal/domain/budget"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
 	"github.com/aymaneelmaini/moka/internal/domain/transaction"
 	"github.com/aymaneelmaini/moka/internal/shared"
 	"time"
@@ -11,25 +14,49 @@ import (
 )
 
 type RecordExpenseUseCase struct {
-	transactionRepo transaction.Repository
-	budgetRepo      budget.Repository
+	transactionRepo    transaction.Repository
+	budgetRepo         budget.Repository
+	categoryRepo       category.Repository
+	accountRepo        account.Repository
+	reconciliationRepo account.ReconciliationRepository
+	dismissalRepo      transaction.DismissalRepository
+	ruleRepo           categorization.Repository
+	converter          CurrencyConverter
 }
 
 func NewRecordExpenseUseCase(
 	transactionRepo transaction.Repository,
 	budgetRepo budget.Repository,
+	categoryRepo category.Repository,
+	accountRepo account.Repository,
+	reconciliationRepo account.ReconciliationRepository,
+	dismissalRepo transaction.DismissalRepository,
+	ruleRepo categorization.Repository,
+	converter CurrencyConverter,
 ) *RecordExpenseUseCase {
 	return &RecordExpenseUseCase{
-		transactionRepo: transactionRepo,
-		budgetRepo:      budgetRepo,
+		transactionRepo:    transactionRepo,
+		budgetRepo:         budgetRepo,
+		categoryRepo:       categoryRepo,
+		accountRepo:        accountRepo,
+		reconciliationRepo: reconciliationRepo,
+		dismissalRepo:      dismissalRepo,
+		ruleRepo:           ruleRepo,
+		converter:          converter,
 	}
 }
 
 type RecordExpenseInput struct {
-	Amount       float64
+	Amount   string
+	Currency string
+	// CategoryName may be left empty for the category rules to pick one,
+	// Other when none matches
 	CategoryName string
 	Description  string
 	Date         time.Time
+	// AccountID is where the money is paid from; empty for the default
+	// account
+	AccountID string
 }
 
 type RecordExpenseOutput struct {
@@ -39,68 +66,132 @@ type RecordExpenseOutput struct {
 	RemainingBudget shared.Money
 	BudgetExceeded  bool
 	PercentageUsed  float64
+	// Duplicates are recorded transactions that look like this one entered
+	// twice
+	Duplicates []transaction.Transaction
 }
 
 func (uc *RecordExpenseUseCase) Execute(input RecordExpenseInput) (*RecordExpenseOutput, error) {
 	// Validate input
-	if input.CategoryName == "" {
-		return nil, fmt.Errorf("category name cannot be empty: %w", shared.ErrInvalidInput)
-	}
 	if input.Description == "" {
 		return nil, fmt.Errorf("description cannot be empty: %w", shared.ErrInvalidInput)
 	}
 
-	category, err := shared.NewCategory(input.CategoryName, shared.CategoryTypeExpense)
+	money, err := shared.ParseMoney(input.Amount, currencyOrDefault(input.Currency))
 	if err != nil {
-		return nil, fmt.Errorf("invalid category: %w", err)
+		return nil, fmt.Errorf("invalid expense amount: %w", err)
 	}
 
-	money, err := shared.NewMoney(input.Amount)
+	var expenseCategory category.Category
+	if input.CategoryName == "" {
+		expenseCategory, err = ruleCategory(uc.ruleRepo, uc.categoryRepo, shared.CategoryTypeExpense, money, input.Description, shared.CategoryOther.Name())
+	} else {
+		expenseCategory, err = resolveCategory(uc.categoryRepo, input.CategoryName, shared.CategoryTypeExpense)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("invalid expense amount: %w", err)
+		return nil, err
+	}
+
+	accountObj, err := resolveAccount(uc.accountRepo, input.AccountID)
+	if err != nil {
+		return nil, err
 	}
 
 	tx := transaction.NewTransaction(
 		uuid.New().String(),
 		money,
-		category,
+		expenseCategory.Value(),
 		input.Description,
 		transaction.TransactionTypeExpense,
 		input.Date,
-	)
+	).WithAccountID(accountObj.ID())
+
+	if err := ensureOpenPeriod(uc.reconciliationRepo, tx); err != nil {
+		return nil, err
+	}
 
 	if err := uc.transactionRepo.Save(tx); err != nil {
 		return nil, fmt.Errorf("failed to save transaction: %w", err)
 	}
 
-	year, month, _ := input.Date.Date()
-	budgetObj, err := uc.budgetRepo.FindByCategoryAndMonth(input.CategoryName, month, year)
-
 	output := &RecordExpenseOutput{
 		Transaction: tx,
 	}
 
-	if err == nil {
-		startOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
-		endOfMonth := startOfMonth.AddDate(0, 1, 0).Add(-time.Second)
+	// The expense is recorded either way, so failing to look for duplicates
+	// only means none are reported
+	if duplicates, err := findDuplicatesOf(uc.transactionRepo, uc.dismissalRepo, tx); err == nil {
+		output.Duplicates = duplicates
+	}
+
+	categories, err := uc.categoryRepo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+	parents := category.ParentNames(categories)
+
+	year, month, _ := input.Date.Date()
+	startOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	endOfMonth := startOfMonth.AddDate(0, 1, 0).Add(-time.Second)
+
+	monthTransactions, err := uc.transactionRepo.FindByDateRange(startOfMonth, endOfMonth)
+	if err != nil {
+		return output, nil
+	}
+	grouped := transaction.GroupByCategory(monthTransactions, parents)
+
+	// A budget may be set on the category or on its parent. Report the
+	// first one that is exceeded, otherwise the most specific one.
+	budgetNames := []string{expenseCategory.Name()}
+	if parentName, exists := parents[expenseCategory.Name()]; exists {
+		budgetNames = append(budgetNames, parentName)
+	}
+
+	for _, budgetName := range budgetNames {
+		budgetObj, err := uc.budgetRepo.FindByCategoryAndMonth(budgetName, month, year)
+		if err != nil {
+			continue
+		}
 
-		monthTransactions, err := uc.transactionRepo.FindByDateRange(startOfMonth, endOfMonth)
-		if err == nil {
-			var categoryTransactions []transaction.Transaction
-			for _, t := range monthTransactions {
-				if t.Category().Name() == input.CategoryName && t.IsExpense() {
-					categoryTransactions = append(categoryTransactions, t)
+		// Spending is measured in the budget currency, whatever the
+		// currency each expense was paid in
+		var categoryTransactions []transaction.Transaction
+		for _, t := range grouped[budgetName] {
+			if t.IsExpense() {
+				converted, err := uc.converter.Convert(t.Amount(), budgetObj.Limit().Currency(), t.CreatedAt())
+				if err != nil {
+					return nil, fmt.Errorf("failed to convert transaction: %w", err)
 				}
+				categoryTransactions = append(categoryTransactions, t.WithAmount(converted))
 			}
+		}
+
+		spent, err := transaction.CalculateMonthlyTotal(categoryTransactions, transaction.TransactionTypeExpense)
+		if err != nil {
+			return nil, fmt.Errorf("failed to total spending: %w", err)
+		}
+
+		remaining, err := budgetObj.RemainingAmount(spent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compute remaining budget: %w", err)
+		}
 
-			spent := transaction.CalculateMonthlyTotal(categoryTransactions, transaction.TransactionTypeExpense)
+		exceeded, err := budgetObj.IsExceeded(spent)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare spending with budget: %w", err)
+		}
 
+		if output.Budget == nil || exceeded {
 			output.Budget = &budgetObj
 			output.Spent = spent
-			output.RemainingBudget = budgetObj.RemainingAmount(spent)
-			output.BudgetExceeded = budgetObj.IsExceeded(spent)
+			output.RemainingBudget = remaining
+			output.BudgetExceeded = exceeded
 			output.PercentageUsed = budgetObj.PercentageUsed(spent)
 		}
+
+		if output.BudgetExceeded {
+			break
+		}
 	}
 
 	return output, nil
@@ -0,0 +1,129 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RecordIncomeUseCase records money coming in under any income category,
+// e.g. freelance work or a bonus. Like a salary, it can pay the month's fixed
+// charges, but only those no other income has paid yet.
+type RecordIncomeUseCase struct {
+	uow          UnitOfWork
+	categoryRepo category.Repository
+	ruleRepo     categorization.Repository
+	converter    CurrencyConverter
+}
+
+func NewRecordIncomeUseCase(
+	uow UnitOfWork,
+	categoryRepo category.Repository,
+	ruleRepo categorization.Repository,
+	converter CurrencyConverter,
+) *RecordIncomeUseCase {
+	return &RecordIncomeUseCase{
+		uow:          uow,
+		categoryRepo: categoryRepo,
+		ruleRepo:     ruleRepo,
+		converter:    converter,
+	}
+}
+
+type RecordIncomeInput struct {
+	Amount   string
+	Currency string
+	// CategoryName may be left empty for the category rules to pick one
+	CategoryName      string
+	Description       string
+	Date              time.Time
+	ApplyFixedCharges bool
+	// AccountID is where the money arrives; empty for the default account
+	AccountID string
+}
+
+type RecordIncomeOutput struct {
+	Transaction transaction.Transaction
+	FixedChargeDeduction
+	NetAmount shared.Money
+}
+
+func (uc *RecordIncomeUseCase) Execute(input RecordIncomeInput) (*RecordIncomeOutput, error) {
+	// Validate input
+	if input.Description == "" {
+		return nil, fmt.Errorf("description cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	money, err := shared.ParseMoney(input.Amount, currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("invalid income amount: %w", err)
+	}
+
+	var incomeCategory category.Category
+	if input.CategoryName == "" {
+		incomeCategory, err = ruleCategory(uc.ruleRepo, uc.categoryRepo, shared.CategoryTypeIncome, money, input.Description, "")
+	} else {
+		incomeCategory, err = resolveCategory(uc.categoryRepo, input.CategoryName, shared.CategoryTypeIncome)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if incomeCategory.Name() == shared.CategoryBorrowed.Name() {
+		return nil, fmt.Errorf("borrowed money must be recorded as a loan: %w", shared.ErrInvalidInput)
+	}
+
+	tx := transaction.NewTransaction(
+		uuid.New().String(),
+		money,
+		incomeCategory.Value(),
+		input.Description,
+		transaction.TransactionTypeIncome,
+		input.Date,
+	)
+
+	deduction := FixedChargeDeduction{
+		FixedChargesTotal: shared.ZeroOf(money.Currency()),
+	}
+
+	err = uc.uow.Do(func(repos Repositories) error {
+		accountObj, err := resolveAccount(repos.Accounts, input.AccountID)
+		if err != nil {
+			return err
+		}
+		tx = tx.WithAccountID(accountObj.ID())
+
+		if err := ensureOpenPeriod(repos.Reconciliations, tx); err != nil {
+			return err
+		}
+
+		if err := repos.Transactions.Save(tx); err != nil {
+			return fmt.Errorf("failed to save income transaction: %w", err)
+		}
+
+		if !input.ApplyFixedCharges {
+			return nil
+		}
+
+		deduction, err = deductFixedCharges(repos, uc.converter, accountObj.ID(), money.Currency(), input.Date)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	netAmount, err := money.Subtract(deduction.FixedChargesTotal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute net amount: %w", err)
+	}
+
+	return &RecordIncomeOutput{
+		Transaction:          tx,
+		FixedChargeDeduction: deduction,
+		NetAmount:            netAmount,
+	}, nil
+}
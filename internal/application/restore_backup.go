@@ -0,0 +1,340 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"sort"
+)
+
+// ErrDatabaseNotEmpty is returned when restoring a backup over data, which
+// would mix the two
+var ErrDatabaseNotEmpty = fmt.Errorf("the database already holds data, restore into a new one: %w", shared.ErrInvalidInput)
+
+// RestoreBackupUseCase loads a backup into a database that holds nothing but
+// what a new install starts with: the default account and the built-in
+// categories, which take the look and archived state they have in the
+// backup. Everything is restored in one unit of work, so a backup that fails
+// half way leaves the database as it was.
+type RestoreBackupUseCase struct {
+	uow UnitOfWork
+}
+
+func NewRestoreBackupUseCase(uow UnitOfWork) *RestoreBackupUseCase {
+	return &RestoreBackupUseCase{
+		uow: uow,
+	}
+}
+
+type RestoreBackupInput struct {
+	Backup *Backup
+}
+
+type RestoreBackupOutput struct {
+	Backup *Backup
+}
+
+func (uc *RestoreBackupUseCase) Execute(input RestoreBackupInput) (*RestoreBackupOutput, error) {
+	// Validate input
+	if input.Backup == nil {
+		return nil, fmt.Errorf("backup cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	if input.Backup.Version < 1 {
+		return nil, fmt.Errorf("not a moka backup: %w", shared.ErrInvalidInput)
+	}
+	if input.Backup.Version > BackupVersion {
+		return nil, fmt.Errorf("backup version %d was made by a newer moka, this one reads up to version %d: %w", input.Backup.Version, BackupVersion, shared.ErrInvalidInput)
+	}
+
+	backup := input.Backup
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		if err := ensureNoData(repos); err != nil {
+			return err
+		}
+
+		for _, record := range backup.Accounts {
+			a, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("account %s: %w", record.ID, err)
+			}
+			save := repos.Accounts.Save
+			if a.ID() == account.DefaultAccountID {
+				save = repos.Accounts.Update
+			}
+			if err := save(a); err != nil {
+				return fmt.Errorf("failed to restore account %s: %w", record.ID, err)
+			}
+		}
+
+		// Parents go first, as sub-categories refer to them
+		categories := make([]BackupCategory, len(backup.Categories))
+		copy(categories, backup.Categories)
+		sort.SliceStable(categories, func(i, j int) bool {
+			return categories[i].ParentID == "" && categories[j].ParentID != ""
+		})
+		for _, record := range categories {
+			c, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("category %s: %w", record.ID, err)
+			}
+			_, err = repos.Categories.FindByID(c.ID())
+			switch {
+			case err == nil:
+				err = repos.Categories.Update(c)
+			case errors.Is(err, shared.ErrNotFound):
+				err = repos.Categories.Save(c)
+			}
+			if err != nil {
+				return fmt.Errorf("failed to restore category %s: %w", record.ID, err)
+			}
+		}
+
+		for _, record := range backup.ExchangeRates {
+			rate, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("exchange rate %s: %w", record.ID, err)
+			}
+			if err := repos.ExchangeRates.Save(rate); err != nil {
+				return fmt.Errorf("failed to restore exchange rate %s: %w", record.ID, err)
+			}
+		}
+
+		for _, record := range backup.Loans {
+			l, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("loan %s: %w", record.ID, err)
+			}
+			if err := repos.Loans.Save(l); err != nil {
+				return fmt.Errorf("failed to restore loan %s: %w", record.ID, err)
+			}
+		}
+
+		for _, record := range backup.LoanInstallments {
+			i, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("loan installment %s: %w", record.ID, err)
+			}
+			if err := repos.LoanInstallments.Save(i); err != nil {
+				return fmt.Errorf("failed to restore loan installment %s: %w", record.ID, err)
+			}
+		}
+
+		for _, record := range backup.FixedCharges {
+			fc, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("fixed charge %s: %w", record.ID, err)
+			}
+			if err := repos.FixedCharges.Save(fc); err != nil {
+				return fmt.Errorf("failed to restore fixed charge %s: %w", record.ID, err)
+			}
+		}
+
+		for _, record := range backup.Goals {
+			g, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("goal %s: %w", record.ID, err)
+			}
+			if err := repos.Goals.Save(g); err != nil {
+				return fmt.Errorf("failed to restore goal %s: %w", record.ID, err)
+			}
+		}
+
+		for _, record := range backup.RecurringRules {
+			rule, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("recurring rule %s: %w", record.ID, err)
+			}
+			if err := repos.RecurringRules.Save(rule); err != nil {
+				return fmt.Errorf("failed to restore recurring rule %s: %w", record.ID, err)
+			}
+		}
+
+		for _, record := range backup.CategoryRules {
+			rule, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("category rule %s: %w", record.ID, err)
+			}
+			if err := repos.CategoryRules.Save(rule); err != nil {
+				return fmt.Errorf("failed to restore category rule %s: %w", record.ID, err)
+			}
+		}
+
+		for _, record := range backup.Budgets {
+			b, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("budget %s: %w", record.ID, err)
+			}
+			if err := repos.Budgets.Save(b); err != nil {
+				return fmt.Errorf("failed to restore budget %s: %w", record.ID, err)
+			}
+		}
+
+		if err := restoreTransactions(repos, backup.Transactions, backup.Reconciliations); err != nil {
+			return err
+		}
+
+		for _, record := range backup.LoanPayments {
+			p, err := record.restore()
+			if err != nil {
+				return fmt.Errorf("loan payment %s: %w", record.ID, err)
+			}
+			if err := repos.LoanPayments.Save(p); err != nil {
+				return fmt.Errorf("failed to restore loan payment %s: %w", record.ID, err)
+			}
+		}
+
+		for _, record := range backup.RecurringOccurrences {
+			if err := repos.RecurringOccurrences.Save(record.restore()); err != nil {
+				return fmt.Errorf("failed to restore recurring occurrence %s: %w", record.ID, err)
+			}
+		}
+
+		for _, record := range backup.Dismissals {
+			d := transaction.NewDismissal(record.TransactionID, record.OtherID)
+			if err := repos.Dismissals.Save(d); err != nil {
+				return fmt.Errorf("failed to restore duplicate dismissal: %w", err)
+			}
+		}
+
+		for _, record := range backup.StatementAccounts {
+			s, err := account.NewStatementAccount(record.Number, record.AccountID)
+			if err != nil {
+				return fmt.Errorf("statement account %s: %w", record.Number, err)
+			}
+			if err := repos.StatementAccounts.Save(s); err != nil {
+				return fmt.Errorf("failed to restore statement account %s: %w", record.Number, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &RestoreBackupOutput{
+		Backup: backup,
+	}, nil
+}
+
+// restoreTransactions saves the transactions along with the reconciliations
+// that lock them. The two refer to each other through the adjustments a
+// reconciliation posts, so adjustments are saved unlocked before the
+// reconciliations and locked again once those exist.
+func restoreTransactions(repos Repositories, transactions []BackupTransaction, reconciliations []BackupReconciliation) error {
+	adjustments := make(map[string]bool)
+	for _, record := range reconciliations {
+		if record.AdjustmentTransactionID != "" {
+			adjustments[record.AdjustmentTransactionID] = true
+		}
+	}
+
+	for _, record := range transactions {
+		if !adjustments[record.ID] {
+			continue
+		}
+		tx, err := record.restore()
+		if err != nil {
+			return fmt.Errorf("transaction %s: %w", record.ID, err)
+		}
+		if err := repos.Transactions.Save(tx.WithReconciliationID("")); err != nil {
+			return fmt.Errorf("failed to restore transaction %s: %w", record.ID, err)
+		}
+	}
+
+	for _, record := range reconciliations {
+		rec, err := record.restore()
+		if err != nil {
+			return fmt.Errorf("reconciliation %s: %w", record.ID, err)
+		}
+		if err := repos.Reconciliations.Save(rec); err != nil {
+			return fmt.Errorf("failed to restore reconciliation %s: %w", record.ID, err)
+		}
+	}
+
+	for _, record := range transactions {
+		if adjustments[record.ID] {
+			if record.ReconciliationID == "" {
+				continue
+			}
+			if err := repos.Transactions.Lock(record.ID, record.ReconciliationID); err != nil {
+				return fmt.Errorf("failed to restore transaction %s: %w", record.ID, err)
+			}
+			continue
+		}
+		tx, err := record.restore()
+		if err != nil {
+			return fmt.Errorf("transaction %s: %w", record.ID, err)
+		}
+		if err := repos.Transactions.Save(tx); err != nil {
+			return fmt.Errorf("failed to restore transaction %s: %w", record.ID, err)
+		}
+	}
+
+	return nil
+}
+
+// ensureNoData refuses to restore into a database where anything was
+// recorded, beyond the default account and the built-in categories
+func ensureNoData(repos Repositories) error {
+	transactions, err := repos.Transactions.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to get transactions: %w", err)
+	}
+	budgets, err := repos.Budgets.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to get budgets: %w", err)
+	}
+	charges, err := repos.FixedCharges.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to get fixed charges: %w", err)
+	}
+	loans, err := repos.Loans.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to get loans: %w", err)
+	}
+	goals, err := repos.Goals.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to get goals: %w", err)
+	}
+	recurringRules, err := repos.RecurringRules.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to get recurring rules: %w", err)
+	}
+	categoryRules, err := repos.CategoryRules.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to get category rules: %w", err)
+	}
+	rates, err := repos.ExchangeRates.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to get exchange rates: %w", err)
+	}
+	if len(transactions)+len(budgets)+len(charges)+len(loans)+len(goals)+len(recurringRules)+len(categoryRules)+len(rates) > 0 {
+		return ErrDatabaseNotEmpty
+	}
+
+	accounts, err := repos.Accounts.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to get accounts: %w", err)
+	}
+	for _, a := range accounts {
+		if !a.IsDefault() {
+			return ErrDatabaseNotEmpty
+		}
+	}
+
+	categories, err := repos.Categories.FindAll()
+	if err != nil {
+		return fmt.Errorf("failed to get categories: %w", err)
+	}
+	for _, c := range categories {
+		if !c.IsBuiltin() {
+			return ErrDatabaseNotEmpty
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/recurring"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RunRecurringRulesUseCase generates the transactions of every active rule
+// that fell due up to a given day. Due dates already applied are skipped, so
+// running it again, or after a long downtime, never duplicates anything. Due
+// dates in a period that was reconciled are skipped too, and reported once.
+type RunRecurringRulesUseCase struct {
+	uow UnitOfWork
+}
+
+func NewRunRecurringRulesUseCase(uow UnitOfWork) *RunRecurringRulesUseCase {
+	return &RunRecurringRulesUseCase{
+		uow: uow,
+	}
+}
+
+type RunRecurringRulesInput struct {
+	At time.Time
+}
+
+type RunRecurringRulesOutput struct {
+	Transactions []transaction.Transaction
+	// Skipped are the due dates that fell in a reconciled period, recorded
+	// without a transaction so they are not tried again
+	Skipped []recurring.Occurrence
+}
+
+func (uc *RunRecurringRulesUseCase) Execute(input RunRecurringRulesInput) (*RunRecurringRulesOutput, error) {
+	var rules []recurring.Rule
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		var err error
+		rules, err = repos.RecurringRules.FindActive()
+		if err != nil {
+			return fmt.Errorf("failed to get recurring rules: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := &RunRecurringRulesOutput{}
+	var errs []error
+
+	// Each rule is applied in its own unit of work so that one broken rule
+	// does not hold back the others
+	for _, rule := range rules {
+		generated, skipped, err := uc.applyRule(rule, input.At)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recurring rule %q: %w", rule.Description(), err))
+			continue
+		}
+		output.Transactions = append(output.Transactions, generated...)
+		output.Skipped = append(output.Skipped, skipped...)
+		for _, o := range skipped {
+			errs = append(errs, fmt.Errorf(
+				"recurring rule %q: skipped %s, the account was already reconciled for that day: %w",
+				rule.Description(), o.DueDate().Format("Jan 02, 2006"), shared.ErrInvalidInput,
+			))
+		}
+	}
+
+	return output, errors.Join(errs...)
+}
+
+func (uc *RunRecurringRulesUseCase) applyRule(rule recurring.Rule, at time.Time) ([]transaction.Transaction, []recurring.Occurrence, error) {
+	var (
+		generated []transaction.Transaction
+		skipped   []recurring.Occurrence
+	)
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		generated, skipped = nil, nil
+
+		occurrences, err := repos.RecurringOccurrences.FindByRuleID(rule.ID())
+		if err != nil {
+			return err
+		}
+
+		applied := make(map[time.Time]bool, len(occurrences))
+		for _, o := range occurrences {
+			applied[o.DueDate()] = true
+		}
+
+		for _, due := range rule.DueDates(at) {
+			if applied[due] {
+				continue
+			}
+
+			tx := transaction.NewTransaction(
+				uuid.New().String(),
+				rule.Amount(),
+				rule.Category(),
+				rule.Description(),
+				recurringTransactionType(rule),
+				due,
+			)
+
+			// A reconciled period must not change, so the due date is
+			// marked as applied without a transaction
+			if err := ensureOpenPeriod(repos.Reconciliations, tx); err != nil {
+				if !errors.Is(err, shared.ErrInvalidInput) {
+					return err
+				}
+				occurrence := recurring.NewOccurrence(uuid.New().String(), rule.ID(), due, "")
+				if err := repos.RecurringOccurrences.Save(occurrence); err != nil {
+					return err
+				}
+				skipped = append(skipped, occurrence)
+				continue
+			}
+
+			if err := repos.Transactions.Save(tx); err != nil {
+				return fmt.Errorf("failed to save transaction: %w", err)
+			}
+
+			occurrence := recurring.NewOccurrence(uuid.New().String(), rule.ID(), due, tx.ID())
+			if err := repos.RecurringOccurrences.Save(occurrence); err != nil {
+				return err
+			}
+
+			generated = append(generated, tx)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return generated, skipped, nil
+}
+
+func recurringTransactionType(rule recurring.Rule) transaction.TransactionType {
+	if rule.IsIncome() {
+		return transaction.TransactionTypeIncome
+	}
+	return transaction.TransactionTypeExpense
+}
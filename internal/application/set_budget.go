@@ -0,0 +1,90 @@
+package application
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/budget"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SetBudgetUseCase struct {
+	budgetRepo   budget.Repository
+	categoryRepo category.Repository
+}
+
+func NewSetBudgetUseCase(budgetRepo budget.Repository, categoryRepo category.Repository) *SetBudgetUseCase {
+	return &SetBudgetUseCase{
+		budgetRepo:   budgetRepo,
+		categoryRepo: categoryRepo,
+	}
+}
+
+type SetBudgetInput struct {
+	CategoryName string
+	Limit        string
+	Currency     string
+	Month        time.Month
+	Year         int
+}
+
+type SetBudgetOutput struct {
+	Budget  budget.Budget
+	Created bool
+}
+
+// Execute creates the budget for a category and month, or replaces its limit
+// when one is already set for that period. The category must be an existing
+// expense category that is not archived.
+func (uc *SetBudgetUseCase) Execute(input SetBudgetInput) (*SetBudgetOutput, error) {
+	// Validate input
+	if input.CategoryName == "" {
+		return nil, fmt.Errorf("category name cannot be empty: %w", shared.ErrInvalidInput)
+	}
+	if input.Month < time.January || input.Month > time.December {
+		return nil, fmt.Errorf("invalid month %d: %w", input.Month, shared.ErrInvalidInput)
+	}
+	if input.Year <= 0 {
+		return nil, fmt.Errorf("invalid year %d: %w", input.Year, shared.ErrInvalidInput)
+	}
+
+	categoryObj, err := resolveCategory(uc.categoryRepo, input.CategoryName, shared.CategoryTypeExpense)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := shared.ParseMoney(input.Limit, currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("invalid budget limit %q, %v: %w", input.Limit, err, shared.ErrInvalidInput)
+	}
+
+	existing, err := uc.budgetRepo.FindByCategoryAndMonth(categoryObj.Name(), input.Month, input.Year)
+	if err == nil {
+		updated := existing.WithLimit(limit)
+		if err := uc.budgetRepo.Update(updated); err != nil {
+			return nil, fmt.Errorf("failed to update budget: %w", err)
+		}
+
+		return &SetBudgetOutput{Budget: updated, Created: false}, nil
+	}
+	if !errors.Is(err, shared.ErrNotFound) {
+		return nil, fmt.Errorf("failed to find budget: %w", err)
+	}
+
+	budgetObj := budget.NewBudget(
+		uuid.New().String(),
+		categoryObj.Value(),
+		limit,
+		input.Month,
+		input.Year,
+	)
+
+	if err := uc.budgetRepo.Save(budgetObj); err != nil {
+		return nil, fmt.Errorf("failed to save budget: %w", err)
+	}
+
+	return &SetBudgetOutput{Budget: budgetObj, Created: true}, nil
+}
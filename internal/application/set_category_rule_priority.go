@@ -0,0 +1,53 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// SetCategoryRulePriorityUseCase moves a rule up or down the order in which
+// rules are evaluated
+type SetCategoryRulePriorityUseCase struct {
+	ruleRepo categorization.Repository
+}
+
+func NewSetCategoryRulePriorityUseCase(ruleRepo categorization.Repository) *SetCategoryRulePriorityUseCase {
+	return &SetCategoryRulePriorityUseCase{
+		ruleRepo: ruleRepo,
+	}
+}
+
+type SetCategoryRulePriorityInput struct {
+	RuleID   string
+	Priority int
+}
+
+type SetCategoryRulePriorityOutput struct {
+	Rule categorization.Rule
+}
+
+func (uc *SetCategoryRulePriorityUseCase) Execute(input SetCategoryRulePriorityInput) (*SetCategoryRulePriorityOutput, error) {
+	// Validate input
+	if input.RuleID == "" {
+		return nil, fmt.Errorf("rule ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	rule, err := uc.ruleRepo.FindByID(input.RuleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category rule: %w", err)
+	}
+
+	moved, err := rule.WithPriority(input.Priority)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ruleRepo.Update(moved); err != nil {
+		return nil, fmt.Errorf("failed to update category rule: %w", err)
+	}
+
+	return &SetCategoryRulePriorityOutput{
+		Rule: moved,
+	}, nil
+}
@@ -0,0 +1,181 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"html"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ParseOFX reads the transactions of an OFX (or QFX) statement, either the
+// SGML of OFX 1.x, where elements are not closed, or the XML of OFX 2.x.
+// Entries are listed under the number of their bank or card account and
+// carry their FITID. Entries that cannot be read are reported and skipped;
+// a file that is not OFX at all is an error.
+func ParseOFX(r io.Reader, booking StatementBooking) ([]StatementFileEntry, []ImportRowError, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+
+	content := string(data)
+	start := strings.Index(strings.ToUpper(content), "<OFX>")
+	if start < 0 {
+		return nil, nil, fmt.Errorf("not an OFX statement: %w", shared.ErrInvalidInput)
+	}
+
+	var (
+		entries []StatementFileEntry
+		errs    []ImportRowError
+		// open lists the aggregates around the current element, outermost
+		// first
+		open             []string
+		statementAccount string
+		currency         = booking.Currency
+		// fields holds the elements of the STMTTRN being read, nil outside
+		// of one
+		fields    map[string]string
+		entryLine int
+	)
+
+	line := 1 + strings.Count(content[:start], "\n")
+	rest := content[start:]
+
+	for {
+		i := strings.IndexByte(rest, '<')
+		if i < 0 {
+			break
+		}
+		line += strings.Count(rest[:i], "\n")
+		rest = rest[i+1:]
+
+		j := strings.IndexByte(rest, '>')
+		if j < 0 {
+			break
+		}
+		tag := strings.ToUpper(strings.TrimSpace(rest[:j]))
+		rest = rest[j+1:]
+
+		k := strings.IndexByte(rest, '<')
+		if k < 0 {
+			k = len(rest)
+		}
+		value := html.UnescapeString(strings.TrimSpace(rest[:k]))
+
+		switch {
+		case strings.HasPrefix(tag, "/"):
+			// Closing an aggregate also closes the elements left open inside
+			// it; the closing tags of elements holding a value are ignored
+			name := tag[1:]
+			for n := len(open) - 1; n >= 0; n-- {
+				if open[n] != name {
+					continue
+				}
+				open = open[:n]
+				if name == "STMTTRN" && fields != nil {
+					entry, err := ofxEntry(fields, statementAccount, currency, booking)
+					if err != nil {
+						errs = append(errs, ImportRowError{Line: entryLine, Err: err})
+					} else {
+						entry.Line = entryLine
+						entries = append(entries, entry)
+					}
+					fields = nil
+				}
+				break
+			}
+
+		case value == "":
+			open = append(open, tag)
+			if tag == "STMTTRN" {
+				fields = make(map[string]string)
+				entryLine = line
+			}
+
+		case fields != nil:
+			// The first value wins, so that the NAME of a PAYEE does not
+			// replace the NAME of the entry
+			if _, ok := fields[tag]; !ok {
+				fields[tag] = value
+			}
+
+		case tag == "ACCTID" && (containsTag(open, "BANKACCTFROM") || containsTag(open, "CCACCTFROM")):
+			statementAccount = value
+
+		case tag == "CURDEF":
+			currency = value
+		}
+	}
+
+	return entries, errs, nil
+}
+
+// ofxEntry books the elements of a STMTTRN as a transaction. The sign of the
+// amount tells a payment from money received.
+func ofxEntry(fields map[string]string, statementAccount string, currency string, booking StatementBooking) (StatementFileEntry, error) {
+	posted := fields["DTPOSTED"]
+	if len(posted) < 8 {
+		return StatementFileEntry{}, fmt.Errorf("invalid date %q: %w", posted, shared.ErrInvalidInput)
+	}
+	// Only the day matters, whatever the time and time zone that follow it
+	date, err := time.Parse("20060102", posted[:8])
+	if err != nil {
+		return StatementFileEntry{}, fmt.Errorf("invalid date %q: %w", posted, shared.ErrInvalidInput)
+	}
+
+	signed, err := shared.ParseMinorUnits(fields["TRNAMT"])
+	if err != nil {
+		return StatementFileEntry{}, fmt.Errorf("invalid amount %q: %w", fields["TRNAMT"], err)
+	}
+	if signed == 0 {
+		return StatementFileEntry{}, fmt.Errorf("amount cannot be zero: %w", shared.ErrInvalidInput)
+	}
+
+	description := fields["NAME"]
+	if description == "" {
+		description = fields["MEMO"]
+	}
+	if description == "" {
+		return StatementFileEntry{}, fmt.Errorf("description cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	amount, err := shared.NewMoney(absMinorUnits(signed), currencyOrDefault(currency))
+	if err != nil {
+		return StatementFileEntry{}, err
+	}
+
+	typ := transaction.TransactionTypeIncome
+	txCategory := booking.IncomeCategory
+	if signed < 0 {
+		typ = transaction.TransactionTypeExpense
+		txCategory = booking.ExpenseCategory
+	}
+
+	tx := transaction.NewTransaction(
+		uuid.New().String(),
+		amount,
+		txCategory,
+		description,
+		typ,
+		date,
+	).WithFITID(fields["FITID"])
+
+	return StatementFileEntry{
+		StatementAccount: statementAccount,
+		Transaction:      tx,
+	}, nil
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
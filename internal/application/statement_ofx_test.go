@@ -0,0 +1,167 @@
+package application
+
+import (
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// wantEntry is what a test expects of a StatementFileEntry
+type wantEntry struct {
+	line             int
+	statementAccount string
+	date             string
+	description      string
+	typ              transaction.TransactionType
+	minorUnits       int64
+	currency         string
+	fitID            string
+}
+
+func testBooking(t *testing.T) StatementBooking {
+	t.Helper()
+
+	expense, err := shared.NewCategory("Other", shared.CategoryTypeExpense)
+	if err != nil {
+		t.Fatal(err)
+	}
+	income, err := shared.NewCategory("Salary", shared.CategoryTypeIncome)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return StatementBooking{
+		Currency:        "MAD",
+		ExpenseCategory: expense,
+		IncomeCategory:  income,
+	}
+}
+
+// parseFixture parses a file of testdata, which must be readable
+func parseFixture(t *testing.T, name string, parse func(*os.File) ([]StatementFileEntry, []ImportRowError, error)) ([]StatementFileEntry, []ImportRowError) {
+	t.Helper()
+
+	file, err := os.Open(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	entries, errs, err := parse(file)
+	if err != nil {
+		t.Fatalf("parse %s: %v", name, err)
+	}
+	return entries, errs
+}
+
+func checkEntries(t *testing.T, got []StatementFileEntry, want []wantEntry) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d entries, want %d", len(got), len(want))
+	}
+
+	for i, w := range want {
+		g := got[i]
+		tx := g.Transaction
+
+		if g.Line != w.line {
+			t.Errorf("entry %d: line = %d, want %d", i, g.Line, w.line)
+		}
+		if g.StatementAccount != w.statementAccount {
+			t.Errorf("entry %d: statement account = %q, want %q", i, g.StatementAccount, w.statementAccount)
+		}
+		if date := tx.CreatedAt().Format("2006-01-02"); date != w.date {
+			t.Errorf("entry %d: date = %s, want %s", i, date, w.date)
+		}
+		if tx.Description() != w.description {
+			t.Errorf("entry %d: description = %q, want %q", i, tx.Description(), w.description)
+		}
+		if tx.Type() != w.typ {
+			t.Errorf("entry %d: type = %s, want %s", i, tx.Type(), w.typ)
+		}
+		if tx.Amount().MinorUnits() != w.minorUnits || tx.Amount().Currency() != w.currency {
+			t.Errorf("entry %d: amount = %d %s, want %d %s", i, tx.Amount().MinorUnits(), tx.Amount().Currency(), w.minorUnits, w.currency)
+		}
+		if tx.FITID() != w.fitID {
+			t.Errorf("entry %d: FITID = %q, want %q", i, tx.FITID(), w.fitID)
+		}
+
+		wantCategory := "Salary"
+		if w.typ == transaction.TransactionTypeExpense {
+			wantCategory = "Other"
+		}
+		if tx.Category().Name() != wantCategory {
+			t.Errorf("entry %d: category = %q, want %q", i, tx.Category().Name(), wantCategory)
+		}
+		if tx.ID() == "" {
+			t.Errorf("entry %d: missing id", i)
+		}
+	}
+}
+
+func checkErrorLines(t *testing.T, got []ImportRowError, want []int) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("got %d errors (%v), want %d", len(got), got, len(want))
+	}
+	for i, line := range want {
+		if got[i].Line != line {
+			t.Errorf("error %d: line = %d, want %d (%v)", i, got[i].Line, line, got[i].Err)
+		}
+	}
+}
+
+func TestParseOFX(t *testing.T) {
+	tests := []struct {
+		fixture    string
+		wantErrors []int
+		want       []wantEntry
+	}{
+		{
+			fixture:    "checking.ofx",
+			wantErrors: []int{63},
+			want: []wantEntry{
+				{40, "000123456789", "2026-10-02", "CARREFOUR MARKET", transaction.TransactionTypeExpense, 4250, "EUR", "20261002-0001"},
+				{48, "000123456789", "2026-10-05", "SALAIRE OCTOBRE", transaction.TransactionTypeIncome, 250000, "EUR", "20261005-0002"},
+				{56, "000123456789", "2026-10-07", "PROCTER & GAMBLE", transaction.TransactionTypeExpense, 1890, "EUR", "20261007-0003"},
+				{70, "000123456789", "2026-10-12", "PRLV SEPA STREAMING", transaction.TransactionTypeExpense, 999, "EUR", "20261012-0005"},
+			},
+		},
+		{
+			fixture:    "creditcard.ofx",
+			wantErrors: []int{43},
+			want: []wantEntry{
+				{21, "4111111111111111", "2026-09-03", "Corner Bookshop", transaction.TransactionTypeExpense, 6420, "USD", "CC-7781"},
+				{35, "4111111111111111", "2026-09-20", "Payment - Thank You", transaction.TransactionTypeIncome, 30000, "USD", "CC-7790"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			booking := testBooking(t)
+			entries, errs := parseFixture(t, tt.fixture, func(f *os.File) ([]StatementFileEntry, []ImportRowError, error) {
+				return ParseOFX(f, booking)
+			})
+
+			checkEntries(t, entries, tt.want)
+			checkErrorLines(t, errs, tt.wantErrors)
+		})
+	}
+}
+
+func TestParseOFXRejectsOtherFiles(t *testing.T) {
+	file, err := os.Open(filepath.Join("testdata", "european.qif"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	if _, _, err := ParseOFX(file, testBooking(t)); err == nil {
+		t.Fatal("expected an error for a QIF file")
+	}
+}
@@ -0,0 +1,211 @@
+package application
+
+import (
+	"bufio"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// qifBankTypes are the QIF sections that list the transactions of an
+// account. Category, class, memorized and investment lists are ignored.
+var qifBankTypes = map[string]bool{
+	"BANK":  true,
+	"CASH":  true,
+	"CCARD": true,
+	"OTH A": true,
+	"OTH L": true,
+}
+
+// ParseQIF reads the transactions of a QIF statement. Entries are listed
+// under the name of the account given by the !Account block before them,
+// if any. QIF has no transaction ids, so re-imported entries can only be
+// caught as possible duplicates.
+func ParseQIF(r io.Reader, booking StatementBooking) ([]StatementFileEntry, []ImportRowError, error) {
+	var (
+		entries []StatementFileEntry
+		errs    []ImportRowError
+		// section is the type of the current !Type section
+		section          string
+		inAccount        bool
+		statementAccount string
+		// fields holds the lines of the record being read by field code,
+		// nil between records
+		fields     map[byte]string
+		recordLine int
+	)
+
+	endRecord := func() {
+		switch {
+		case fields == nil:
+		case inAccount:
+			statementAccount = fields['N']
+			inAccount = false
+		case qifBankTypes[section]:
+			entry, err := qifEntry(fields, statementAccount, booking)
+			if err != nil {
+				errs = append(errs, ImportRowError{Line: recordLine, Err: err})
+			} else {
+				entry.Line = recordLine
+				entries = append(entries, entry)
+			}
+		}
+		fields = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+
+		switch text[0] {
+		case '!':
+			endRecord()
+			header := strings.ToUpper(text)
+			switch {
+			case header == "!ACCOUNT":
+				inAccount = true
+			case strings.HasPrefix(header, "!TYPE:"):
+				section = strings.TrimSpace(strings.TrimPrefix(header, "!TYPE:"))
+				inAccount = false
+			}
+			// !Option and !Clear lines only change how Quicken reads the
+			// file
+
+		case '^':
+			endRecord()
+
+		default:
+			if fields == nil {
+				fields = make(map[byte]string)
+				recordLine = line
+			}
+			// Split lines repeat their codes; the first value, which is the
+			// one of the whole transaction, wins
+			if _, ok := fields[text[0]]; !ok {
+				fields[text[0]] = strings.TrimSpace(text[1:])
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to read statement: %w", err)
+	}
+
+	// The last record may lack its closing ^
+	endRecord()
+
+	return entries, errs, nil
+}
+
+// qifEntry books the fields of a QIF record as a transaction. The sign of
+// the amount tells a payment from money received.
+func qifEntry(fields map[byte]string, statementAccount string, booking StatementBooking) (StatementFileEntry, error) {
+	date, err := parseQIFDate(fields['D'], booking.DateFormat)
+	if err != nil {
+		return StatementFileEntry{}, err
+	}
+
+	value, ok := fields['T']
+	if !ok {
+		value = fields['U']
+	}
+	signed, err := parseStatementAmount(value, booking.DecimalComma)
+	if err != nil {
+		return StatementFileEntry{}, err
+	}
+	if signed == 0 {
+		return StatementFileEntry{}, fmt.Errorf("amount cannot be zero: %w", shared.ErrInvalidInput)
+	}
+
+	description := fields['P']
+	if description == "" {
+		description = fields['M']
+	}
+	if description == "" {
+		return StatementFileEntry{}, fmt.Errorf("description cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	amount, err := shared.NewMoney(absMinorUnits(signed), currencyOrDefault(booking.Currency))
+	if err != nil {
+		return StatementFileEntry{}, err
+	}
+
+	typ := transaction.TransactionTypeIncome
+	txCategory := booking.IncomeCategory
+	if signed < 0 {
+		typ = transaction.TransactionTypeExpense
+		txCategory = booking.ExpenseCategory
+	}
+
+	tx := transaction.NewTransaction(
+		uuid.New().String(),
+		amount,
+		txCategory,
+		description,
+		typ,
+		date,
+	)
+
+	return StatementFileEntry{
+		StatementAccount: statementAccount,
+		Transaction:      tx,
+	}, nil
+}
+
+// parseQIFDate reads a QIF date in the order of a StatementDateFormats label,
+// month first when empty as Quicken writes them. Quicken pads with spaces
+// and marks two-digit years of the 2000s with an apostrophe, as in
+// "1/ 5'26".
+func parseQIFDate(value string, format string) (time.Time, error) {
+	cleaned := strings.NewReplacer("'", "/", " ", "").Replace(value)
+	parts := strings.FieldsFunc(cleaned, func(r rune) bool {
+		return r == '/' || r == '-' || r == '.'
+	})
+	if len(parts) != 3 {
+		return time.Time{}, fmt.Errorf("invalid date %q: %w", value, shared.ErrInvalidInput)
+	}
+
+	numbers := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q: %w", value, shared.ErrInvalidInput)
+		}
+		numbers[i] = n
+	}
+
+	var year, month, day int
+	switch format {
+	case "", "MM/DD/YYYY":
+		month, day, year = numbers[0], numbers[1], numbers[2]
+	case "YYYY-MM-DD":
+		year, month, day = numbers[0], numbers[1], numbers[2]
+	case "DD/MM/YYYY", "DD.MM.YYYY", "DD-MM-YYYY":
+		day, month, year = numbers[0], numbers[1], numbers[2]
+	default:
+		return time.Time{}, fmt.Errorf("unknown date format %q: %w", format, shared.ErrInvalidInput)
+	}
+
+	if year < 100 {
+		if strings.Contains(value, "'") || year < 70 {
+			year += 2000
+		} else {
+			year += 1900
+		}
+	}
+
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+	if date.Month() != time.Month(month) || date.Day() != day {
+		return time.Time{}, fmt.Errorf("invalid date %q: %w", value, shared.ErrInvalidInput)
+	}
+
+	return date, nil
+}
@@ -0,0 +1,86 @@
+package application
+
+import (
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParseQIF(t *testing.T) {
+	tests := []struct {
+		fixture      string
+		dateFormat   string
+		decimalComma bool
+		wantErrors   []int
+		want         []wantEntry
+	}{
+		{
+			fixture:    "multi_account.qif",
+			wantErrors: []int{41, 55},
+			want: []wantEntry{
+				{22, "Everyday Checking", "2026-10-02", "Landlord", transaction.TransactionTypeExpense, 123456, "MAD", ""},
+				{28, "Everyday Checking", "2026-10-05", "Acme Corp", transaction.TransactionTypeIncome, 250000, "MAD", ""},
+				{33, "Everyday Checking", "2026-10-07", "Supermarket", transaction.TransactionTypeExpense, 8640, "MAD", ""},
+				{50, "Visa Card", "2026-10-08", "Streaming subscription", transaction.TransactionTypeExpense, 1999, "MAD", ""},
+			},
+		},
+		{
+			fixture:      "european.qif",
+			dateFormat:   "DD.MM.YYYY",
+			decimalComma: true,
+			wantErrors:   []int{10},
+			want: []wantEntry{
+				{2, "", "2026-10-02", "Loyer", transaction.TransactionTypeExpense, 123456, "MAD", ""},
+				{6, "", "2026-10-05", "Salaire", transaction.TransactionTypeIncome, 250000, "MAD", ""},
+				{14, "", "2026-10-09", "Boulangerie", transaction.TransactionTypeExpense, 1230, "MAD", ""},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			booking := testBooking(t)
+			booking.DateFormat = tt.dateFormat
+			booking.DecimalComma = tt.decimalComma
+
+			entries, errs := parseFixture(t, tt.fixture, func(f *os.File) ([]StatementFileEntry, []ImportRowError, error) {
+				return ParseQIF(f, booking)
+			})
+
+			checkEntries(t, entries, tt.want)
+			checkErrorLines(t, errs, tt.wantErrors)
+		})
+	}
+}
+
+func TestParseQIFDate(t *testing.T) {
+	tests := []struct {
+		value  string
+		format string
+		want   string
+	}{
+		{"1/ 5'26", "", "2026-01-05"},
+		{"12/31/99", "MM/DD/YYYY", "1999-12-31"},
+		{"31/12/2026", "DD/MM/YYYY", "2026-12-31"},
+		{"2026-03-04", "YYYY-MM-DD", "2026-03-04"},
+	}
+
+	for _, tt := range tests {
+		got, err := parseQIFDate(tt.value, tt.format)
+		if err != nil {
+			t.Errorf("parseQIFDate(%q, %q): %v", tt.value, tt.format, err)
+			continue
+		}
+		if got.Format("2006-01-02") != tt.want {
+			t.Errorf("parseQIFDate(%q, %q) = %s, want %s", tt.value, tt.format, got.Format("2006-01-02"), tt.want)
+		}
+		if got.Location() != time.UTC {
+			t.Errorf("parseQIFDate(%q, %q) is not in UTC", tt.value, tt.format)
+		}
+	}
+
+	if _, err := parseQIFDate("02/30/2026", "MM/DD/YYYY"); err == nil {
+		t.Error("expected an error for February 30")
+	}
+}
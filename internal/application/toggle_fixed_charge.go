@@ -0,0 +1,53 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// ToggleFixedChargeUseCase activates an inactive fixed charge or deactivates
+// an active one
+type ToggleFixedChargeUseCase struct {
+	fixedChargeRepo fixed_charge.Repository
+}
+
+func NewToggleFixedChargeUseCase(fixedChargeRepo fixed_charge.Repository) *ToggleFixedChargeUseCase {
+	return &ToggleFixedChargeUseCase{
+		fixedChargeRepo: fixedChargeRepo,
+	}
+}
+
+type ToggleFixedChargeInput struct {
+	FixedChargeID string
+}
+
+type ToggleFixedChargeOutput struct {
+	FixedCharge fixed_charge.FixedCharge
+}
+
+func (uc *ToggleFixedChargeUseCase) Execute(input ToggleFixedChargeInput) (*ToggleFixedChargeOutput, error) {
+	// Validate input
+	if input.FixedChargeID == "" {
+		return nil, fmt.Errorf("fixed charge ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	charge, err := uc.fixedChargeRepo.FindByID(input.FixedChargeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find fixed charge: %w", err)
+	}
+
+	if charge.IsActive() {
+		charge = charge.Deactivate()
+	} else {
+		charge = charge.Activate()
+	}
+
+	if err := uc.fixedChargeRepo.Update(charge); err != nil {
+		return nil, fmt.Errorf("failed to update fixed charge: %w", err)
+	}
+
+	return &ToggleFixedChargeOutput{
+		FixedCharge: charge,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/recurring"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// ToggleRecurringRuleUseCase pauses an active rule or resumes a paused one.
+// Due dates missed while paused are caught up on resume.
+type ToggleRecurringRuleUseCase struct {
+	ruleRepo recurring.Repository
+}
+
+func NewToggleRecurringRuleUseCase(ruleRepo recurring.Repository) *ToggleRecurringRuleUseCase {
+	return &ToggleRecurringRuleUseCase{
+		ruleRepo: ruleRepo,
+	}
+}
+
+type ToggleRecurringRuleInput struct {
+	RuleID string
+}
+
+type ToggleRecurringRuleOutput struct {
+	Rule recurring.Rule
+}
+
+func (uc *ToggleRecurringRuleUseCase) Execute(input ToggleRecurringRuleInput) (*ToggleRecurringRuleOutput, error) {
+	// Validate input
+	if input.RuleID == "" {
+		return nil, fmt.Errorf("rule ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	rule, err := uc.ruleRepo.FindByID(input.RuleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find recurring rule: %w", err)
+	}
+
+	if rule.IsActive() {
+		rule = rule.Deactivate()
+	} else {
+		rule = rule.Activate()
+	}
+
+	if err := uc.ruleRepo.Update(rule); err != nil {
+		return nil, fmt.Errorf("failed to update recurring rule: %w", err)
+	}
+
+	return &ToggleRecurringRuleOutput{
+		Rule: rule,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TransferMoneyUseCase moves money from one account to another, e.g. cash
+// withdrawn from the bank card. It records a linked pair of entries that
+// changes both balances but counts as neither income nor expense.
+type TransferMoneyUseCase struct {
+	uow UnitOfWork
+}
+
+func NewTransferMoneyUseCase(uow UnitOfWork) *TransferMoneyUseCase {
+	return &TransferMoneyUseCase{
+		uow: uow,
+	}
+}
+
+type TransferMoneyInput struct {
+	FromAccountID string
+	ToAccountID   string
+	Amount        string
+	Currency      string
+	Description   string
+	Date          time.Time
+}
+
+type TransferMoneyOutput struct {
+	From     account.Account
+	To       account.Account
+	Outgoing transaction.Transaction
+	Incoming transaction.Transaction
+}
+
+func (uc *TransferMoneyUseCase) Execute(input TransferMoneyInput) (*TransferMoneyOutput, error) {
+	// Validate input
+	if input.FromAccountID == "" || input.ToAccountID == "" {
+		return nil, fmt.Errorf("both accounts are required: %w", shared.ErrInvalidInput)
+	}
+	if input.FromAccountID == input.ToAccountID {
+		return nil, fmt.Errorf("cannot transfer to the same account: %w", shared.ErrInvalidInput)
+	}
+
+	money, err := shared.ParseMoney(input.Amount, currencyOrDefault(input.Currency))
+	if err != nil {
+		return nil, fmt.Errorf("invalid transfer amount: %w", err)
+	}
+
+	output := &TransferMoneyOutput{}
+
+	err = uc.uow.Do(func(repos Repositories) error {
+		from, err := resolveAccount(repos.Accounts, input.FromAccountID)
+		if err != nil {
+			return err
+		}
+		to, err := resolveAccount(repos.Accounts, input.ToAccountID)
+		if err != nil {
+			return err
+		}
+
+		description := strings.TrimSpace(input.Description)
+		if description == "" {
+			description = fmt.Sprintf("%s → %s", from.Name(), to.Name())
+		}
+
+		outgoing, incoming := newTransfer(from, to, money, description, input.Date)
+
+		for _, leg := range []transaction.Transaction{outgoing, incoming} {
+			if err := ensureOpenPeriod(repos.Reconciliations, leg); err != nil {
+				return err
+			}
+		}
+
+		if err := repos.Transactions.Save(outgoing); err != nil {
+			return fmt.Errorf("failed to save transfer: %w", err)
+		}
+		if err := repos.Transactions.Save(incoming); err != nil {
+			return fmt.Errorf("failed to save transfer: %w", err)
+		}
+
+		output.From = from
+		output.To = to
+		output.Outgoing = outgoing
+		output.Incoming = incoming
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// newTransfer builds the linked pair of entries that moves money from one
+// account to another
+func newTransfer(
+	from account.Account,
+	to account.Account,
+	money shared.Money,
+	description string,
+	date time.Time,
+) (outgoing transaction.Transaction, incoming transaction.Transaction) {
+	transferID := uuid.New().String()
+
+	outgoing = transaction.NewTransaction(
+		uuid.New().String(),
+		money,
+		shared.CategoryTransferOut,
+		description,
+		transaction.TransactionTypeTransferOut,
+		date,
+	).WithAccountID(from.ID()).WithTransferID(transferID)
+
+	incoming = transaction.NewTransaction(
+		uuid.New().String(),
+		money,
+		shared.CategoryTransferIn,
+		description,
+		transaction.TransactionTypeTransferIn,
+		date,
+	).WithAccountID(to.ID()).WithTransferID(transferID)
+
+	return outgoing, incoming
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// UndoLoanPaymentUseCase removes a single repayment and its expense, then
+// recomputes the loan status and paid back date from the remaining history
+type UndoLoanPaymentUseCase struct {
+	uow UnitOfWork
+}
+
+func NewUndoLoanPaymentUseCase(uow UnitOfWork) *UndoLoanPaymentUseCase {
+	return &UndoLoanPaymentUseCase{
+		uow: uow,
+	}
+}
+
+type UndoLoanPaymentInput struct {
+	PaymentID string
+}
+
+type UndoLoanPaymentOutput struct {
+	Loan    loan.Loan
+	Payment loan.Payment
+}
+
+func (uc *UndoLoanPaymentUseCase) Execute(input UndoLoanPaymentInput) (*UndoLoanPaymentOutput, error) {
+	// Validate input
+	if input.PaymentID == "" {
+		return nil, fmt.Errorf("payment ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	output := &UndoLoanPaymentOutput{}
+
+	err := uc.uow.Do(func(repos Repositories) error {
+		payment, err := repos.LoanPayments.FindByID(input.PaymentID)
+		if err != nil {
+			return fmt.Errorf("failed to find loan payment: %w", err)
+		}
+
+		loanObj, err := repos.Loans.FindByID(payment.LoanID())
+		if err != nil {
+			return fmt.Errorf("failed to find loan: %w", err)
+		}
+
+		updatedLoan, err := undoPayment(repos, loanObj, payment)
+		if err != nil {
+			return err
+		}
+
+		output.Loan = updatedLoan
+		output.Payment = payment
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return output, nil
+}
+
+// undoPayment deletes a payment together with the expense recorded for it
+func undoPayment(repos Repositories, loanObj loan.Loan, payment loan.Payment) (loan.Loan, error) {
+	if err := repos.LoanPayments.Delete(payment.ID()); err != nil {
+		return loan.Loan{}, fmt.Errorf("failed to delete loan payment: %w", err)
+	}
+
+	if payment.TransactionID() != "" {
+		tx, err := repos.Transactions.FindByID(payment.TransactionID())
+		if err != nil {
+			return loan.Loan{}, fmt.Errorf("failed to find payment transaction: %w", err)
+		}
+		if err := ensureUnlocked(tx); err != nil {
+			return loan.Loan{}, err
+		}
+		if err := deleteTransaction(repos, payment.TransactionID()); err != nil {
+			return loan.Loan{}, fmt.Errorf("failed to delete payment transaction: %w", err)
+		}
+	}
+
+	return recomputeLoan(repos, loanObj)
+}
+
+// recomputeLoan refreshes the amount paid, status and paid back date of a loan
+// from its stored payments and saves it
+func recomputeLoan(repos Repositories, loanObj loan.Loan) (loan.Loan, error) {
+	payments, err := repos.LoanPayments.FindByLoanID(loanObj.ID())
+	if err != nil {
+		return loan.Loan{}, fmt.Errorf("failed to get loan payments: %w", err)
+	}
+
+	updatedLoan, err := loanObj.WithPayments(payments)
+	if err != nil {
+		return loan.Loan{}, fmt.Errorf("failed to recompute loan: %w", err)
+	}
+
+	if err := repos.Loans.Update(updatedLoan); err != nil {
+		return loan.Loan{}, fmt.Errorf("failed to update loan: %w", err)
+	}
+
+	return updatedLoan, nil
+}
@@ -0,0 +1,40 @@
+package application
+
+import (
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/domain/budget"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/domain/exchange_rate"
+	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
+	"github.com/aymaneelmaini/moka/internal/domain/goal"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/domain/recurring"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+)
+
+// Repositories gives access to every repository taking part in a unit of work
+type Repositories struct {
+	Transactions         transaction.Repository
+	Budgets              budget.Repository
+	FixedCharges         fixed_charge.Repository
+	Loans                loan.Repository
+	LoanPayments         loan.PaymentRepository
+	LoanInstallments     loan.InstallmentRepository
+	RecurringRules       recurring.Repository
+	RecurringOccurrences recurring.OccurrenceRepository
+	Accounts             account.Repository
+	Reconciliations      account.ReconciliationRepository
+	Goals                goal.Repository
+	Categories           category.Repository
+	CategoryRules        categorization.Repository
+	ExchangeRates        exchange_rate.Repository
+	Dismissals           transaction.DismissalRepository
+	StatementAccounts    account.StatementAccountRepository
+}
+
+// UnitOfWork runs fn atomically (port): everything written through the given
+// repositories is committed when fn returns nil and rolled back otherwise
+type UnitOfWork interface {
+	Do(fn func(repos Repositories) error) error
+}
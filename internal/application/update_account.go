@@ -0,0 +1,58 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// UpdateAccountUseCase renames an account or changes its type. Transactions
+// refer to accounts by ID, so they follow.
+type UpdateAccountUseCase struct {
+	accountRepo account.Repository
+}
+
+func NewUpdateAccountUseCase(accountRepo account.Repository) *UpdateAccountUseCase {
+	return &UpdateAccountUseCase{
+		accountRepo: accountRepo,
+	}
+}
+
+type UpdateAccountInput struct {
+	AccountID string
+	Name      string
+	Type      account.AccountType
+}
+
+type UpdateAccountOutput struct {
+	Account account.Account
+}
+
+func (uc *UpdateAccountUseCase) Execute(input UpdateAccountInput) (*UpdateAccountOutput, error) {
+	// Validate input
+	if input.AccountID == "" {
+		return nil, fmt.Errorf("account ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	accountObj, err := uc.accountRepo.FindByID(input.AccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find account: %w", err)
+	}
+
+	updated, err := accountObj.WithDetails(input.Name, input.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkAccountNameFree(uc.accountRepo, updated.Name(), updated.ID()); err != nil {
+		return nil, err
+	}
+
+	if err := uc.accountRepo.Update(updated); err != nil {
+		return nil, fmt.Errorf("failed to update account: %w", err)
+	}
+
+	return &UpdateAccountOutput{
+		Account: updated,
+	}, nil
+}
@@ -0,0 +1,53 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/budget"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+type UpdateBudgetUseCase struct {
+	budgetRepo budget.Repository
+}
+
+func NewUpdateBudgetUseCase(budgetRepo budget.Repository) *UpdateBudgetUseCase {
+	return &UpdateBudgetUseCase{
+		budgetRepo: budgetRepo,
+	}
+}
+
+type UpdateBudgetInput struct {
+	BudgetID string
+	Limit    string
+}
+
+type UpdateBudgetOutput struct {
+	Budget budget.Budget
+}
+
+func (uc *UpdateBudgetUseCase) Execute(input UpdateBudgetInput) (*UpdateBudgetOutput, error) {
+	// Validate input
+	if input.BudgetID == "" {
+		return nil, fmt.Errorf("budget ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	budgetObj, err := uc.budgetRepo.FindByID(input.BudgetID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find budget: %w", err)
+	}
+
+	limit, err := shared.ParseMoney(input.Limit, budgetObj.Limit().Currency())
+	if err != nil {
+		return nil, fmt.Errorf("invalid budget limit: %w", err)
+	}
+
+	updated := budgetObj.WithLimit(limit)
+
+	if err := uc.budgetRepo.Update(updated); err != nil {
+		return nil, fmt.Errorf("failed to update budget: %w", err)
+	}
+
+	return &UpdateBudgetOutput{
+		Budget: updated,
+	}, nil
+}
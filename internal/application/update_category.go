@@ -0,0 +1,56 @@
+package application
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// UpdateCategoryUseCase changes how a category looks. The name is left alone
+// because transactions and budgets refer to categories by name.
+type UpdateCategoryUseCase struct {
+	categoryRepo category.Repository
+}
+
+func NewUpdateCategoryUseCase(categoryRepo category.Repository) *UpdateCategoryUseCase {
+	return &UpdateCategoryUseCase{
+		categoryRepo: categoryRepo,
+	}
+}
+
+type UpdateCategoryInput struct {
+	CategoryID string
+	Color      string
+	Icon       string
+}
+
+type UpdateCategoryOutput struct {
+	Category category.Category
+}
+
+func (uc *UpdateCategoryUseCase) Execute(input UpdateCategoryInput) (*UpdateCategoryOutput, error) {
+	// Validate input
+	if input.CategoryID == "" {
+		return nil, fmt.Errorf("category ID cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	color, icon, err := validateAppearance(input.Color, input.Icon)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryObj, err := uc.categoryRepo.FindByID(input.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find category: %w", err)
+	}
+
+	updated := categoryObj.WithAppearance(color, icon)
+
+	if err := uc.categoryRepo.Update(updated); err != nil {
+		return nil, fmt.Errorf("failed to update category: %w", err)
+	}
+
+	return &UpdateCategoryOutput{
+		Category: updated,
+	}, nil
+}
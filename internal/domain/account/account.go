@@ -0,0 +1,88 @@
+package account
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+)
+
+// DefaultAccountID is the account that receives transactions recorded without
+// one, including every transaction made before accounts existed
+const DefaultAccountID = "default"
+
+type AccountType string
+
+const (
+	AccountTypeCash         AccountType = "cash"
+	AccountTypeBankCard     AccountType = "bank_card"
+	AccountTypeSavings      AccountType = "savings"
+	AccountTypeMobileWallet AccountType = "mobile_wallet"
+)
+
+// AccountTypes lists the account types offered in the UI
+var AccountTypes = []AccountType{
+	AccountTypeCash,
+	AccountTypeBankCard,
+	AccountTypeSavings,
+	AccountTypeMobileWallet,
+}
+
+// Label returns the type as shown to the user
+func (t AccountType) Label() string {
+	switch t {
+	case AccountTypeBankCard:
+		return "Bank card"
+	case AccountTypeSavings:
+		return "Savings"
+	case AccountTypeMobileWallet:
+		return "Mobile wallet"
+	default:
+		return "Cash"
+	}
+}
+
+// Account is a place where money is kept, e.g. a wallet or a bank card
+type Account struct {
+	id   string
+	name string
+	typ  AccountType
+}
+
+func NewAccount(id string, name string, typ AccountType) (Account, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Account{}, fmt.Errorf("account name cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	if !isValidType(typ) {
+		return Account{}, fmt.Errorf("unknown account type %q: %w", typ, shared.ErrInvalidInput)
+	}
+
+	return Account{
+		id:   id,
+		name: name,
+		typ:  typ,
+	}, nil
+}
+
+func (a Account) ID() string        { return a.id }
+func (a Account) Name() string      { return a.name }
+func (a Account) Type() AccountType { return a.typ }
+
+func (a Account) IsDefault() bool {
+	return a.id == DefaultAccountID
+}
+
+// WithDetails returns a copy of the account renamed and retyped
+func (a Account) WithDetails(name string, typ AccountType) (Account, error) {
+	return NewAccount(a.id, name, typ)
+}
+
+func isValidType(typ AccountType) bool {
+	for _, t := range AccountTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
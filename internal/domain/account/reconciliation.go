@@ -0,0 +1,56 @@
+package account
+
+import (
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+// Reconciliation records that an account agreed with the bank statement on
+// a date, possibly after posting an adjustment for the difference
+type Reconciliation struct {
+	id                      string
+	accountID               string
+	statementDate           time.Time
+	statementBalance        shared.Money
+	computedBalance         shared.Money // before any adjustment
+	adjustmentTransactionID string
+	createdAt               time.Time
+}
+
+func NewReconciliation(
+	id string,
+	accountID string,
+	statementDate time.Time,
+	statementBalance shared.Money,
+	computedBalance shared.Money,
+	adjustmentTransactionID string,
+	createdAt time.Time,
+) Reconciliation {
+	return Reconciliation{
+		id:                      id,
+		accountID:               accountID,
+		statementDate:           statementDate,
+		statementBalance:        statementBalance,
+		computedBalance:         computedBalance,
+		adjustmentTransactionID: adjustmentTransactionID,
+		createdAt:               createdAt,
+	}
+}
+
+func (r Reconciliation) ID() string                      { return r.id }
+func (r Reconciliation) AccountID() string               { return r.accountID }
+func (r Reconciliation) StatementDate() time.Time        { return r.statementDate }
+func (r Reconciliation) StatementBalance() shared.Money  { return r.statementBalance }
+func (r Reconciliation) ComputedBalance() shared.Money   { return r.computedBalance }
+func (r Reconciliation) AdjustmentTransactionID() string { return r.adjustmentTransactionID }
+func (r Reconciliation) CreatedAt() time.Time            { return r.createdAt }
+
+// Difference is what the bank holds beyond what the transactions add up to;
+// negative when money is missing
+func (r Reconciliation) Difference() (shared.Money, error) {
+	return r.statementBalance.Subtract(r.computedBalance)
+}
+
+func (r Reconciliation) WasAdjusted() bool {
+	return r.adjustmentTransactionID != ""
+}
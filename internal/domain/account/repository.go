@@ -0,0 +1,32 @@
+package account
+
+// Repository defines the interface for account persistence (port)
+type Repository interface {
+	Save(a Account) error
+	FindByID(id string) (Account, error)
+	// FindByName matches names case-insensitively
+	FindByName(name string) (Account, error)
+	// FindAll returns the default account first, then the others by name
+	FindAll() ([]Account, error)
+	Update(a Account) error
+}
+
+// ReconciliationRepository defines the interface for reconciliation
+// persistence (port)
+type ReconciliationRepository interface {
+	Save(r Reconciliation) error
+	// FindLatest returns the most recent reconciliation of every account that
+	// has one
+	FindLatest() ([]Reconciliation, error)
+	// FindAll returns every reconciliation, in the order they were made
+	FindAll() ([]Reconciliation, error)
+}
+
+// StatementAccountRepository remembers which account each bank account found
+// in a statement file is imported into (port)
+type StatementAccountRepository interface {
+	// Save links the statement account to its account, replacing any
+	// previous link
+	Save(s StatementAccount) error
+	FindAll() ([]StatementAccount, error)
+}
@@ -0,0 +1,33 @@
+package account
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+)
+
+// StatementAccount links the number a bank gives an account in its statement
+// files to the account it is imported into
+type StatementAccount struct {
+	number    string
+	accountID string
+}
+
+func NewStatementAccount(number string, accountID string) (StatementAccount, error) {
+	number = strings.TrimSpace(number)
+	if number == "" {
+		return StatementAccount{}, fmt.Errorf("statement account number cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	if accountID == "" {
+		accountID = DefaultAccountID
+	}
+
+	return StatementAccount{
+		number:    number,
+		accountID: accountID,
+	}, nil
+}
+
+func (s StatementAccount) Number() string    { return s.number }
+func (s StatementAccount) AccountID() string { return s.accountID }
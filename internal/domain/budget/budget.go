@@ -35,11 +35,15 @@ func (b Budget) Limit() shared.Money       { return b.limit }
 func (b Budget) Month() time.Month         { return b.month }
 func (b Budget) Year() int                 { return b.year }
 
-func (b Budget) IsExceeded(spent shared.Money) bool {
+// IsExceeded reports whether spent goes over the limit; spent must be in the
+// budget currency
+func (b Budget) IsExceeded(spent shared.Money) (bool, error) {
 	return spent.GreaterThan(b.limit)
 }
 
-func (b Budget) RemainingAmount(spent shared.Money) shared.Money {
+// RemainingAmount returns what is left of the limit; spent must be in the
+// budget currency
+func (b Budget) RemainingAmount(spent shared.Money) (shared.Money, error) {
 	return b.limit.Subtract(spent)
 }
 
@@ -48,5 +52,15 @@ func (b Budget) PercentageUsed(spent shared.Money) float64 {
 		return 0
 	}
 
-	return (spent.Amount() / b.limit.Amount()) * 100
+	return float64(spent.MinorUnits()) / float64(b.limit.MinorUnits()) * 100
+}
+
+func (b Budget) WithLimit(limit shared.Money) Budget {
+	return Budget{
+		id:       b.id,
+		category: b.category,
+		limit:    limit,
+		month:    b.month,
+		year:     b.year,
+	}
 }
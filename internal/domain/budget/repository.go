@@ -6,6 +6,8 @@ import "time"
 type Repository interface {
 	Save(b Budget) error
 	FindByID(id string) (Budget, error)
+	// FindAll returns every budget, oldest month first
+	FindAll() ([]Budget, error)
 	FindByMonthAndYear(month time.Month, year int) ([]Budget, error)
 	FindByCategoryAndMonth(categoryName string, month time.Month, year int) (Budget, error)
 	Delete(id string) error
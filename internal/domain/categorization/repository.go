@@ -0,0 +1,11 @@
+package categorization
+
+// Repository defines the interface for categorization rule persistence (port)
+type Repository interface {
+	Save(r Rule) error
+	FindByID(id string) (Rule, error)
+	// FindAll returns the rules in the order they are evaluated
+	FindAll() ([]Rule, error)
+	Update(r Rule) error
+	Delete(id string) error
+}
@@ -0,0 +1,174 @@
+package categorization
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Rule puts the transactions it matches in its category. Every condition
+// that is set must hold; a rule has at least one. Only transactions of the
+// category's type match, so an expense rule never touches income.
+type Rule struct {
+	id       string
+	priority int // lower runs first
+	// contains matches descriptions holding it, whatever the case
+	contains string
+	// pattern is a regular expression matched against the description
+	pattern string
+	// counterparty matches descriptions that start with it as whole words,
+	// whatever the case, the way statements lead with the payee
+	counterparty string
+	// minAmount and maxAmount bound the amount, both inclusive. Transactions
+	// in another currency do not match a rule with bounds.
+	minAmount *shared.Money
+	maxAmount *shared.Money
+	category  shared.Category
+	createdAt time.Time
+
+	compiled *regexp.Regexp
+}
+
+func NewRule(
+	id string,
+	priority int,
+	contains string,
+	pattern string,
+	counterparty string,
+	minAmount *shared.Money,
+	maxAmount *shared.Money,
+	category shared.Category,
+	createdAt time.Time,
+) (Rule, error) {
+	contains = strings.TrimSpace(contains)
+	pattern = strings.TrimSpace(pattern)
+	counterparty = strings.TrimSpace(counterparty)
+
+	if contains == "" && pattern == "" && counterparty == "" && minAmount == nil && maxAmount == nil {
+		return Rule{}, fmt.Errorf("a rule needs at least one condition: %w", shared.ErrInvalidInput)
+	}
+
+	if priority < 1 {
+		return Rule{}, fmt.Errorf("priority must be at least 1: %w", shared.ErrInvalidInput)
+	}
+
+	if category.Name() == "" {
+		return Rule{}, fmt.Errorf("category cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	var compiled *regexp.Regexp
+	if pattern != "" {
+		var err error
+		compiled, err = regexp.Compile(pattern)
+		if err != nil {
+			return Rule{}, fmt.Errorf("invalid pattern %q: %v: %w", pattern, err, shared.ErrInvalidInput)
+		}
+	}
+
+	if minAmount != nil && maxAmount != nil {
+		if minAmount.Currency() != maxAmount.Currency() {
+			return Rule{}, fmt.Errorf("amount bounds must be in the same currency: %w", shared.ErrInvalidInput)
+		}
+		if minAmount.MinorUnits() > maxAmount.MinorUnits() {
+			return Rule{}, fmt.Errorf("minimum amount cannot exceed the maximum: %w", shared.ErrInvalidInput)
+		}
+	}
+
+	return Rule{
+		id:           id,
+		priority:     priority,
+		contains:     contains,
+		pattern:      pattern,
+		counterparty: counterparty,
+		minAmount:    minAmount,
+		maxAmount:    maxAmount,
+		category:     category,
+		createdAt:    createdAt,
+		compiled:     compiled,
+	}, nil
+}
+
+func (r Rule) ID() string                { return r.id }
+func (r Rule) Priority() int             { return r.priority }
+func (r Rule) Contains() string          { return r.contains }
+func (r Rule) Pattern() string           { return r.pattern }
+func (r Rule) Counterparty() string      { return r.counterparty }
+func (r Rule) MinAmount() *shared.Money  { return r.minAmount }
+func (r Rule) MaxAmount() *shared.Money  { return r.maxAmount }
+func (r Rule) Category() shared.Category { return r.category }
+func (r Rule) CreatedAt() time.Time      { return r.createdAt }
+
+// WithPriority returns a copy of the rule evaluated at another rank
+func (r Rule) WithPriority(priority int) (Rule, error) {
+	if priority < 1 {
+		return Rule{}, fmt.Errorf("priority must be at least 1: %w", shared.ErrInvalidInput)
+	}
+
+	moved := r
+	moved.priority = priority
+	return moved, nil
+}
+
+// Matches reports whether the rule applies to the transaction
+func (r Rule) Matches(tx transaction.Transaction) bool {
+	switch {
+	case tx.IsExpense():
+		if !r.category.IsExpense() {
+			return false
+		}
+	case tx.IsIncome():
+		if !r.category.IsIncome() {
+			return false
+		}
+	default:
+		return false
+	}
+
+	description := tx.Description()
+
+	if r.contains != "" && !strings.Contains(strings.ToLower(description), strings.ToLower(r.contains)) {
+		return false
+	}
+
+	if r.compiled != nil && !r.compiled.MatchString(description) {
+		return false
+	}
+
+	if r.counterparty != "" && !startsWithWords(description, r.counterparty) {
+		return false
+	}
+
+	amount := tx.Amount()
+	if r.minAmount != nil {
+		if amount.Currency() != r.minAmount.Currency() || amount.MinorUnits() < r.minAmount.MinorUnits() {
+			return false
+		}
+	}
+	if r.maxAmount != nil {
+		if amount.Currency() != r.maxAmount.Currency() || amount.MinorUnits() > r.maxAmount.MinorUnits() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// startsWithWords reports whether s starts with prefix, whatever the case,
+// and the prefix ends on a word boundary
+func startsWithWords(s, prefix string) bool {
+	if len(s) < len(prefix) || !strings.EqualFold(s[:len(prefix)], prefix) {
+		return false
+	}
+
+	rest := s[len(prefix):]
+	if rest == "" {
+		return true
+	}
+
+	next := []rune(rest)[0]
+	return !unicode.IsLetter(next) && !unicode.IsDigit(next)
+}
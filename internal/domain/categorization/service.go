@@ -0,0 +1,44 @@
+package categorization
+
+import (
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"sort"
+)
+
+// SortByPriority orders rules the way they are evaluated, the oldest first
+// among rules of equal priority
+func SortByPriority(rules []Rule) {
+	sort.SliceStable(rules, func(i, j int) bool {
+		if rules[i].priority != rules[j].priority {
+			return rules[i].priority < rules[j].priority
+		}
+		return rules[i].createdAt.Before(rules[j].createdAt)
+	})
+}
+
+// Categorize returns the first rule, in priority order, that matches the
+// transaction
+func Categorize(rules []Rule, tx transaction.Transaction) (Rule, bool) {
+	sorted := make([]Rule, len(rules))
+	copy(sorted, rules)
+	SortByPriority(sorted)
+
+	for _, rule := range sorted {
+		if rule.Matches(tx) {
+			return rule, true
+		}
+	}
+
+	return Rule{}, false
+}
+
+// NextPriority returns the priority that puts a new rule after all others
+func NextPriority(rules []Rule) int {
+	next := 1
+	for _, rule := range rules {
+		if rule.priority >= next {
+			next = rule.priority + 1
+		}
+	}
+	return next
+}
@@ -0,0 +1,97 @@
+package category
+
+import (
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+type Category struct {
+	id         string
+	name       string
+	typ        shared.CategoryType
+	parentID   string
+	color      string
+	icon       string
+	isArchived bool
+	isBuiltin  bool
+}
+
+func NewCategory(
+	id string,
+	name string,
+	typ shared.CategoryType,
+	parentID string,
+	color string,
+	icon string,
+	isArchived bool,
+	isBuiltin bool,
+) Category {
+	return Category{
+		id:         id,
+		name:       name,
+		typ:        typ,
+		parentID:   parentID,
+		color:      color,
+		icon:       icon,
+		isArchived: isArchived,
+		isBuiltin:  isBuiltin,
+	}
+}
+
+func (c Category) ID() string                { return c.id }
+func (c Category) Name() string              { return c.name }
+func (c Category) Type() shared.CategoryType { return c.typ }
+func (c Category) ParentID() string          { return c.parentID }
+func (c Category) Color() string             { return c.color }
+func (c Category) Icon() string              { return c.icon }
+func (c Category) IsArchived() bool          { return c.isArchived }
+func (c Category) IsBuiltin() bool           { return c.isBuiltin }
+
+// IsSubcategory reports whether the category sits under a parent
+func (c Category) IsSubcategory() bool {
+	return c.parentID != ""
+}
+
+// Value returns the category as the value object carried by transactions
+func (c Category) Value() shared.Category {
+	value, _ := shared.NewCategory(c.name, c.typ)
+	return value
+}
+
+func (c Category) WithAppearance(color string, icon string) Category {
+	return Category{
+		id:         c.id,
+		name:       c.name,
+		typ:        c.typ,
+		parentID:   c.parentID,
+		color:      color,
+		icon:       icon,
+		isArchived: c.isArchived,
+		isBuiltin:  c.isBuiltin,
+	}
+}
+
+func (c Category) Archive() Category {
+	return Category{
+		id:         c.id,
+		name:       c.name,
+		typ:        c.typ,
+		parentID:   c.parentID,
+		color:      c.color,
+		icon:       c.icon,
+		isArchived: true,
+		isBuiltin:  c.isBuiltin,
+	}
+}
+
+func (c Category) Unarchive() Category {
+	return Category{
+		id:         c.id,
+		name:       c.name,
+		typ:        c.typ,
+		parentID:   c.parentID,
+		color:      c.color,
+		icon:       c.icon,
+		isArchived: false,
+		isBuiltin:  c.isBuiltin,
+	}
+}
@@ -0,0 +1,15 @@
+package category
+
+import "github.com/aymaneelmaini/moka/internal/shared"
+
+// Repository defines the interface for category persistence (port)
+type Repository interface {
+	Save(c Category) error
+	FindByID(id string) (Category, error)
+	// FindByName matches names case-insensitively
+	FindByName(name string) (Category, error)
+	FindAll() ([]Category, error)
+	FindByType(typ shared.CategoryType) ([]Category, error)
+	Update(c Category) error
+	Delete(id string) error
+}
@@ -0,0 +1,65 @@
+package category
+
+// FilterActive returns only categories that are not archived (pure function)
+func FilterActive(categories []Category) []Category {
+	var active []Category
+
+	for _, c := range categories {
+		if !c.IsArchived() {
+			active = append(active, c)
+		}
+	}
+
+	return active
+}
+
+// ParentNames maps each sub-category name to the name of its parent (pure function)
+func ParentNames(categories []Category) map[string]string {
+	names := make(map[string]string)
+	for _, c := range categories {
+		names[c.ID()] = c.Name()
+	}
+
+	parents := make(map[string]string)
+	for _, c := range categories {
+		if parentName, exists := names[c.ParentID()]; exists && c.IsSubcategory() {
+			parents[c.Name()] = parentName
+		}
+	}
+
+	return parents
+}
+
+// ChildrenOf returns the direct sub-categories of a category (pure function)
+func ChildrenOf(categories []Category, parentID string) []Category {
+	var children []Category
+
+	for _, c := range categories {
+		if c.ParentID() == parentID {
+			children = append(children, c)
+		}
+	}
+
+	return children
+}
+
+// SortAsTree orders categories so that each top-level category is followed
+// by its sub-categories, keeping the incoming order within each level (pure function)
+func SortAsTree(categories []Category) []Category {
+	known := make(map[string]bool)
+	for _, c := range categories {
+		known[c.ID()] = true
+	}
+
+	sorted := make([]Category, 0, len(categories))
+	for _, c := range categories {
+		// Sub-categories whose parent is missing are listed as top-level
+		if c.IsSubcategory() && known[c.ParentID()] {
+			continue
+		}
+		sorted = append(sorted, c)
+		sorted = append(sorted, ChildrenOf(categories, c.ID())...)
+	}
+
+	return sorted
+}
@@ -0,0 +1,47 @@
+package exchange_rate
+
+import (
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+// Rate says how many units of the quote currency one unit of the currency
+// buys, starting on effectiveOn and until a more recent rate takes over
+type Rate struct {
+	id            string
+	currency      string
+	quoteCurrency string
+	rate          shared.ConversionRate
+	effectiveOn   time.Time
+}
+
+func NewRate(
+	id string,
+	currency string,
+	quoteCurrency string,
+	rate shared.ConversionRate,
+	effectiveOn time.Time,
+) Rate {
+	year, month, day := effectiveOn.Date()
+
+	return Rate{
+		id:            id,
+		currency:      currency,
+		quoteCurrency: quoteCurrency,
+		rate:          rate,
+		effectiveOn:   time.Date(year, month, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (r Rate) ID() string                  { return r.id }
+func (r Rate) Currency() string            { return r.currency }
+func (r Rate) QuoteCurrency() string       { return r.quoteCurrency }
+func (r Rate) Rate() shared.ConversionRate { return r.rate }
+func (r Rate) EffectiveOn() time.Time      { return r.effectiveOn }
+
+// IsEffectiveAt reports whether the rate had already started on the given date
+func (r Rate) IsEffectiveAt(at time.Time) bool {
+	year, month, day := at.Date()
+	date := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return !r.effectiveOn.After(date)
+}
@@ -0,0 +1,14 @@
+package exchange_rate
+
+import "time"
+
+// Repository defines the interface for exchange rate persistence (port)
+type Repository interface {
+	// Save stores the rate, replacing any rate of the same pair and date
+	Save(r Rate) error
+	FindByID(id string) (Rate, error)
+	FindAll() ([]Rate, error)
+	// FindEffective returns the most recent rate of the pair effective on date
+	FindEffective(currency, quoteCurrency string, date time.Time) (Rate, error)
+	Delete(id string) error
+}
@@ -1,7 +1,9 @@
 package fixed_charge
 
 import (
+	"fmt"
 	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
 )
 
 type FixedCharge struct {
@@ -10,6 +12,9 @@ type FixedCharge struct {
 	amount      shared.Money
 	description string
 	isActive    bool
+	startMonth  *time.Time // first month charged, nil for no start
+	endMonth    *time.Time // last month charged, nil for no end
+	dueDay      int        // day of month it is due; 0 follows the salary date
 }
 
 func NewFixedCharge(
@@ -28,28 +33,98 @@ func NewFixedCharge(
 	}
 }
 
-func (f FixedCharge) ID() string           { return f.id }
-func (f FixedCharge) Name() string         { return f.name }
-func (f FixedCharge) Amount() shared.Money { return f.amount }
-func (f FixedCharge) Description() string  { return f.description }
-func (f FixedCharge) IsActive() bool       { return f.isActive }
+func (f FixedCharge) ID() string             { return f.id }
+func (f FixedCharge) Name() string           { return f.name }
+func (f FixedCharge) Amount() shared.Money   { return f.amount }
+func (f FixedCharge) Description() string    { return f.description }
+func (f FixedCharge) IsActive() bool         { return f.isActive }
+func (f FixedCharge) StartMonth() *time.Time { return f.startMonth }
+func (f FixedCharge) EndMonth() *time.Time   { return f.endMonth }
+func (f FixedCharge) DueDay() int            { return f.dueDay }
 
 func (f FixedCharge) Deactivate() FixedCharge {
-	return FixedCharge{
-		id:          f.id,
-		name:        f.name,
-		amount:      f.amount,
-		description: f.description,
-		isActive:    false,
-	}
+	deactivated := f
+	deactivated.isActive = false
+	return deactivated
 }
 
 func (f FixedCharge) Activate() FixedCharge {
-	return FixedCharge{
-		id:          f.id,
-		name:        f.name,
-		amount:      f.amount,
-		description: f.description,
-		isActive:    true,
+	activated := f
+	activated.isActive = true
+	return activated
+}
+
+// WithDetails returns a copy of the charge with a corrected name, amount and
+// description
+func (f FixedCharge) WithDetails(name string, amount shared.Money, description string) FixedCharge {
+	edited := f
+	edited.name = name
+	edited.amount = amount
+	edited.description = description
+	return edited
+}
+
+// WithSchedule returns a copy of the charge limited to the months from start
+// to end, both optional and inclusive, and due on the given day of the month
+func (f FixedCharge) WithSchedule(startMonth *time.Time, endMonth *time.Time, dueDay int) (FixedCharge, error) {
+	if dueDay < 0 || dueDay > 31 {
+		return FixedCharge{}, fmt.Errorf("due day must be between 1 and 31: %w", shared.ErrInvalidInput)
+	}
+
+	startMonth = firstOfMonth(startMonth)
+	endMonth = firstOfMonth(endMonth)
+	if startMonth != nil && endMonth != nil && endMonth.Before(*startMonth) {
+		return FixedCharge{}, fmt.Errorf("end month cannot be before the start month: %w", shared.ErrInvalidInput)
+	}
+
+	scheduled := f
+	scheduled.startMonth = startMonth
+	scheduled.endMonth = endMonth
+	scheduled.dueDay = dueDay
+	return scheduled, nil
+}
+
+// AppliesTo reports whether the charge is active and falls within its
+// schedule in the given month
+func (f FixedCharge) AppliesTo(year int, month time.Month) bool {
+	if !f.isActive {
+		return false
+	}
+
+	current := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	if f.startMonth != nil && current.Before(*f.startMonth) {
+		return false
+	}
+	if f.endMonth != nil && current.After(*f.endMonth) {
+		return false
+	}
+
+	return true
+}
+
+// DueDate returns when the charge is due in the month of paidAt. Charges
+// without a due day are due on paidAt itself. Days past the end of the month
+// fall on its last day.
+func (f FixedCharge) DueDate(paidAt time.Time) time.Time {
+	if f.dueDay == 0 {
+		return paidAt
+	}
+
+	first := time.Date(paidAt.Year(), paidAt.Month(), 1, 0, 0, 0, 0, paidAt.Location())
+	lastDay := first.AddDate(0, 1, -1).Day()
+
+	day := f.dueDay
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return first.AddDate(0, 0, day-1)
+}
+
+func firstOfMonth(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
 	}
+	first := time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return &first
 }
@@ -1,18 +1,25 @@
 package fixed_charge
 
-import "github.com/aymaneelmaini/moka/internal/shared"
+import (
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
 
 // CalculateTotalCharges calculates total of all active fixed charges (pure function)
-func CalculateTotalCharges(charges []FixedCharge) shared.Money {
+func CalculateTotalCharges(charges []FixedCharge) (shared.Money, error) {
 	total := shared.Zero()
 
 	for _, charge := range charges {
 		if charge.IsActive() {
-			total = total.Add(charge.Amount())
+			var err error
+			total, err = total.Add(charge.Amount())
+			if err != nil {
+				return shared.Money{}, err
+			}
 		}
 	}
 
-	return total
+	return total, nil
 }
 
 // FilterActive returns only active fixed charges
@@ -27,3 +34,17 @@ func FilterActive(charges []FixedCharge) []FixedCharge {
 
 	return active
 }
+
+// FilterForMonth returns the charges that apply to the given month according
+// to their status and schedule (pure function)
+func FilterForMonth(charges []FixedCharge, year int, month time.Month) []FixedCharge {
+	var applicable []FixedCharge
+
+	for _, charge := range charges {
+		if charge.AppliesTo(year, month) {
+			applicable = append(applicable, charge)
+		}
+	}
+
+	return applicable
+}
@@ -0,0 +1,92 @@
+package goal
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+	"time"
+)
+
+// Goal is an amount to put aside on an account, e.g. a holiday or an
+// emergency fund. Contributions are transfers into its account.
+type Goal struct {
+	id        string
+	name      string
+	target    shared.Money
+	deadline  *time.Time // nil for no deadline
+	accountID string     // where the savings are kept
+	createdAt time.Time
+}
+
+func NewGoal(
+	id string,
+	name string,
+	target shared.Money,
+	deadline *time.Time,
+	accountID string,
+	createdAt time.Time,
+) (Goal, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return Goal{}, fmt.Errorf("goal name cannot be empty: %w", shared.ErrInvalidInput)
+	}
+
+	if !target.IsPositive() {
+		return Goal{}, fmt.Errorf("goal target must be positive: %w", shared.ErrInvalidInput)
+	}
+
+	return Goal{
+		id:        id,
+		name:      name,
+		target:    target,
+		deadline:  deadline,
+		accountID: accountID,
+		createdAt: createdAt,
+	}, nil
+}
+
+func (g Goal) ID() string           { return g.id }
+func (g Goal) Name() string         { return g.name }
+func (g Goal) Target() shared.Money { return g.target }
+func (g Goal) Deadline() *time.Time { return g.deadline }
+func (g Goal) AccountID() string    { return g.accountID }
+func (g Goal) CreatedAt() time.Time { return g.createdAt }
+
+// IsReached reports whether saved covers the target; saved must be in the
+// goal currency
+func (g Goal) IsReached(saved shared.Money) (bool, error) {
+	short, err := saved.LessThan(g.target)
+	if err != nil {
+		return false, err
+	}
+	return !short, nil
+}
+
+// RemainingAmount returns what is still to be saved, never below zero; saved
+// must be in the goal currency
+func (g Goal) RemainingAmount(saved shared.Money) (shared.Money, error) {
+	reached, err := g.IsReached(saved)
+	if err != nil {
+		return shared.Money{}, err
+	}
+	if reached {
+		return shared.ZeroOf(g.target.Currency()), nil
+	}
+	return g.target.Subtract(saved)
+}
+
+// PercentageSaved returns how much of the target is saved, capped at 100;
+// saved must be in the goal currency
+func (g Goal) PercentageSaved(saved shared.Money) (float64, error) {
+	reached, err := g.IsReached(saved)
+	if err != nil {
+		return 0, err
+	}
+	if reached {
+		return 100, nil
+	}
+	if !saved.IsPositive() {
+		return 0, nil
+	}
+	return float64(saved.MinorUnits()) / float64(g.target.MinorUnits()) * 100, nil
+}
@@ -0,0 +1,10 @@
+package goal
+
+// Repository defines the interface for goal persistence (port)
+type Repository interface {
+	Save(g Goal) error
+	FindByID(id string) (Goal, error)
+	// FindAll returns the goals soonest deadline first, those without one last
+	FindAll() ([]Goal, error)
+	Delete(id string) error
+}
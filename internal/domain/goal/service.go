@@ -0,0 +1,26 @@
+package goal
+
+import (
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+// ProjectCompletion estimates when remaining will be saved if monthlySavings
+// keeps being put aside every month from today (pure function). Both amounts
+// must share a currency. It returns nil when nothing is being saved.
+func ProjectCompletion(remaining shared.Money, monthlySavings shared.Money, today time.Time) *time.Time {
+	if !remaining.IsPositive() {
+		return &today
+	}
+	if !monthlySavings.IsPositive() {
+		return nil
+	}
+
+	months := remaining.MinorUnits() / monthlySavings.MinorUnits()
+	if remaining.MinorUnits()%monthlySavings.MinorUnits() != 0 {
+		months++
+	}
+
+	completion := today.AddDate(0, int(months), 0)
+	return &completion
+}
@@ -0,0 +1,31 @@
+package loan
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// ErrLoanNotActive is returned when paying a loan that was already paid back
+// or cancelled
+var ErrLoanNotActive = fmt.Errorf("loan is not active: %w", shared.ErrInvalidInput)
+
+// OverpaymentError reports a payment larger than what remains on a loan. It
+// wraps shared.ErrInvalidInput.
+type OverpaymentError struct {
+	Payment   shared.Money
+	Remaining shared.Money
+}
+
+func (e *OverpaymentError) Error() string {
+	return fmt.Sprintf("payment of %s exceeds the %s remaining on the loan", e.Payment, e.Remaining)
+}
+
+func (e *OverpaymentError) Unwrap() error {
+	return shared.ErrInvalidInput
+}
+
+// Surplus is the part of the payment beyond what remains
+func (e *OverpaymentError) Surplus() shared.Money {
+	surplus, _ := e.Payment.Subtract(e.Remaining)
+	return surplus
+}
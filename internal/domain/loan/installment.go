@@ -0,0 +1,124 @@
+package loan
+
+import (
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+// Installment is one scheduled repayment of a loan
+type Installment struct {
+	id      string
+	loanID  string
+	number  int // 1-based position in the schedule
+	dueDate time.Time
+	amount  shared.Money
+}
+
+func NewInstallment(
+	id string,
+	loanID string,
+	number int,
+	dueDate time.Time,
+	amount shared.Money,
+) Installment {
+	return Installment{
+		id:      id,
+		loanID:  loanID,
+		number:  number,
+		dueDate: dueDate,
+		amount:  amount,
+	}
+}
+
+func (i Installment) ID() string           { return i.id }
+func (i Installment) LoanID() string       { return i.loanID }
+func (i Installment) Number() int          { return i.number }
+func (i Installment) DueDate() time.Time   { return i.dueDate }
+func (i Installment) Amount() shared.Money { return i.amount }
+
+// InstallmentStatus tells whether an installment is covered by the payments
+// made so far, and whether it is late
+type InstallmentStatus struct {
+	Installment Installment
+	Paid        bool
+	Overdue     bool
+}
+
+// GenerateSchedule splits the loan amount into count monthly installments,
+// the first one due on firstDue. Leftover minor units go to the first
+// installments so the schedule adds up exactly (pure function).
+func GenerateSchedule(l Loan, count int, firstDue time.Time, newID func() string) ([]Installment, error) {
+	if count < 1 {
+		return nil, shared.ErrInvalidInput
+	}
+
+	total := l.Amount().MinorUnits()
+	share := total / int64(count)
+	leftover := total % int64(count)
+	if share == 0 {
+		return nil, shared.ErrInvalidInput
+	}
+
+	schedule := make([]Installment, 0, count)
+	for n := 0; n < count; n++ {
+		units := share
+		if int64(n) < leftover {
+			units++
+		}
+
+		schedule = append(schedule, NewInstallment(
+			newID(),
+			l.ID(),
+			n+1,
+			AddMonths(firstDue, n),
+			shared.UnsafeNewMoney(units, l.Amount().Currency()),
+		))
+	}
+
+	return schedule, nil
+}
+
+// ScheduleStatus allocates the amount paid on a loan to its installments in
+// order, and flags the unpaid ones whose due date has passed (pure function)
+func ScheduleStatus(l Loan, installments []Installment, at time.Time) []InstallmentStatus {
+	statuses := make([]InstallmentStatus, 0, len(installments))
+	covered := l.AmountPaid().MinorUnits()
+
+	for _, i := range installments {
+		paid := covered >= i.Amount().MinorUnits()
+		if paid {
+			covered -= i.Amount().MinorUnits()
+		} else {
+			covered = 0
+		}
+
+		statuses = append(statuses, InstallmentStatus{
+			Installment: i,
+			Paid:        paid,
+			Overdue:     !paid && l.IsActive() && isPastDue(i.DueDate(), at),
+		})
+	}
+
+	return statuses
+}
+
+// AddMonths moves t by n calendar months, keeping the day of month where the
+// target month allows it (Jan 31 + 1 month is the last day of February)
+func AddMonths(t time.Time, n int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(n), 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location())
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	day := t.Day()
+	if day > lastDay {
+		day = lastDay
+	}
+
+	return firstOfMonth.AddDate(0, 0, day-1)
+}
+
+// isPastDue reports whether the due day is over, comparing calendar days only
+func isPastDue(due time.Time, at time.Time) bool {
+	dueDay := time.Date(due.Year(), due.Month(), due.Day(), 0, 0, 0, 0, time.UTC)
+	today := time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	return dueDay.Before(today)
+}
@@ -2,6 +2,7 @@ package loan
 
 import (
 	"github.com/aymaneelmaini/moka/internal/shared"
+	"sort"
 	"time"
 )
 
@@ -10,15 +11,29 @@ type LoanStatus string
 const (
 	LoanStatusActive   LoanStatus = "active"
 	LoanStatusPaidBack LoanStatus = "paid_back"
+	// LoanStatusCancelled marks a loan that is no longer owed, e.g. forgiven
+	LoanStatusCancelled LoanStatus = "cancelled"
+)
+
+// LoanDirection tells whether we owe the money or are owed it
+type LoanDirection string
+
+const (
+	// LoanDirectionBorrowed is money we borrowed and have to pay back
+	LoanDirectionBorrowed LoanDirection = "borrowed"
+	// LoanDirectionLent is money we lent and expect to collect (receivable)
+	LoanDirectionLent LoanDirection = "lent"
 )
 
 type Loan struct {
 	id          string
 	lenderName  string          // marouane, younes, hamza, soufiane and and and
+	direction   LoanDirection   // for lent money, lenderName is the borrower
 	amount      shared.Money
 	amountPaid  shared.Money
 	borrowedAt  time.Time
 	paidBackAt  *time.Time
+	dueDate     *time.Time // when the last repayment is due, if agreed
 	status      LoanStatus
 	description string
 }
@@ -33,8 +48,9 @@ func NewLoan(
 	return Loan{
 		id:          id,
 		lenderName:  lenderName,
+		direction:   LoanDirectionBorrowed,
 		amount:      amount,
-		amountPaid:  shared.Zero(),
+		amountPaid:  shared.ZeroOf(amount.Currency()),
 		borrowedAt:  borrowedAt,
 		paidBackAt:  nil,
 		status:      LoanStatusActive,
@@ -42,17 +58,60 @@ func NewLoan(
 	}
 }
 
+// NewReceivable creates a loan of money we lent to someone
+func NewReceivable(
+	id string,
+	borrowerName string,
+	amount shared.Money,
+	lentAt time.Time,
+	description string,
+) Loan {
+	l := NewLoan(id, borrowerName, amount, lentAt, description)
+	l.direction = LoanDirectionLent
+	return l
+}
+
+// RestoreLoan rebuilds a loan from its persisted state
+func RestoreLoan(
+	id string,
+	lenderName string,
+	direction LoanDirection,
+	amount shared.Money,
+	amountPaid shared.Money,
+	borrowedAt time.Time,
+	paidBackAt *time.Time,
+	dueDate *time.Time,
+	status LoanStatus,
+	description string,
+) Loan {
+	return Loan{
+		id:          id,
+		lenderName:  lenderName,
+		direction:   direction,
+		amount:      amount,
+		amountPaid:  amountPaid,
+		borrowedAt:  borrowedAt,
+		paidBackAt:  paidBackAt,
+		dueDate:     dueDate,
+		status:      status,
+		description: description,
+	}
+}
+
 func (l Loan) ID() string            { return l.id }
 func (l Loan) LenderName() string    { return l.lenderName }
+func (l Loan) Direction() LoanDirection { return l.direction }
 func (l Loan) Amount() shared.Money  { return l.amount }
 func (l Loan) AmountPaid() shared.Money { return l.amountPaid }
 func (l Loan) BorrowedAt() time.Time { return l.borrowedAt }
 func (l Loan) PaidBackAt() *time.Time { return l.paidBackAt }
+func (l Loan) DueDate() *time.Time   { return l.dueDate }
 func (l Loan) Status() LoanStatus    { return l.status }
 func (l Loan) Description() string   { return l.description }
 
+// RemainingAmount is always expressed in the loan currency
 func (l Loan) RemainingAmount() shared.Money {
-	return l.amount.Subtract(l.amountPaid)
+	return shared.UnsafeNewMoney(l.amount.MinorUnits()-l.amountPaid.MinorUnits(), l.amount.Currency())
 }
 
 func (l Loan) IsFullyPaid() bool {
@@ -63,26 +122,191 @@ func (l Loan) IsActive() bool {
 	return l.status == LoanStatusActive
 }
 
-func (l Loan) RecordPayment(payment shared.Money, paidAt time.Time) Loan {
-	newAmountPaid := l.amountPaid.Add(payment)
-	remaining := l.amount.Subtract(newAmountPaid)
+// IsReceivable reports whether the money was lent by us rather than borrowed
+func (l Loan) IsReceivable() bool {
+	return l.direction == LoanDirectionLent
+}
+
+// IsOverdue reports whether an active loan is still not paid back after the
+// day it was due
+func (l Loan) IsOverdue(at time.Time) bool {
+	return l.IsActive() && l.dueDate != nil && isPastDue(*l.dueDate, at)
+}
+
+// WithDueDate sets or clears the day the loan has to be paid back by
+func (l Loan) WithDueDate(dueDate *time.Time) Loan {
+	l.dueDate = dueDate
+	return l
+}
+
+func (l Loan) IsCancelled() bool {
+	return l.status == LoanStatusCancelled
+}
+
+// RecordPayment registers a repayment, which must be in the loan currency.
+// Only active loans can be repaid, and never by more than what remains.
+func (l Loan) RecordPayment(payment shared.Money, paidAt time.Time) (Loan, error) {
+	if !l.IsActive() {
+		return Loan{}, ErrLoanNotActive
+	}
+
+	newAmountPaid, err := l.amountPaid.Add(payment)
+	if err != nil {
+		return Loan{}, err
+	}
+
+	overpaid, err := newAmountPaid.GreaterThan(l.amount)
+	if err != nil {
+		return Loan{}, err
+	}
+	if overpaid {
+		return Loan{}, &OverpaymentError{Payment: payment, Remaining: l.RemainingAmount()}
+	}
+
+	return l.withAmountPaid(newAmountPaid, paidAt)
+}
+
+// SplitPayment splits a payment into the part that settles the loan and the
+// surplus beyond what remains, which is zero unless the loan is overpaid.
+// The payment must be in the loan currency.
+func (l Loan) SplitPayment(payment shared.Money) (settled shared.Money, surplus shared.Money, err error) {
+	remaining := l.RemainingAmount()
+	overpaid, err := payment.GreaterThan(remaining)
+	if err != nil {
+		return shared.Money{}, shared.Money{}, err
+	}
+	if !overpaid {
+		return payment, shared.ZeroOf(payment.Currency()), nil
+	}
+
+	excess, err := payment.Subtract(remaining)
+	if err != nil {
+		return shared.Money{}, shared.Money{}, err
+	}
+	return remaining, excess, nil
+}
 
-	newStatus := l.status
+// WithPayments recomputes the amount paid, the status and the paid back date
+// from the full payment history, e.g. after a payment was undone or edited
+func (l Loan) WithPayments(payments []Payment) (Loan, error) {
+	sorted := make([]Payment, len(payments))
+	copy(sorted, payments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PaidAt().Before(sorted[j].PaidAt())
+	})
+
+	recomputed := l.withoutPayments()
+	for _, p := range sorted {
+		amountPaid, err := recomputed.amountPaid.Add(p.Amount())
+		if err != nil {
+			return Loan{}, err
+		}
+
+		recomputed, err = recomputed.withAmountPaid(amountPaid, p.PaidAt())
+		if err != nil {
+			return Loan{}, err
+		}
+	}
+
+	return recomputed, nil
+}
+
+// WithDetails corrects what was borrowed. The amount must stay in the loan
+// currency; call WithPayments afterwards to refresh the status.
+func (l Loan) WithDetails(
+	lenderName string,
+	amount shared.Money,
+	borrowedAt time.Time,
+	description string,
+) (Loan, error) {
+	if amount.Currency() != l.amount.Currency() {
+		return Loan{}, shared.ErrCurrencyMismatch
+	}
+
+	return Loan{
+		id:          l.id,
+		lenderName:  lenderName,
+		direction:   l.direction,
+		amount:      amount,
+		amountPaid:  l.amountPaid,
+		borrowedAt:  borrowedAt,
+		paidBackAt:  l.paidBackAt,
+		dueDate:     l.dueDate,
+		status:      l.status,
+		description: description,
+	}, nil
+}
+
+// Cancel marks an active loan as no longer owed
+func (l Loan) Cancel() (Loan, error) {
+	if !l.IsActive() {
+		return Loan{}, shared.ErrInvalidInput
+	}
+
+	return Loan{
+		id:          l.id,
+		lenderName:  l.lenderName,
+		direction:   l.direction,
+		amount:      l.amount,
+		amountPaid:  l.amountPaid,
+		borrowedAt:  l.borrowedAt,
+		paidBackAt:  l.paidBackAt,
+		dueDate:     l.dueDate,
+		status:      LoanStatusCancelled,
+		description: l.description,
+	}, nil
+}
+
+func (l Loan) withoutPayments() Loan {
+	status := LoanStatusActive
+	if l.IsCancelled() {
+		status = LoanStatusCancelled
+	}
+
+	return Loan{
+		id:          l.id,
+		lenderName:  l.lenderName,
+		direction:   l.direction,
+		amount:      l.amount,
+		amountPaid:  shared.ZeroOf(l.amount.Currency()),
+		borrowedAt:  l.borrowedAt,
+		paidBackAt:  nil,
+		dueDate:     l.dueDate,
+		status:      status,
+		description: l.description,
+	}
+}
+
+// withAmountPaid derives the status from the new amount paid: the loan is paid
+// back at paidAt once nothing remains, and active again otherwise. A
+// cancelled loan stays cancelled.
+func (l Loan) withAmountPaid(amountPaid shared.Money, paidAt time.Time) (Loan, error) {
+	remaining, err := l.amount.Subtract(amountPaid)
+	if err != nil {
+		return Loan{}, err
+	}
+
+	newStatus := LoanStatusActive
 	var newPaidBackAt *time.Time
 
 	if remaining.IsZero() || remaining.IsNegative() {
 		newStatus = LoanStatusPaidBack
 		newPaidBackAt = &paidAt
 	}
+	if l.IsCancelled() {
+		newStatus = LoanStatusCancelled
+	}
 
 	return Loan{
 		id:          l.id,
 		lenderName:  l.lenderName,
+		direction:   l.direction,
 		amount:      l.amount,
-		amountPaid:  newAmountPaid,
+		amountPaid:  amountPaid,
 		borrowedAt:  l.borrowedAt,
 		paidBackAt:  newPaidBackAt,
+		dueDate:     l.dueDate,
 		status:      newStatus,
 		description: l.description,
-	}
+	}, nil
 }
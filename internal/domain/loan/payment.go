@@ -0,0 +1,47 @@
+package loan
+
+import (
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+// Payment is a single repayment of a loan
+type Payment struct {
+	id            string
+	loanID        string
+	transactionID string // the expense recorded for it, if any
+	amount        shared.Money
+	paidAt        time.Time
+}
+
+func NewPayment(
+	id string,
+	loanID string,
+	transactionID string,
+	amount shared.Money,
+	paidAt time.Time,
+) Payment {
+	return Payment{
+		id:            id,
+		loanID:        loanID,
+		transactionID: transactionID,
+		amount:        amount,
+		paidAt:        paidAt,
+	}
+}
+
+func (p Payment) ID() string            { return p.id }
+func (p Payment) LoanID() string        { return p.loanID }
+func (p Payment) TransactionID() string { return p.transactionID }
+func (p Payment) Amount() shared.Money  { return p.amount }
+func (p Payment) PaidAt() time.Time     { return p.paidAt }
+
+func (p Payment) WithAmount(amount shared.Money, paidAt time.Time) Payment {
+	return Payment{
+		id:            p.id,
+		loanID:        p.loanID,
+		transactionID: p.transactionID,
+		amount:        amount,
+		paidAt:        paidAt,
+	}
+}
@@ -10,3 +10,22 @@ type Repository interface {
 	Update(l Loan) error
 	Delete(id string) error
 }
+
+// PaymentRepository defines the interface for loan payment persistence (port)
+type PaymentRepository interface {
+	Save(p Payment) error
+	FindByID(id string) (Payment, error)
+	// FindByLoanID returns the payments of a loan, oldest first
+	FindByLoanID(loanID string) ([]Payment, error)
+	FindByTransactionID(transactionID string) (Payment, error)
+	Update(p Payment) error
+	Delete(id string) error
+}
+
+// InstallmentRepository defines the interface for loan schedule persistence (port)
+type InstallmentRepository interface {
+	Save(i Installment) error
+	// FindByLoanID returns the schedule of a loan, in order
+	FindByLoanID(loanID string) ([]Installment, error)
+	DeleteByLoanID(loanID string) error
+}
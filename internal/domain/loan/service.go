@@ -1,19 +1,35 @@
 package loan
 
-import "github.com/aymaneelmaini/moka/internal/shared"
+import (
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"strings"
+)
 
-// CalculateTotalOwed calculates total amount still owed across all active loans (pure function)
-func CalculateTotalOwed(loans []Loan) shared.Money {
+// CalculateTotalOwed calculates total amount still owed across all active borrowed loans (pure function)
+func CalculateTotalOwed(loans []Loan) (shared.Money, error) {
+	return calculateTotalRemaining(loans, LoanDirectionBorrowed)
+}
+
+// CalculateTotalReceivable calculates total amount still to collect across all active lent loans (pure function)
+func CalculateTotalReceivable(loans []Loan) (shared.Money, error) {
+	return calculateTotalRemaining(loans, LoanDirectionLent)
+}
+
+func calculateTotalRemaining(loans []Loan, direction LoanDirection) (shared.Money, error) {
 	total := shared.Zero()
 
 	for _, loan := range loans {
-		if loan.IsActive() {
+		if loan.IsActive() && loan.Direction() == direction {
 			remaining := loan.RemainingAmount()
-			total = total.Add(remaining)
+			var err error
+			total, err = total.Add(remaining)
+			if err != nil {
+				return shared.Money{}, err
+			}
 		}
 	}
 
-	return total
+	return total, nil
 }
 
 // FilterActive returns only active loans (pure function)
@@ -29,15 +45,44 @@ func FilterActive(loans []Loan) []Loan {
 	return active
 }
 
-// FilterByLender returns loans from a specific lender (pure function)
+// FilterByDirection returns the borrowed or the lent loans (pure function)
+func FilterByDirection(loans []Loan, direction LoanDirection) []Loan {
+	var filtered []Loan
+
+	for _, loan := range loans {
+		if loan.Direction() == direction {
+			filtered = append(filtered, loan)
+		}
+	}
+
+	return filtered
+}
+
+// FilterByLender returns loans with a specific person, whatever the case or
+// spacing of their name (pure function)
 func FilterByLender(loans []Loan, lenderName string) []Loan {
 	var filtered []Loan
 
 	for _, loan := range loans {
-		if loan.LenderName() == lenderName {
+		if SamePerson(loan.LenderName(), lenderName) {
 			filtered = append(filtered, loan)
 		}
 	}
 
 	return filtered
 }
+
+// NormalizeName trims a person's name and collapses inner whitespace (pure function)
+func NormalizeName(name string) string {
+	return strings.Join(strings.Fields(name), " ")
+}
+
+// PersonKey identifies a person regardless of the case or spacing of their name (pure function)
+func PersonKey(name string) string {
+	return strings.ToLower(NormalizeName(name))
+}
+
+// SamePerson reports whether two names refer to the same person (pure function)
+func SamePerson(a, b string) bool {
+	return PersonKey(a) == PersonKey(b)
+}
@@ -0,0 +1,27 @@
+package recurring
+
+import "time"
+
+// Occurrence records that a rule was applied for one due date, so that each
+// due date produces at most one transaction even when the scheduler catches
+// up on missed runs
+type Occurrence struct {
+	id            string
+	ruleID        string
+	dueDate       time.Time
+	transactionID string // empty once the generated transaction is deleted
+}
+
+func NewOccurrence(id string, ruleID string, dueDate time.Time, transactionID string) Occurrence {
+	return Occurrence{
+		id:            id,
+		ruleID:        ruleID,
+		dueDate:       day(dueDate),
+		transactionID: transactionID,
+	}
+}
+
+func (o Occurrence) ID() string            { return o.id }
+func (o Occurrence) RuleID() string        { return o.ruleID }
+func (o Occurrence) DueDate() time.Time    { return o.dueDate }
+func (o Occurrence) TransactionID() string { return o.transactionID }
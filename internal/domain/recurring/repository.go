@@ -0,0 +1,22 @@
+package recurring
+
+// Repository defines the interface for recurring rule persistence (port)
+type Repository interface {
+	Save(r Rule) error
+	FindByID(id string) (Rule, error)
+	FindAll() ([]Rule, error)
+	FindActive() ([]Rule, error)
+	Update(r Rule) error
+	Delete(id string) error
+}
+
+// OccurrenceRepository defines the interface for occurrence persistence (port)
+type OccurrenceRepository interface {
+	Save(o Occurrence) error
+	// FindByRuleID returns the occurrences of a rule, oldest first
+	FindByRuleID(ruleID string) ([]Occurrence, error)
+	DeleteByRuleID(ruleID string) error
+	// UnlinkTransaction forgets a deleted transaction while keeping its
+	// occurrence, so the due date is not generated again
+	UnlinkTransaction(transactionID string) error
+}
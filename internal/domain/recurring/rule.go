@@ -0,0 +1,194 @@
+package recurring
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "daily"
+	FrequencyWeekly  Frequency = "weekly"
+	FrequencyMonthly Frequency = "monthly"
+	FrequencyYearly  Frequency = "yearly"
+)
+
+// Rule describes a transaction that repeats on a schedule, e.g. rent on the
+// 5th of every month or a yearly subscription
+type Rule struct {
+	id          string
+	description string
+	amount      shared.Money
+	category    shared.Category
+	frequency   Frequency
+	interval    int // every N days, weeks, months or years
+	dayOfMonth  int // monthly rules only; 0 keeps the day of the start date
+	startDate   time.Time
+	endDate     *time.Time
+	isActive    bool
+}
+
+func NewRule(
+	id string,
+	description string,
+	amount shared.Money,
+	category shared.Category,
+	frequency Frequency,
+	interval int,
+	dayOfMonth int,
+	startDate time.Time,
+	endDate *time.Time,
+	isActive bool,
+) (Rule, error) {
+	switch frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+	default:
+		return Rule{}, fmt.Errorf("unknown frequency %q: %w", frequency, shared.ErrInvalidInput)
+	}
+
+	if interval < 1 {
+		return Rule{}, fmt.Errorf("interval must be at least 1: %w", shared.ErrInvalidInput)
+	}
+
+	if dayOfMonth < 0 || dayOfMonth > 31 {
+		return Rule{}, fmt.Errorf("day of month must be between 1 and 31: %w", shared.ErrInvalidInput)
+	}
+	if frequency != FrequencyMonthly {
+		dayOfMonth = 0
+	}
+
+	startDate = day(startDate)
+	if endDate != nil {
+		end := day(*endDate)
+		if end.Before(startDate) {
+			return Rule{}, fmt.Errorf("end date cannot be before the start date: %w", shared.ErrInvalidInput)
+		}
+		endDate = &end
+	}
+
+	return Rule{
+		id:          id,
+		description: description,
+		amount:      amount,
+		category:    category,
+		frequency:   frequency,
+		interval:    interval,
+		dayOfMonth:  dayOfMonth,
+		startDate:   startDate,
+		endDate:     endDate,
+		isActive:    isActive,
+	}, nil
+}
+
+func (r Rule) ID() string                { return r.id }
+func (r Rule) Description() string       { return r.description }
+func (r Rule) Amount() shared.Money      { return r.amount }
+func (r Rule) Category() shared.Category { return r.category }
+func (r Rule) Frequency() Frequency      { return r.frequency }
+func (r Rule) Interval() int             { return r.interval }
+func (r Rule) DayOfMonth() int           { return r.dayOfMonth }
+func (r Rule) StartDate() time.Time      { return r.startDate }
+func (r Rule) EndDate() *time.Time       { return r.endDate }
+func (r Rule) IsActive() bool            { return r.isActive }
+
+func (r Rule) IsIncome() bool {
+	return r.category.IsIncome()
+}
+
+func (r Rule) Deactivate() Rule {
+	deactivated := r
+	deactivated.isActive = false
+	return deactivated
+}
+
+func (r Rule) Activate() Rule {
+	activated := r
+	activated.isActive = true
+	return activated
+}
+
+// DueDates returns every due date of the rule from its start up to and
+// including the day of until, oldest first
+func (r Rule) DueDates(until time.Time) []time.Time {
+	var dates []time.Time
+
+	last := day(until)
+	if r.endDate != nil && r.endDate.Before(last) {
+		last = *r.endDate
+	}
+
+	for n := 0; ; n++ {
+		due, ok := r.occurrence(n)
+		if !ok {
+			continue
+		}
+		if due.After(last) {
+			break
+		}
+		dates = append(dates, due)
+	}
+
+	return dates
+}
+
+// NextDueDate returns the first due date on or after the day of at, or nil
+// once the rule has ended
+func (r Rule) NextDueDate(at time.Time) *time.Time {
+	from := day(at)
+
+	for n := 0; ; n++ {
+		due, ok := r.occurrence(n)
+		if !ok {
+			continue
+		}
+		if r.endDate != nil && due.After(*r.endDate) {
+			return nil
+		}
+		if !due.Before(from) {
+			return &due
+		}
+	}
+}
+
+// occurrence returns the n-th candidate due date of the rule. Monthly rules
+// pinned to a day before the start day skip their first month, which is
+// reported with ok false.
+func (r Rule) occurrence(n int) (time.Time, bool) {
+	steps := n * r.interval
+
+	switch r.frequency {
+	case FrequencyDaily:
+		return r.startDate.AddDate(0, 0, steps), true
+	case FrequencyWeekly:
+		return r.startDate.AddDate(0, 0, 7*steps), true
+	case FrequencyYearly:
+		return addMonths(r.startDate, r.startDate.Day(), 12*steps), true
+	default:
+		dayOfMonth := r.dayOfMonth
+		if dayOfMonth == 0 {
+			dayOfMonth = r.startDate.Day()
+		}
+		due := addMonths(r.startDate, dayOfMonth, steps)
+		return due, !due.Before(r.startDate)
+	}
+}
+
+// addMonths moves t forward by n months and sets the day of month, clamped to
+// the last day of the target month (the 31st becomes the 30th in April)
+func addMonths(t time.Time, dayOfMonth int, n int) time.Time {
+	firstOfMonth := time.Date(t.Year(), t.Month()+time.Month(n), 1, 0, 0, 0, 0, time.UTC)
+	lastDay := firstOfMonth.AddDate(0, 1, -1).Day()
+
+	if dayOfMonth > lastDay {
+		dayOfMonth = lastDay
+	}
+
+	return firstOfMonth.AddDate(0, 0, dayOfMonth-1)
+}
+
+// day truncates t to midnight UTC of its calendar day
+func day(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
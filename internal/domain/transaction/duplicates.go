@@ -0,0 +1,160 @@
+package transaction
+
+import (
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DuplicateWindowDays is how many days apart two entries of the same amount
+// may be and still be the same expense entered twice
+const DuplicateWindowDays = 2
+
+// DuplicatePair is two transactions that look like the same one entered
+// twice. Original is the one recorded first.
+type DuplicatePair struct {
+	Original  Transaction
+	Duplicate Transaction
+}
+
+// Dismissal records that two transactions that look alike are both genuine
+type Dismissal struct {
+	transactionID string
+	otherID       string
+}
+
+// NewDismissal stores the pair in a fixed order so that it does not matter
+// which of the two the user dismissed from
+func NewDismissal(transactionID string, otherID string) Dismissal {
+	if otherID < transactionID {
+		transactionID, otherID = otherID, transactionID
+	}
+	return Dismissal{
+		transactionID: transactionID,
+		otherID:       otherID,
+	}
+}
+
+func (d Dismissal) TransactionID() string { return d.transactionID }
+func (d Dismissal) OtherID() string       { return d.otherID }
+
+// Covers reports whether the dismissal is about the two given transactions
+func (d Dismissal) Covers(a string, b string) bool {
+	return NewDismissal(a, b) == d
+}
+
+// CanBeDuplicate reports whether the transaction is an entry the user typed
+// in. Transfers and the entries recorded for loans are kept in step with
+// other records and are never treated as duplicates.
+func (t Transaction) CanBeDuplicate() bool {
+	return (t.IsIncome() || t.IsExpense()) && !t.IsLoanRelated()
+}
+
+// IsPossibleDuplicate reports whether two transactions have the same type
+// and amount, were made at most DuplicateWindowDays apart and have similar
+// descriptions (pure function)
+func IsPossibleDuplicate(a Transaction, b Transaction) bool {
+	if a.id == b.id || !a.CanBeDuplicate() || !b.CanBeDuplicate() {
+		return false
+	}
+	if a.typ != b.typ || a.amount != b.amount {
+		return false
+	}
+	if daysApart(a.createdAt, b.createdAt) > DuplicateWindowDays {
+		return false
+	}
+	return similarDescriptions(a.description, b.description)
+}
+
+// FindDuplicates returns the candidates that look like tx entered twice,
+// leaving out the pairs the user dismissed (pure function)
+func FindDuplicates(tx Transaction, candidates []Transaction, dismissals []Dismissal) []Transaction {
+	var duplicates []Transaction
+	for _, candidate := range candidates {
+		if IsPossibleDuplicate(tx, candidate) && !isDismissed(tx, candidate, dismissals) {
+			duplicates = append(duplicates, candidate)
+		}
+	}
+	return duplicates
+}
+
+// FindDuplicatePairs returns every pair of transactions that look like the
+// same one entered twice, oldest first, leaving out the pairs the user
+// dismissed (pure function)
+func FindDuplicatePairs(transactions []Transaction, dismissals []Dismissal) []DuplicatePair {
+	sorted := make([]Transaction, len(transactions))
+	copy(sorted, transactions)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].createdAt.Before(sorted[j].createdAt)
+	})
+
+	var pairs []DuplicatePair
+	for i := range sorted {
+		for j := i + 1; j < len(sorted); j++ {
+			if IsPossibleDuplicate(sorted[i], sorted[j]) && !isDismissed(sorted[i], sorted[j], dismissals) {
+				pairs = append(pairs, DuplicatePair{Original: sorted[i], Duplicate: sorted[j]})
+			}
+		}
+	}
+	return pairs
+}
+
+func isDismissed(a Transaction, b Transaction, dismissals []Dismissal) bool {
+	for _, d := range dismissals {
+		if d.Covers(a.id, b.id) {
+			return true
+		}
+	}
+	return false
+}
+
+func daysApart(a time.Time, b time.Time) int {
+	dayA := time.Date(a.Year(), a.Month(), a.Day(), 0, 0, 0, 0, time.UTC)
+	dayB := time.Date(b.Year(), b.Month(), b.Day(), 0, 0, 0, 0, time.UTC)
+
+	days := int(dayA.Sub(dayB).Hours() / 24)
+	if days < 0 {
+		return -days
+	}
+	return days
+}
+
+// similarDescriptions compares descriptions word by word, ignoring case and
+// punctuation: "Carrefour" matches "CARREFOUR MARKET", and two descriptions
+// sharing at least half of their words match
+func similarDescriptions(a string, b string) bool {
+	wordsA := descriptionWords(a)
+	wordsB := descriptionWords(b)
+	if len(wordsA) == 0 || len(wordsB) == 0 {
+		return len(wordsA) == len(wordsB)
+	}
+
+	common := 0
+	for word := range wordsA {
+		if wordsB[word] {
+			common++
+		}
+	}
+
+	smaller := len(wordsA)
+	if len(wordsB) < smaller {
+		smaller = len(wordsB)
+	}
+	if common == smaller {
+		return true
+	}
+
+	union := len(wordsA) + len(wordsB) - common
+	return common*2 >= union
+}
+
+func descriptionWords(description string) map[string]bool {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(description), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		words[word] = true
+	}
+	return words
+}
@@ -0,0 +1,14 @@
+package transaction
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+// ErrReconciled is returned when changing or deleting a transaction that was
+// already matched against a bank statement
+var ErrReconciled = fmt.Errorf("transaction was reconciled against the bank and is locked: %w", shared.ErrInvalidInput)
+
+// ErrPossibleDuplicate is returned for an entry that looks like a transaction
+// already recorded
+var ErrPossibleDuplicate = fmt.Errorf("looks like a transaction already recorded: %w", shared.ErrDuplicateEntry)
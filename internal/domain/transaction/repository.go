@@ -9,5 +9,41 @@ type Repository interface {
 	FindAll() ([]Transaction, error)
 	FindByDateRange(start, end time.Time) ([]Transaction, error)
 	FindByMonth(year int, month time.Month) ([]Transaction, error)
+	// FindByLoanID returns the borrowing and repayments of a loan, oldest first
+	FindByLoanID(loanID string) ([]Transaction, error)
+	// FindByTransferID returns both entries of a transfer
+	FindByTransferID(transferID string) ([]Transaction, error)
+	// FindByAccountID returns the transactions of an account made up to and
+	// including until, newest first
+	FindByAccountID(accountID string, until time.Time) ([]Transaction, error)
+	// FindByGoalID returns both entries of every contribution to a goal,
+	// oldest first
+	FindByGoalID(goalID string) ([]Transaction, error)
+	// FindByFITID returns the transaction of an account imported under the
+	// id the bank gave it, or shared.ErrNotFound
+	FindByFITID(accountID, fitID string) (Transaction, error)
+	Update(tx Transaction) error
+	// UnlinkFixedCharge detaches past deductions from a fixed charge that is
+	// being deleted; the transactions themselves are kept
+	UnlinkFixedCharge(fixedChargeID string) error
+	// UnlinkGoal turns the contributions to a goal that is being deleted into
+	// plain transfers
+	UnlinkGoal(goalID string) error
+	// MarkReconciled locks the transactions of an account made up to and
+	// including until that are not locked yet, and returns how many it locked
+	MarkReconciled(accountID string, until time.Time, reconciliationID string) (int, error)
+	// Lock locks a single transaction to a reconciliation, or returns
+	// shared.ErrNotFound
+	Lock(id, reconciliationID string) error
 	Delete(id string) error
 }
+
+// DismissalRepository remembers the pairs of transactions the user confirmed
+// are not duplicates (port)
+type DismissalRepository interface {
+	Save(d Dismissal) error
+	FindAll() ([]Dismissal, error)
+	// DeleteByTransactionID forgets the dismissals a transaction that is
+	// being deleted is part of
+	DeleteByTransactionID(transactionID string) error
+}
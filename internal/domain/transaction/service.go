@@ -6,61 +6,113 @@ import (
 )
 
 // CalculateBalance calculates total balance from transactions (pure function)
-func CalculateBalance(transactions []Transaction) shared.Money {
+func CalculateBalance(transactions []Transaction) (shared.Money, error) {
 	balance := shared.Zero()
 
 	for _, tx := range transactions {
-		if tx.IsIncome() {
-			balance = balance.Add(tx.Amount())
+		var err error
+		if tx.IsInflow() {
+			balance, err = balance.Add(tx.Amount())
 		} else {
-			balance = balance.Subtract(tx.Amount())
+			balance, err = balance.Subtract(tx.Amount())
 		}
+		if err != nil {
+			return shared.Money{}, err
+		}
+	}
+
+	return balance, nil
+}
+
+// CalculateBalanceByAccount calculates the balance of each account, keyed by
+// account ID (pure function)
+func CalculateBalanceByAccount(transactions []Transaction) (map[string]shared.Money, error) {
+	grouped := make(map[string][]Transaction)
+	for _, tx := range transactions {
+		grouped[tx.AccountID()] = append(grouped[tx.AccountID()], tx)
+	}
+
+	balances := make(map[string]shared.Money)
+	for accountID, accountTransactions := range grouped {
+		balance, err := CalculateBalance(accountTransactions)
+		if err != nil {
+			return nil, err
+		}
+		balances[accountID] = balance
 	}
 
-	return balance
+	return balances, nil
 }
 
 // CalculateMonthlyTotal calculates total for a specific month (pure function)
-func CalculateMonthlyTotal(transactions []Transaction, typ TransactionType) shared.Money {
+func CalculateMonthlyTotal(transactions []Transaction, typ TransactionType) (shared.Money, error) {
 	total := shared.Zero()
 
 	for _, tx := range transactions {
 		if tx.Type() == typ {
-			total = total.Add(tx.Amount())
+			var err error
+			total, err = total.Add(tx.Amount())
+			if err != nil {
+				return shared.Money{}, err
+			}
 		}
 	}
 
-	return total
+	return total, nil
 }
 
-// GroupByCategory groups transactions by category (pure function)
-func GroupByCategory(transactions []Transaction) map[string][]Transaction {
+// GroupByCategory groups transactions by category. parents maps a
+// sub-category name to its parent, whose group also receives the
+// sub-category's transactions. (pure function)
+func GroupByCategory(transactions []Transaction, parents map[string]string) map[string][]Transaction {
 	grouped := make(map[string][]Transaction)
 
 	for _, tx := range transactions {
-		categoryName := tx.Category().Name()
-		grouped[categoryName] = append(grouped[categoryName], tx)
+		for _, categoryName := range categoryPath(tx.Category().Name(), parents) {
+			grouped[categoryName] = append(grouped[categoryName], tx)
+		}
 	}
 
 	return grouped
 }
 
-// CalculateCategoryTotal calculates total spent per category (pure function)
-func CalculateCategoryTotal(transactions []Transaction) map[string]shared.Money {
+// CalculateCategoryTotal calculates total spent per category, rolling
+// sub-category spending up to the parent given by parents (pure function)
+func CalculateCategoryTotal(transactions []Transaction, parents map[string]string) (map[string]shared.Money, error) {
 	totals := make(map[string]shared.Money)
 
 	for _, tx := range transactions {
 		if tx.IsExpense() {
-			categoryName := tx.Category().Name()
-			current, exists := totals[categoryName]
-			if !exists {
-				current = shared.Zero()
+			for _, categoryName := range categoryPath(tx.Category().Name(), parents) {
+				current, exists := totals[categoryName]
+				if !exists {
+					current = shared.Zero()
+				}
+				total, err := current.Add(tx.Amount())
+				if err != nil {
+					return nil, err
+				}
+				totals[categoryName] = total
 			}
-			totals[categoryName] = current.Add(tx.Amount())
 		}
 	}
 
-	return totals
+	return totals, nil
+}
+
+// categoryPath returns the category followed by its ancestors
+func categoryPath(categoryName string, parents map[string]string) []string {
+	path := []string{categoryName}
+
+	for parent, exists := parents[categoryName]; exists; parent, exists = parents[parent] {
+		// Guard against cycles in malformed data
+		if len(path) > len(parents) {
+			break
+		}
+		path = append(path, parent)
+	}
+
+	return path
 }
 
 // FilterByDateRange filters transactions within date range (pure function)
@@ -10,15 +10,30 @@ type TransactionType string
 const (
 	TransactionTypeIncome  TransactionType = "income"
 	TransactionTypeExpense TransactionType = "expense"
+	// Transfers move money between two accounts and count as neither
+	// income nor expense
+	TransactionTypeTransferIn  TransactionType = "transfer_in"
+	TransactionTypeTransferOut TransactionType = "transfer_out"
 )
 
 type Transaction struct {
-	id          string
-	amount      shared.Money
-	category    shared.Category
-	description string
-	typ         TransactionType
-	createdAt   time.Time
+	id            string
+	amount        shared.Money
+	category      shared.Category
+	description   string
+	typ           TransactionType
+	createdAt     time.Time
+	loanID        string // set on borrowings and repayments
+	fixedChargeID string // set on fixed charges deducted from a salary
+	accountID     string // empty for the default account
+	transferID    string // shared by the two entries of a transfer
+	// reconciliationID is set once the transaction was matched against a
+	// bank statement, which locks it
+	reconciliationID string
+	goalID           string // set on both entries of a contribution to a goal
+	// fitID is the id the bank gave a transaction imported from an OFX
+	// statement, so that importing the statement again skips it
+	fitID string
 }
 
 func NewTransaction(
@@ -39,12 +54,19 @@ func NewTransaction(
 	}
 }
 
-func (t Transaction) ID() string                     { return t.id }
-func (t Transaction) Amount() shared.Money           { return t.amount }
-func (t Transaction) Category() shared.Category      { return t.category }
-func (t Transaction) Description() string            { return t.description }
-func (t Transaction) Type() TransactionType          { return t.typ }
-func (t Transaction) CreatedAt() time.Time           { return t.createdAt }
+func (t Transaction) ID() string                { return t.id }
+func (t Transaction) Amount() shared.Money      { return t.amount }
+func (t Transaction) Category() shared.Category { return t.category }
+func (t Transaction) Description() string       { return t.description }
+func (t Transaction) Type() TransactionType     { return t.typ }
+func (t Transaction) CreatedAt() time.Time      { return t.createdAt }
+func (t Transaction) LoanID() string            { return t.loanID }
+func (t Transaction) FixedChargeID() string     { return t.fixedChargeID }
+func (t Transaction) AccountID() string         { return t.accountID }
+func (t Transaction) TransferID() string        { return t.transferID }
+func (t Transaction) ReconciliationID() string  { return t.reconciliationID }
+func (t Transaction) GoalID() string            { return t.goalID }
+func (t Transaction) FITID() string             { return t.fitID }
 
 func (t Transaction) IsIncome() bool {
 	return t.typ == TransactionTypeIncome
@@ -53,3 +75,116 @@ func (t Transaction) IsIncome() bool {
 func (t Transaction) IsExpense() bool {
 	return t.typ == TransactionTypeExpense
 }
+
+// IsTransfer reports whether the transaction is one side of a transfer
+// between accounts
+func (t Transaction) IsTransfer() bool {
+	return t.typ == TransactionTypeTransferIn || t.typ == TransactionTypeTransferOut
+}
+
+// IsInflow reports whether the transaction adds money to its account
+func (t Transaction) IsInflow() bool {
+	return t.typ == TransactionTypeIncome || t.typ == TransactionTypeTransferIn
+}
+
+// IsReconciled reports whether the transaction was matched against a bank
+// statement and can no longer change
+func (t Transaction) IsReconciled() bool {
+	return t.reconciliationID != ""
+}
+
+// IsFixedCharge reports whether the transaction deducts a fixed charge
+func (t Transaction) IsFixedCharge() bool {
+	return t.fixedChargeID != ""
+}
+
+// IsGoalContribution reports whether the transaction is one side of a
+// transfer that puts money aside for a savings goal
+func (t Transaction) IsGoalContribution() bool {
+	return t.goalID != ""
+}
+
+// IsLoanRelated reports whether the transaction records a borrowing (income)
+// or a repayment (expense) of a loan
+func (t Transaction) IsLoanRelated() bool {
+	return t.loanID != ""
+}
+
+// WithAmount returns a copy of the transaction carrying a different amount,
+// e.g. the same entry expressed in another currency
+func (t Transaction) WithAmount(amount shared.Money) Transaction {
+	c := t
+	c.amount = amount
+	return c
+}
+
+// WithLoanID returns a copy of the transaction linked to a loan
+func (t Transaction) WithLoanID(loanID string) Transaction {
+	c := t
+	c.loanID = loanID
+	return c
+}
+
+// WithFixedChargeID returns a copy of the transaction linked to the fixed
+// charge it deducts
+func (t Transaction) WithFixedChargeID(fixedChargeID string) Transaction {
+	c := t
+	c.fixedChargeID = fixedChargeID
+	return c
+}
+
+// WithAccountID returns a copy of the transaction booked on an account
+func (t Transaction) WithAccountID(accountID string) Transaction {
+	c := t
+	c.accountID = accountID
+	return c
+}
+
+// WithTransferID returns a copy of the transaction linked to the other side
+// of its transfer
+func (t Transaction) WithTransferID(transferID string) Transaction {
+	c := t
+	c.transferID = transferID
+	return c
+}
+
+// WithReconciliationID returns a copy of the transaction locked by a
+// reconciliation
+func (t Transaction) WithReconciliationID(reconciliationID string) Transaction {
+	c := t
+	c.reconciliationID = reconciliationID
+	return c
+}
+
+// WithGoalID returns a copy of the transaction counted as a contribution to a
+// savings goal
+func (t Transaction) WithGoalID(goalID string) Transaction {
+	c := t
+	c.goalID = goalID
+	return c
+}
+
+// WithFITID returns a copy of the transaction carrying the id the bank gave
+// it in an OFX statement
+func (t Transaction) WithFITID(fitID string) Transaction {
+	c := t
+	c.fitID = fitID
+	return c
+}
+
+// WithDetails returns a copy of the transaction with corrected details. The
+// type, the account, the loan, fixed charge, transfer and goal links and the
+// reconciliation lock never change.
+func (t Transaction) WithDetails(
+	amount shared.Money,
+	category shared.Category,
+	description string,
+	createdAt time.Time,
+) Transaction {
+	c := t
+	c.amount = amount
+	c.category = category
+	c.description = description
+	c.createdAt = createdAt
+	return c
+}
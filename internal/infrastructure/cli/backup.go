@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"io"
+	"os"
+	"time"
+)
+
+// BackupCommands runs the export and import commands, so the data can be
+// backed up and restored without the server running
+type BackupCommands struct {
+	exportDataUC    *application.ExportDataUseCase
+	restoreBackupUC *application.RestoreBackupUseCase
+	stdout          io.Writer
+}
+
+func NewBackupCommands(
+	exportDataUC *application.ExportDataUseCase,
+	restoreBackupUC *application.RestoreBackupUseCase,
+	stdout io.Writer,
+) *BackupCommands {
+	return &BackupCommands{
+		exportDataUC:    exportDataUC,
+		restoreBackupUC: restoreBackupUC,
+		stdout:          stdout,
+	}
+}
+
+// Run runs the command named by the first argument
+func (c *BackupCommands) Run(args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("usage: moka export|import")
+	}
+
+	switch args[0] {
+	case "export":
+		return c.export(args[1:])
+	case "import":
+		return c.restore(args[1:])
+	default:
+		return fmt.Errorf("unknown command %q, expected export or import", args[0])
+	}
+}
+
+// export writes the data out, to stdout unless -o names a file:
+//
+//	moka export [-format json|csv|zip] [-table transactions] [-o file]
+func (c *BackupCommands) export(args []string) error {
+	flags := flag.NewFlagSet("export", flag.ContinueOnError)
+	format := flags.String("format", string(application.BackupFormatJSON), "json, csv (one table) or zip (json and every table)")
+	tableName := flags.String("table", "transactions", "table to write with -format csv")
+	outPath := flags.String("o", "", "file to write to instead of stdout")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	var write func(w io.Writer, b *application.Backup) error
+	switch application.BackupFormat(*format) {
+	case application.BackupFormatJSON:
+		write = application.WriteBackupJSON
+	case application.BackupFormatArchive:
+		write = application.WriteBackupArchive
+	case application.BackupFormatCSV:
+		table, err := application.FindBackupTable(*tableName)
+		if err != nil {
+			return err
+		}
+		write = table.WriteCSV
+	default:
+		return fmt.Errorf("unknown format %q, expected json, csv or zip", *format)
+	}
+
+	output, err := c.exportDataUC.Execute(application.ExportDataInput{
+		At: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export data: %w", err)
+	}
+
+	if *outPath == "" {
+		return write(c.stdout, output.Backup)
+	}
+
+	file, err := os.Create(*outPath)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", *outPath, err)
+	}
+	if err := write(file, output.Backup); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// restore loads a backup file, JSON or archive, into an empty database:
+//
+//	moka import backup.json
+func (c *BackupCommands) restore(args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("usage: moka import <backup file>")
+	}
+
+	content, err := os.ReadFile(args[0])
+	if err != nil {
+		return fmt.Errorf("failed to read %s: %w", args[0], err)
+	}
+
+	backup, err := application.ReadBackup(content)
+	if err != nil {
+		return err
+	}
+
+	output, err := c.restoreBackupUC.Execute(application.RestoreBackupInput{
+		Backup: backup,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore backup: %w", err)
+	}
+
+	fmt.Fprintf(c.stdout, "Restored the backup of %s: %d transaction(s), %d budget(s), %d fixed charge(s), %d loan(s)\n",
+		output.Backup.ExportedAt.Format("2006-01-02 15:04"),
+		len(output.Backup.Transactions),
+		len(output.Backup.Budgets),
+		len(output.Backup.FixedCharges),
+		len(output.Backup.Loans),
+	)
+	return nil
+}
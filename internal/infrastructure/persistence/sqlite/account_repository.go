@@ -0,0 +1,134 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+type AccountRepository struct {
+	db querier
+}
+
+func NewAccountRepository(db *DB) *AccountRepository {
+	return &AccountRepository{db: db}
+}
+
+func (r *AccountRepository) Save(a account.Account) error {
+	query := `
+		INSERT INTO accounts (id, name, type)
+		VALUES (?, ?, ?)
+	`
+
+	_, err := r.db.Exec(query, a.ID(), a.Name(), string(a.Type()))
+	if err != nil {
+		return fmt.Errorf("failed to save account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AccountRepository) FindByID(id string) (account.Account, error) {
+	query := `
+		SELECT id, name, type
+		FROM accounts
+		WHERE id = ?
+	`
+
+	row := r.db.QueryRow(query, id)
+	return r.scanAccount(row)
+}
+
+func (r *AccountRepository) FindByName(name string) (account.Account, error) {
+	query := `
+		SELECT id, name, type
+		FROM accounts
+		WHERE name = ?
+	`
+
+	row := r.db.QueryRow(query, name)
+	return r.scanAccount(row)
+}
+
+func (r *AccountRepository) FindAll() ([]account.Account, error) {
+	query := `
+		SELECT id, name, type
+		FROM accounts
+		ORDER BY id != ?, name
+	`
+
+	rows, err := r.db.Query(query, account.DefaultAccountID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []account.Account
+
+	for rows.Next() {
+		var id, name, typ string
+
+		if err := rows.Scan(&id, &name, &typ); err != nil {
+			return nil, fmt.Errorf("failed to scan account: %w", err)
+		}
+
+		a, err := account.NewAccount(id, name, account.AccountType(typ))
+		if err != nil {
+			return nil, fmt.Errorf("invalid stored account %s: %w", id, err)
+		}
+
+		accounts = append(accounts, a)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+func (r *AccountRepository) Update(a account.Account) error {
+	query := `
+		UPDATE accounts
+		SET name = ?, type = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.Exec(query, a.Name(), string(a.Type()), a.ID())
+	if err != nil {
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *AccountRepository) scanAccount(row *sql.Row) (account.Account, error) {
+	var id, name, typ string
+
+	err := row.Scan(&id, &name, &typ)
+
+	if err == sql.ErrNoRows {
+		return account.Account{}, shared.ErrNotFound
+	}
+
+	if err != nil {
+		return account.Account{}, fmt.Errorf("failed to scan account: %w", err)
+	}
+
+	a, err := account.NewAccount(id, name, account.AccountType(typ))
+	if err != nil {
+		return account.Account{}, fmt.Errorf("invalid stored account %s: %w", id, err)
+	}
+
+	return a, nil
+}
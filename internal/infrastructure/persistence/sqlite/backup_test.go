@@ -0,0 +1,130 @@
+package sqlite
+
+import (
+	"bytes"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"testing"
+	"time"
+)
+
+// populate records a borrowed loan partly paid back, a recurring expense
+// that has come due once, and a reconciliation that posts an adjustment and
+// locks all of it
+func populate(t *testing.T, db *DB) {
+	t.Helper()
+
+	uow := NewUnitOfWork(db)
+	converter := application.NewStaticRateConverter("MAD", nil)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	borrowed, err := application.NewBorrowMoneyUseCase(uow).Execute(application.BorrowMoneyInput{
+		LenderName:   "Youssef",
+		Amount:       "5000.00",
+		Currency:     "MAD",
+		Description:  "Car repair",
+		Date:         day(time.September, 1),
+		Installments: 5,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := application.NewPayLoanUseCase(uow).Execute(application.PayLoanInput{
+		LoanID: borrowed.Loan.ID(),
+		Amount: "1000.00",
+		Date:   day(time.September, 15),
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := application.NewCreateRecurringRuleUseCase(NewRecurringRuleRepository(db), NewCategoryRepository(db)).Execute(application.CreateRecurringRuleInput{
+		Description:  "Gym",
+		Amount:       "200.00",
+		Currency:     "MAD",
+		Type:         "expense",
+		CategoryName: "Entertainment",
+		Frequency:    "monthly",
+		Interval:     1,
+		DayOfMonth:   5,
+		StartDate:    day(time.September, 5),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	run, err := application.NewRunRecurringRulesUseCase(uow).Execute(application.RunRecurringRulesInput{
+		At: day(time.September, 10),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(run.Transactions) != 1 {
+		t.Fatalf("got %d recurring transactions, want 1", len(run.Transactions))
+	}
+
+	reconciled, err := application.NewReconcileAccountUseCase(uow, converter).Execute(application.ReconcileAccountInput{
+		Date:           day(time.September, 30),
+		Balance:        "3750.00",
+		Currency:       "MAD",
+		PostAdjustment: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reconciled.Reconciled || reconciled.Adjustment == nil {
+		t.Fatal("the reconciliation posted no adjustment")
+	}
+}
+
+func exportJSON(t *testing.T, db *DB, at time.Time) []byte {
+	t.Helper()
+
+	output, err := application.NewExportDataUseCase(NewUnitOfWork(db)).Execute(application.ExportDataInput{At: at})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+	if err := application.WriteBackupJSON(&b, output.Backup); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestBackupRoundTrip(t *testing.T) {
+	source := newTestDB(t)
+	populate(t, source)
+
+	at := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	exported := exportJSON(t, source, at)
+
+	backup, err := application.ReadBackup(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, n := range map[string]int{
+		"reconciliations":       len(backup.Reconciliations),
+		"loans":                 len(backup.Loans),
+		"loan payments":         len(backup.LoanPayments),
+		"recurring occurrences": len(backup.RecurringOccurrences),
+	} {
+		if n == 0 {
+			t.Fatalf("the backup holds no %s", name)
+		}
+	}
+	for _, tx := range backup.Transactions {
+		if tx.ReconciliationID == "" {
+			t.Fatalf("transaction %s %s is not locked", tx.Description, tx.Date.Format("2006-01-02"))
+		}
+	}
+
+	restored := newTestDB(t)
+	if _, err := application.NewRestoreBackupUseCase(NewUnitOfWork(restored)).Execute(application.RestoreBackupInput{
+		Backup: backup,
+	}); err != nil {
+		t.Fatalf("restoring the backup: %v", err)
+	}
+
+	if again := exportJSON(t, restored, at); !bytes.Equal(again, exported) {
+		t.Errorf("the restored database exports differently\nbefore: %s\nafter:  %s", exported, again)
+	}
+}
@@ -9,7 +9,7 @@ import (
 )
 
 type BudgetRepository struct {
-	db *DB
+	db querier
 }
 
 func NewBudgetRepository(db *DB) *BudgetRepository {
@@ -26,7 +26,7 @@ func (r *BudgetRepository) Save(b budget.Budget) error {
 		query,
 		b.ID(),
 		b.Category().Name(),
-		b.Limit().Amount(),
+		b.Limit().MinorUnits(),
 		b.Limit().Currency(),
 		int(b.Month()),
 		b.Year(),
@@ -50,6 +50,22 @@ func (r *BudgetRepository) FindByID(id string) (budget.Budget, error) {
 	return r.scanBudget(row)
 }
 
+func (r *BudgetRepository) FindAll() ([]budget.Budget, error) {
+	query := `
+		SELECT id, category_name, limit_amount, currency, month, year
+		FROM budgets
+		ORDER BY year, month, category_name
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query budgets: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanBudgets(rows)
+}
+
 func (r *BudgetRepository) FindByMonthAndYear(month time.Month, year int) ([]budget.Budget, error) {
 	query := `
 		SELECT id, category_name, limit_amount, currency, month, year
@@ -87,7 +103,7 @@ func (r *BudgetRepository) Update(b budget.Budget) error {
 	result, err := r.db.Exec(
 		query,
 		b.Category().Name(),
-		b.Limit().Amount(),
+		b.Limit().MinorUnits(),
 		b.Limit().Currency(),
 		int(b.Month()),
 		b.Year(),
@@ -134,7 +150,7 @@ func (r *BudgetRepository) scanBudget(row *sql.Row) (budget.Budget, error) {
 	var (
 		id           string
 		categoryName string
-		limitAmount  float64
+		limitAmount  int64
 		currency     string
 		month        int
 		year         int
@@ -150,7 +166,7 @@ func (r *BudgetRepository) scanBudget(row *sql.Row) (budget.Budget, error) {
 		return budget.Budget{}, fmt.Errorf("failed to scan budget: %w", err)
 	}
 
-	money := shared.UnsafeNewMoney(limitAmount)
+	money := shared.UnsafeNewMoney(limitAmount, currency)
 	category, _ := shared.NewCategory(categoryName, shared.CategoryTypeExpense)
 
 	return budget.NewBudget(id, category, money, time.Month(month), year), nil
@@ -163,7 +179,7 @@ func (r *BudgetRepository) scanBudgets(rows *sql.Rows) ([]budget.Budget, error)
 		var (
 			id           string
 			categoryName string
-			limitAmount  float64
+			limitAmount  int64
 			currency     string
 			month        int
 			year         int
@@ -174,7 +190,7 @@ func (r *BudgetRepository) scanBudgets(rows *sql.Rows) ([]budget.Budget, error)
 			return nil, fmt.Errorf("failed to scan budget: %w", err)
 		}
 
-		money := shared.UnsafeNewMoney(limitAmount)
+		money := shared.UnsafeNewMoney(limitAmount, currency)
 		category, _ := shared.NewCategory(categoryName, shared.CategoryTypeExpense)
 
 		b := budget.NewBudget(id, category, money, time.Month(month), year)
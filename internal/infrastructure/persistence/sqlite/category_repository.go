@@ -0,0 +1,200 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/shared"
+)
+
+type CategoryRepository struct {
+	db querier
+}
+
+func NewCategoryRepository(db *DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+func (r *CategoryRepository) Save(c category.Category) error {
+	query := `
+		INSERT INTO categories (id, name, type, parent_id, color, icon, is_archived, is_builtin)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		c.ID(),
+		c.Name(),
+		string(c.Type()),
+		nullableString(c.ParentID()),
+		c.Color(),
+		c.Icon(),
+		c.IsArchived(),
+		c.IsBuiltin(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save category: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CategoryRepository) FindByID(id string) (category.Category, error) {
+	query := `
+		SELECT id, name, type, parent_id, color, icon, is_archived, is_builtin
+		FROM categories
+		WHERE id = ?
+	`
+
+	row := r.db.QueryRow(query, id)
+	return r.scanCategory(row)
+}
+
+func (r *CategoryRepository) FindByName(name string) (category.Category, error) {
+	query := `
+		SELECT id, name, type, parent_id, color, icon, is_archived, is_builtin
+		FROM categories
+		WHERE name = ?
+	`
+
+	row := r.db.QueryRow(query, name)
+	return r.scanCategory(row)
+}
+
+func (r *CategoryRepository) FindAll() ([]category.Category, error) {
+	query := `
+		SELECT id, name, type, parent_id, color, icon, is_archived, is_builtin
+		FROM categories
+		ORDER BY type DESC, is_archived, name
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categories: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanCategories(rows)
+}
+
+func (r *CategoryRepository) FindByType(typ shared.CategoryType) ([]category.Category, error) {
+	query := `
+		SELECT id, name, type, parent_id, color, icon, is_archived, is_builtin
+		FROM categories
+		WHERE type = ?
+		ORDER BY is_archived, name
+	`
+
+	rows, err := r.db.Query(query, string(typ))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query categories: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanCategories(rows)
+}
+
+func (r *CategoryRepository) Update(c category.Category) error {
+	query := `
+		UPDATE categories
+		SET color = ?, icon = ?, is_archived = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.Exec(query, c.Color(), c.Icon(), c.IsArchived(), c.ID())
+	if err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *CategoryRepository) Delete(id string) error {
+	query := `DELETE FROM categories WHERE id = ?`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *CategoryRepository) scanCategory(row *sql.Row) (category.Category, error) {
+	var (
+		id         string
+		name       string
+		typ        string
+		parentID   sql.NullString
+		color      string
+		icon       string
+		isArchived bool
+		isBuiltin  bool
+	)
+
+	err := row.Scan(&id, &name, &typ, &parentID, &color, &icon, &isArchived, &isBuiltin)
+
+	if err == sql.ErrNoRows {
+		return category.Category{}, shared.ErrNotFound
+	}
+
+	if err != nil {
+		return category.Category{}, fmt.Errorf("failed to scan category: %w", err)
+	}
+
+	return category.NewCategory(id, name, shared.CategoryType(typ), parentID.String, color, icon, isArchived, isBuiltin), nil
+}
+
+func (r *CategoryRepository) scanCategories(rows *sql.Rows) ([]category.Category, error) {
+	var categories []category.Category
+
+	for rows.Next() {
+		var (
+			id         string
+			name       string
+			typ        string
+			parentID   sql.NullString
+			color      string
+			icon       string
+			isArchived bool
+			isBuiltin  bool
+		)
+
+		err := rows.Scan(&id, &name, &typ, &parentID, &color, &icon, &isArchived, &isBuiltin)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category: %w", err)
+		}
+
+		categories = append(categories, category.NewCategory(id, name, shared.CategoryType(typ), parentID.String, color, icon, isArchived, isBuiltin))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating categories: %w", err)
+	}
+
+	return categories, nil
+}
+
+// nullableString stores empty strings as NULL so foreign keys stay valid
+func nullableString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
+}
@@ -0,0 +1,217 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+type CategoryRuleRepository struct {
+	db querier
+}
+
+func NewCategoryRuleRepository(db *DB) *CategoryRuleRepository {
+	return &CategoryRuleRepository{db: db}
+}
+
+func (r *CategoryRuleRepository) Save(rule categorization.Rule) error {
+	query := `
+		INSERT INTO category_rules (id, priority, contains, pattern, counterparty, min_amount, max_amount, amount_currency, category_name, category_type, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	minAmount, maxAmount, currency := ruleBounds(rule)
+
+	_, err := r.db.Exec(
+		query,
+		rule.ID(),
+		rule.Priority(),
+		nullableString(rule.Contains()),
+		nullableString(rule.Pattern()),
+		nullableString(rule.Counterparty()),
+		minAmount,
+		maxAmount,
+		currency,
+		rule.Category().Name(),
+		string(rule.Category().Type()),
+		rule.CreatedAt(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save category rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *CategoryRuleRepository) FindByID(id string) (categorization.Rule, error) {
+	query := `
+		SELECT id, priority, contains, pattern, counterparty, min_amount, max_amount, amount_currency, category_name, category_type, created_at
+		FROM category_rules
+		WHERE id = ?
+	`
+
+	rules, err := r.query(query, id)
+	if err != nil {
+		return categorization.Rule{}, err
+	}
+
+	if len(rules) == 0 {
+		return categorization.Rule{}, shared.ErrNotFound
+	}
+
+	return rules[0], nil
+}
+
+func (r *CategoryRuleRepository) FindAll() ([]categorization.Rule, error) {
+	query := `
+		SELECT id, priority, contains, pattern, counterparty, min_amount, max_amount, amount_currency, category_name, category_type, created_at
+		FROM category_rules
+		ORDER BY priority, created_at
+	`
+
+	return r.query(query)
+}
+
+func (r *CategoryRuleRepository) Update(rule categorization.Rule) error {
+	query := `
+		UPDATE category_rules
+		SET priority = ?, contains = ?, pattern = ?, counterparty = ?, min_amount = ?, max_amount = ?, amount_currency = ?, category_name = ?, category_type = ?
+		WHERE id = ?
+	`
+
+	minAmount, maxAmount, currency := ruleBounds(rule)
+
+	result, err := r.db.Exec(
+		query,
+		rule.Priority(),
+		nullableString(rule.Contains()),
+		nullableString(rule.Pattern()),
+		nullableString(rule.Counterparty()),
+		minAmount,
+		maxAmount,
+		currency,
+		rule.Category().Name(),
+		string(rule.Category().Type()),
+		rule.ID(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update category rule: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *CategoryRuleRepository) Delete(id string) error {
+	query := `DELETE FROM category_rules WHERE id = ?`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete category rule: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *CategoryRuleRepository) query(query string, args ...interface{}) ([]categorization.Rule, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query category rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []categorization.Rule
+
+	for rows.Next() {
+		var (
+			id             string
+			priority       int
+			contains       sql.NullString
+			pattern        sql.NullString
+			counterparty   sql.NullString
+			minAmount      sql.NullInt64
+			maxAmount      sql.NullInt64
+			amountCurrency sql.NullString
+			categoryName   string
+			categoryType   string
+			createdAt      time.Time
+		)
+
+		err := rows.Scan(&id, &priority, &contains, &pattern, &counterparty, &minAmount, &maxAmount, &amountCurrency, &categoryName, &categoryType, &createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category rule: %w", err)
+		}
+
+		var minMoney, maxMoney *shared.Money
+		if minAmount.Valid {
+			money := shared.UnsafeNewMoney(minAmount.Int64, amountCurrency.String)
+			minMoney = &money
+		}
+		if maxAmount.Valid {
+			money := shared.UnsafeNewMoney(maxAmount.Int64, amountCurrency.String)
+			maxMoney = &money
+		}
+
+		category, _ := shared.NewCategory(categoryName, shared.CategoryType(categoryType))
+
+		rule, err := categorization.NewRule(
+			id,
+			priority,
+			contains.String,
+			pattern.String,
+			counterparty.String,
+			minMoney,
+			maxMoney,
+			category,
+			createdAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid category rule %s: %w", id, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating category rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+// ruleBounds returns the amount bounds of a rule as stored, NULL when unset
+func ruleBounds(rule categorization.Rule) (sql.NullInt64, sql.NullInt64, sql.NullString) {
+	var minAmount, maxAmount sql.NullInt64
+	var currency sql.NullString
+
+	if m := rule.MinAmount(); m != nil {
+		minAmount = sql.NullInt64{Int64: m.MinorUnits(), Valid: true}
+		currency = nullableString(m.Currency())
+	}
+	if m := rule.MaxAmount(); m != nil {
+		maxAmount = sql.NullInt64{Int64: m.MinorUnits(), Valid: true}
+		currency = nullableString(m.Currency())
+	}
+
+	return minAmount, maxAmount, currency
+}
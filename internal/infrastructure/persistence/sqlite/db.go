@@ -15,10 +15,20 @@ import (
 
 type DB struct {
 	*sql.DB
+	path string
+}
+
+// querier is implemented by both *DB and *sql.Tx, so repositories can run
+// inside or outside a unit of work
+type querier interface {
+	Exec(query string, args ...any) (sql.Result, error)
+	Query(query string, args ...any) (*sql.Rows, error)
+	QueryRow(query string, args ...any) *sql.Row
 }
 
 func NewDB(dbPath string) (*DB, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	// Foreign keys are set in the DSN so every pooled connection enforces them
+	db, err := sql.Open("sqlite3", dbPath+"?_foreign_keys=on")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
@@ -27,11 +37,7 @@ func NewDB(dbPath string) (*DB, error) {
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	if _, err := db.Exec("PRAGMA foreign_keys = ON"); err != nil {
-		return nil, fmt.Errorf("failed to enable foreign keys: %w", err)
-	}
-
-	return &DB{db}, nil
+	return &DB{DB: db, path: dbPath}, nil
 }
 
 func (db *DB) Close() error {
@@ -39,7 +45,12 @@ func (db *DB) Close() error {
 }
 
 func (db *DB) RunMigrations(migrationsPath string) error {
-	driver, err := sqlite3.WithInstance(db.DB, &sqlite3.Config{})
+	migrationDB, err := db.openForMigrations()
+	if err != nil {
+		return err
+	}
+
+	driver, err := sqlite3.WithInstance(migrationDB, &sqlite3.Config{})
 	if err != nil {
 		return fmt.Errorf("failed to create migration driver: %w", err)
 	}
@@ -53,15 +64,22 @@ func (db *DB) RunMigrations(migrationsPath string) error {
 		return fmt.Errorf("failed to create migration instance: %w", err)
 	}
 
+	defer m.Close()
+
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	return nil
+	return checkForeignKeys(migrationDB)
 }
 
 func (db *DB) RunMigrationsFromFS(migrationFS embed.FS, path string) error {
-	driver, err := sqlite3.WithInstance(db.DB, &sqlite3.Config{})
+	migrationDB, err := db.openForMigrations()
+	if err != nil {
+		return err
+	}
+
+	driver, err := sqlite3.WithInstance(migrationDB, &sqlite3.Config{})
 	if err != nil {
 		return fmt.Errorf("failed to create migration driver: %w", err)
 	}
@@ -81,9 +99,45 @@ func (db *DB) RunMigrationsFromFS(migrationFS embed.FS, path string) error {
 		return fmt.Errorf("failed to create migration instance: %w", err)
 	}
 
+	defer m.Close()
+
 	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
 		return fmt.Errorf("failed to run migrations: %w", err)
 	}
 
-	return nil
+	return checkForeignKeys(migrationDB)
+}
+
+// openForMigrations opens a separate connection with foreign keys off, as
+// SQLite requires when a migration rebuilds a table other tables reference
+func (db *DB) openForMigrations() (*sql.DB, error) {
+	migrationDB, err := sql.Open("sqlite3", db.path+"?_foreign_keys=off")
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database for migrations: %w", err)
+	}
+	return migrationDB, nil
+}
+
+// checkForeignKeys fails when a migration left rows pointing nowhere
+func checkForeignKeys(db *sql.DB) error {
+	rows, err := db.Query("PRAGMA foreign_key_check")
+	if err != nil {
+		return fmt.Errorf("failed to check foreign keys: %w", err)
+	}
+	defer rows.Close()
+
+	if rows.Next() {
+		var (
+			table  string
+			rowID  sql.NullInt64
+			parent string
+			fkID   int
+		)
+		if err := rows.Scan(&table, &rowID, &parent, &fkID); err != nil {
+			return fmt.Errorf("failed to check foreign keys: %w", err)
+		}
+		return fmt.Errorf("migrations left a row in %s referencing a missing %s", table, parent)
+	}
+
+	return rows.Err()
 }
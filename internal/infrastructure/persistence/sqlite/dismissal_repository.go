@@ -0,0 +1,63 @@
+package sqlite
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+)
+
+type DismissalRepository struct {
+	db querier
+}
+
+func NewDismissalRepository(db *DB) *DismissalRepository {
+	return &DismissalRepository{db: db}
+}
+
+func (r *DismissalRepository) Save(d transaction.Dismissal) error {
+	query := `
+		INSERT OR IGNORE INTO duplicate_dismissals (transaction_id, other_id)
+		VALUES (?, ?)
+	`
+
+	if _, err := r.db.Exec(query, d.TransactionID(), d.OtherID()); err != nil {
+		return fmt.Errorf("failed to save dismissal: %w", err)
+	}
+
+	return nil
+}
+
+func (r *DismissalRepository) FindAll() ([]transaction.Dismissal, error) {
+	query := `SELECT transaction_id, other_id FROM duplicate_dismissals`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query dismissals: %w", err)
+	}
+	defer rows.Close()
+
+	var dismissals []transaction.Dismissal
+
+	for rows.Next() {
+		var transactionID, otherID string
+		if err := rows.Scan(&transactionID, &otherID); err != nil {
+			return nil, fmt.Errorf("failed to scan dismissal: %w", err)
+		}
+		dismissals = append(dismissals, transaction.NewDismissal(transactionID, otherID))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating dismissals: %w", err)
+	}
+
+	return dismissals, nil
+}
+
+func (r *DismissalRepository) DeleteByTransactionID(transactionID string) error {
+	query := `DELETE FROM duplicate_dismissals WHERE transaction_id = ? OR other_id = ?`
+
+	if _, err := r.db.Exec(query, transactionID, transactionID); err != nil {
+		return fmt.Errorf("failed to delete dismissals: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+package sqlite
+
+import (
+	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTestDB(t *testing.T) *DB {
+	t.Helper()
+
+	db, err := NewDB(filepath.Join(t.TempDir(), "moka.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrations, err := filepath.Abs(filepath.Join("..", "..", "..", "..", "migrations"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.RunMigrations(migrations); err != nil {
+		t.Fatal(err)
+	}
+
+	return db
+}
+
+// saveDismissedPair saves two look-alike expenses the user dismissed as
+// duplicates and returns their IDs
+func saveDismissedPair(t *testing.T, db *DB) (string, string) {
+	t.Helper()
+
+	amount, err := shared.ParseMoney("120.50", "MAD")
+	if err != nil {
+		t.Fatal(err)
+	}
+	category, err := shared.NewCategory("Food", shared.CategoryTypeExpense)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	transactionRepo := NewTransactionRepository(db)
+	date := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	ids := []string{"tx-a", "tx-b"}
+	for _, id := range ids {
+		tx := transaction.NewTransaction(id, amount, category, "Carrefour", transaction.TransactionTypeExpense, date)
+		if err := transactionRepo.Save(tx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dismiss := application.NewDismissDuplicateUseCase(transactionRepo, NewDismissalRepository(db))
+	if _, err := dismiss.Execute(application.DismissDuplicateInput{TransactionID: ids[0], OtherID: ids[1]}); err != nil {
+		t.Fatal(err)
+	}
+
+	return ids[0], ids[1]
+}
+
+func checkNoDismissals(t *testing.T, db *DB) {
+	t.Helper()
+
+	dismissals, err := NewDismissalRepository(db).FindAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dismissals) != 0 {
+		t.Errorf("got %d dismissals left, want 0", len(dismissals))
+	}
+}
+
+func TestDeleteDismissedTransaction(t *testing.T) {
+	db := newTestDB(t)
+	_, other := saveDismissedPair(t, db)
+
+	deleteTransaction := application.NewDeleteTransactionUseCase(NewUnitOfWork(db))
+	if _, err := deleteTransaction.Execute(application.DeleteTransactionInput{TransactionID: other}); err != nil {
+		t.Fatalf("deleting a dismissed transaction: %v", err)
+	}
+
+	checkNoDismissals(t, db)
+}
+
+func TestMergeDismissedTransaction(t *testing.T) {
+	db := newTestDB(t)
+	keep, remove := saveDismissedPair(t, db)
+
+	merge := application.NewMergeDuplicateUseCase(NewUnitOfWork(db))
+	if _, err := merge.Execute(application.MergeDuplicateInput{KeepID: keep, RemoveID: remove}); err != nil {
+		t.Fatalf("merging a dismissed pair: %v", err)
+	}
+
+	checkNoDismissals(t, db)
+}
@@ -0,0 +1,175 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/exchange_rate"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+// effectiveDateLayout stores effective dates as plain calendar days so they
+// compare correctly as text
+const effectiveDateLayout = "2006-01-02"
+
+type ExchangeRateRepository struct {
+	db querier
+}
+
+func NewExchangeRateRepository(db *DB) *ExchangeRateRepository {
+	return &ExchangeRateRepository{db: db}
+}
+
+func (r *ExchangeRateRepository) Save(rate exchange_rate.Rate) error {
+	query := `
+		INSERT INTO exchange_rates (id, currency, quote_currency, rate_micros, effective_on)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(currency, quote_currency, effective_on) DO UPDATE SET id = excluded.id, rate_micros = excluded.rate_micros
+	`
+
+	_, err := r.db.Exec(
+		query,
+		rate.ID(),
+		rate.Currency(),
+		rate.QuoteCurrency(),
+		rate.Rate().Micros(),
+		rate.EffectiveOn().Format(effectiveDateLayout),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save exchange rate: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ExchangeRateRepository) FindByID(id string) (exchange_rate.Rate, error) {
+	query := `
+		SELECT id, currency, quote_currency, rate_micros, effective_on
+		FROM exchange_rates
+		WHERE id = ?
+	`
+
+	row := r.db.QueryRow(query, id)
+	return r.scanRate(row)
+}
+
+func (r *ExchangeRateRepository) FindAll() ([]exchange_rate.Rate, error) {
+	query := `
+		SELECT id, currency, quote_currency, rate_micros, effective_on
+		FROM exchange_rates
+		ORDER BY effective_on DESC, currency
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query exchange rates: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanRates(rows)
+}
+
+func (r *ExchangeRateRepository) FindEffective(currency, quoteCurrency string, date time.Time) (exchange_rate.Rate, error) {
+	query := `
+		SELECT id, currency, quote_currency, rate_micros, effective_on
+		FROM exchange_rates
+		WHERE currency = ? AND quote_currency = ? AND effective_on <= ?
+		ORDER BY effective_on DESC
+		LIMIT 1
+	`
+
+	row := r.db.QueryRow(query, currency, quoteCurrency, date.Format(effectiveDateLayout))
+	return r.scanRate(row)
+}
+
+func (r *ExchangeRateRepository) Delete(id string) error {
+	query := `DELETE FROM exchange_rates WHERE id = ?`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete exchange rate: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *ExchangeRateRepository) scanRate(row *sql.Row) (exchange_rate.Rate, error) {
+	var (
+		id            string
+		currency      string
+		quoteCurrency string
+		rateMicros    int64
+		effectiveOn   string
+	)
+
+	err := row.Scan(&id, &currency, &quoteCurrency, &rateMicros, &effectiveOn)
+
+	if err == sql.ErrNoRows {
+		return exchange_rate.Rate{}, shared.ErrNotFound
+	}
+
+	if err != nil {
+		return exchange_rate.Rate{}, fmt.Errorf("failed to scan exchange rate: %w", err)
+	}
+
+	return r.buildRate(id, currency, quoteCurrency, rateMicros, effectiveOn)
+}
+
+func (r *ExchangeRateRepository) scanRates(rows *sql.Rows) ([]exchange_rate.Rate, error) {
+	var rates []exchange_rate.Rate
+
+	for rows.Next() {
+		var (
+			id            string
+			currency      string
+			quoteCurrency string
+			rateMicros    int64
+			effectiveOn   string
+		)
+
+		err := rows.Scan(&id, &currency, &quoteCurrency, &rateMicros, &effectiveOn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan exchange rate: %w", err)
+		}
+
+		rate, err := r.buildRate(id, currency, quoteCurrency, rateMicros, effectiveOn)
+		if err != nil {
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating exchange rates: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (r *ExchangeRateRepository) buildRate(
+	id, currency, quoteCurrency string,
+	rateMicros int64,
+	effectiveOn string,
+) (exchange_rate.Rate, error) {
+	rate, err := shared.NewConversionRate(rateMicros)
+	if err != nil {
+		return exchange_rate.Rate{}, fmt.Errorf("invalid stored exchange rate %s: %w", id, err)
+	}
+
+	date, err := time.Parse(effectiveDateLayout, effectiveOn)
+	if err != nil {
+		return exchange_rate.Rate{}, fmt.Errorf("invalid stored exchange rate date %s: %w", id, err)
+	}
+
+	return exchange_rate.NewRate(id, currency, quoteCurrency, rate, date), nil
+}
@@ -0,0 +1,36 @@
+package sqlite
+
+import (
+	"errors"
+	"github.com/aymaneelmaini/moka/internal/domain/exchange_rate"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"testing"
+	"time"
+)
+
+func TestSaveExchangeRateReplacesSamePairAndDate(t *testing.T) {
+	repo := NewExchangeRateRepository(newTestDB(t))
+	effectiveOn := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, r := range []struct{ id, rate string }{{"rate-1", "10.85"}, {"rate-2", "10.90"}} {
+		value, err := shared.ParseConversionRate(r.rate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Save(exchange_rate.NewRate(r.id, "EUR", "MAD", value, effectiveOn)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stored, err := repo.FindByID("rate-2")
+	if err != nil {
+		t.Fatalf("the replacing rate is not found by its id: %v", err)
+	}
+	if got := stored.Rate().String(); got != "10.900000" {
+		t.Errorf("got rate %s, want 10.900000", got)
+	}
+
+	if _, err := repo.FindByID("rate-1"); !errors.Is(err, shared.ErrNotFound) {
+		t.Errorf("the replaced rate is still found: %v", err)
+	}
+}
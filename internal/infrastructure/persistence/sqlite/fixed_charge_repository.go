@@ -5,10 +5,11 @@ import (
 	"fmt"
 	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
 	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
 )
 
 type FixedChargeRepository struct {
-	db *DB
+	db querier
 }
 
 func NewFixedChargeRepository(db *DB) *FixedChargeRepository {
@@ -17,18 +18,21 @@ func NewFixedChargeRepository(db *DB) *FixedChargeRepository {
 
 func (r *FixedChargeRepository) Save(fc fixed_charge.FixedCharge) error {
 	query := `
-		INSERT INTO fixed_charges (id, name, amount, currency, description, is_active)
-		VALUES (?, ?, ?, ?, ?, ?)
+		INSERT INTO fixed_charges (id, name, amount, currency, description, is_active, start_month, end_month, due_day)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := r.db.Exec(
 		query,
 		fc.ID(),
 		fc.Name(),
-		fc.Amount().Amount(),
+		fc.Amount().MinorUnits(),
 		fc.Amount().Currency(),
 		fc.Description(),
 		fc.IsActive(),
+		fc.StartMonth(),
+		fc.EndMonth(),
+		fc.DueDay(),
 	)
 
 	if err != nil {
@@ -40,7 +44,7 @@ func (r *FixedChargeRepository) Save(fc fixed_charge.FixedCharge) error {
 
 func (r *FixedChargeRepository) FindByID(id string) (fixed_charge.FixedCharge, error) {
 	query := `
-		SELECT id, name, amount, currency, description, is_active
+		SELECT id, name, amount, currency, description, is_active, start_month, end_month, due_day
 		FROM fixed_charges
 		WHERE id = ?
 	`
@@ -51,7 +55,7 @@ func (r *FixedChargeRepository) FindByID(id string) (fixed_charge.FixedCharge, e
 
 func (r *FixedChargeRepository) FindAll() ([]fixed_charge.FixedCharge, error) {
 	query := `
-		SELECT id, name, amount, currency, description, is_active
+		SELECT id, name, amount, currency, description, is_active, start_month, end_month, due_day
 		FROM fixed_charges
 		ORDER BY name
 	`
@@ -67,7 +71,7 @@ func (r *FixedChargeRepository) FindAll() ([]fixed_charge.FixedCharge, error) {
 
 func (r *FixedChargeRepository) FindActive() ([]fixed_charge.FixedCharge, error) {
 	query := `
-		SELECT id, name, amount, currency, description, is_active
+		SELECT id, name, amount, currency, description, is_active, start_month, end_month, due_day
 		FROM fixed_charges
 		WHERE is_active = 1
 		ORDER BY name
@@ -85,17 +89,20 @@ func (r *FixedChargeRepository) FindActive() ([]fixed_charge.FixedCharge, error)
 func (r *FixedChargeRepository) Update(fc fixed_charge.FixedCharge) error {
 	query := `
 		UPDATE fixed_charges
-		SET name = ?, amount = ?, currency = ?, description = ?, is_active = ?
+		SET name = ?, amount = ?, currency = ?, description = ?, is_active = ?, start_month = ?, end_month = ?, due_day = ?
 		WHERE id = ?
 	`
 
 	result, err := r.db.Exec(
 		query,
 		fc.Name(),
-		fc.Amount().Amount(),
+		fc.Amount().MinorUnits(),
 		fc.Amount().Currency(),
 		fc.Description(),
 		fc.IsActive(),
+		fc.StartMonth(),
+		fc.EndMonth(),
+		fc.DueDay(),
 		fc.ID(),
 	)
 
@@ -140,13 +147,16 @@ func (r *FixedChargeRepository) scanFixedCharge(row *sql.Row) (fixed_charge.Fixe
 	var (
 		id          string
 		name        string
-		amount      float64
+		amount      int64
 		currency    string
 		description string
 		isActive    bool
+		startMonth  sql.NullTime
+		endMonth    sql.NullTime
+		dueDay      int
 	)
 
-	err := row.Scan(&id, &name, &amount, &currency, &description, &isActive)
+	err := row.Scan(&id, &name, &amount, &currency, &description, &isActive, &startMonth, &endMonth, &dueDay)
 
 	if err == sql.ErrNoRows {
 		return fixed_charge.FixedCharge{}, shared.ErrNotFound
@@ -156,9 +166,10 @@ func (r *FixedChargeRepository) scanFixedCharge(row *sql.Row) (fixed_charge.Fixe
 		return fixed_charge.FixedCharge{}, fmt.Errorf("failed to scan fixed charge: %w", err)
 	}
 
-	money := shared.UnsafeNewMoney(amount)
+	money := shared.UnsafeNewMoney(amount, currency)
+	fc := fixed_charge.NewFixedCharge(id, name, money, description, isActive)
 
-	return fixed_charge.NewFixedCharge(id, name, money, description, isActive), nil
+	return fc.WithSchedule(nullTimePtr(startMonth), nullTimePtr(endMonth), dueDay)
 }
 
 func (r *FixedChargeRepository) scanFixedCharges(rows *sql.Rows) ([]fixed_charge.FixedCharge, error) {
@@ -168,19 +179,26 @@ func (r *FixedChargeRepository) scanFixedCharges(rows *sql.Rows) ([]fixed_charge
 		var (
 			id          string
 			name        string
-			amount      float64
+			amount      int64
 			currency    string
 			description string
 			isActive    bool
+			startMonth  sql.NullTime
+			endMonth    sql.NullTime
+			dueDay      int
 		)
 
-		err := rows.Scan(&id, &name, &amount, &currency, &description, &isActive)
+		err := rows.Scan(&id, &name, &amount, &currency, &description, &isActive, &startMonth, &endMonth, &dueDay)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan fixed charge: %w", err)
 		}
 
-		money := shared.UnsafeNewMoney(amount)
-		fc := fixed_charge.NewFixedCharge(id, name, money, description, isActive)
+		money := shared.UnsafeNewMoney(amount, currency)
+		fc, err := fixed_charge.NewFixedCharge(id, name, money, description, isActive).
+			WithSchedule(nullTimePtr(startMonth), nullTimePtr(endMonth), dueDay)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fixed charge %s: %w", id, err)
+		}
 		charges = append(charges, fc)
 	}
 
@@ -190,3 +208,11 @@ func (r *FixedChargeRepository) scanFixedCharges(rows *sql.Rows) ([]fixed_charge
 
 	return charges, nil
 }
+
+// nullTimePtr converts a nullable column into an optional time
+func nullTimePtr(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
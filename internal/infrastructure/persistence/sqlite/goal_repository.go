@@ -0,0 +1,136 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/goal"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+type GoalRepository struct {
+	db querier
+}
+
+func NewGoalRepository(db *DB) *GoalRepository {
+	return &GoalRepository{db: db}
+}
+
+func (r *GoalRepository) Save(g goal.Goal) error {
+	query := `
+		INSERT INTO goals (id, name, target_amount, currency, deadline, account_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		g.ID(),
+		g.Name(),
+		g.Target().MinorUnits(),
+		g.Target().Currency(),
+		g.Deadline(),
+		accountIDOrDefault(g.AccountID()),
+		g.CreatedAt(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save goal: %w", err)
+	}
+
+	return nil
+}
+
+func (r *GoalRepository) FindByID(id string) (goal.Goal, error) {
+	query := `
+		SELECT id, name, target_amount, currency, deadline, account_id, created_at
+		FROM goals
+		WHERE id = ?
+	`
+
+	goals, err := r.query(query, id)
+	if err != nil {
+		return goal.Goal{}, err
+	}
+
+	if len(goals) == 0 {
+		return goal.Goal{}, shared.ErrNotFound
+	}
+
+	return goals[0], nil
+}
+
+func (r *GoalRepository) FindAll() ([]goal.Goal, error) {
+	query := `
+		SELECT id, name, target_amount, currency, deadline, account_id, created_at
+		FROM goals
+		ORDER BY deadline IS NULL, deadline, name
+	`
+
+	return r.query(query)
+}
+
+func (r *GoalRepository) Delete(id string) error {
+	query := `DELETE FROM goals WHERE id = ?`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete goal: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *GoalRepository) query(query string, args ...interface{}) ([]goal.Goal, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goals: %w", err)
+	}
+	defer rows.Close()
+
+	var goals []goal.Goal
+
+	for rows.Next() {
+		var (
+			id           string
+			name         string
+			targetAmount int64
+			currency     string
+			deadline     sql.NullTime
+			accountID    string
+			createdAt    time.Time
+		)
+
+		err := rows.Scan(&id, &name, &targetAmount, &currency, &deadline, &accountID, &createdAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan goal: %w", err)
+		}
+
+		g, err := goal.NewGoal(
+			id,
+			name,
+			shared.UnsafeNewMoney(targetAmount, currency),
+			nullTimePtr(deadline),
+			accountID,
+			createdAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("invalid goal %s: %w", id, err)
+		}
+		goals = append(goals, g)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating goals: %w", err)
+	}
+
+	return goals, nil
+}
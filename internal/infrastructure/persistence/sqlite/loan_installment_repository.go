@@ -0,0 +1,95 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+type LoanInstallmentRepository struct {
+	db querier
+}
+
+func NewLoanInstallmentRepository(db *DB) *LoanInstallmentRepository {
+	return &LoanInstallmentRepository{db: db}
+}
+
+func (r *LoanInstallmentRepository) Save(i loan.Installment) error {
+	query := `
+		INSERT INTO loan_installments (id, loan_id, number, due_date, amount, currency)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		i.ID(),
+		i.LoanID(),
+		i.Number(),
+		i.DueDate(),
+		i.Amount().MinorUnits(),
+		i.Amount().Currency(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save loan installment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoanInstallmentRepository) FindByLoanID(loanID string) ([]loan.Installment, error) {
+	query := `
+		SELECT id, loan_id, number, due_date, amount, currency
+		FROM loan_installments
+		WHERE loan_id = ?
+		ORDER BY number
+	`
+
+	rows, err := r.db.Query(query, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query loan installments: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanInstallments(rows)
+}
+
+func (r *LoanInstallmentRepository) DeleteByLoanID(loanID string) error {
+	query := `DELETE FROM loan_installments WHERE loan_id = ?`
+
+	if _, err := r.db.Exec(query, loanID); err != nil {
+		return fmt.Errorf("failed to delete loan installments: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoanInstallmentRepository) scanInstallments(rows *sql.Rows) ([]loan.Installment, error) {
+	var installments []loan.Installment
+
+	for rows.Next() {
+		var (
+			id       string
+			loanID   string
+			number   int
+			dueDate  time.Time
+			amount   int64
+			currency string
+		)
+
+		err := rows.Scan(&id, &loanID, &number, &dueDate, &amount, &currency)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan loan installment: %w", err)
+		}
+
+		installments = append(installments, loan.NewInstallment(id, loanID, number, dueDate, shared.UnsafeNewMoney(amount, currency)))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan installments: %w", err)
+	}
+
+	return installments, nil
+}
@@ -0,0 +1,174 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+type LoanPaymentRepository struct {
+	db querier
+}
+
+func NewLoanPaymentRepository(db *DB) *LoanPaymentRepository {
+	return &LoanPaymentRepository{db: db}
+}
+
+func (r *LoanPaymentRepository) Save(p loan.Payment) error {
+	query := `
+		INSERT INTO loan_payments (id, loan_id, transaction_id, amount, currency, paid_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		p.ID(),
+		p.LoanID(),
+		nullableString(p.TransactionID()),
+		p.Amount().MinorUnits(),
+		p.Amount().Currency(),
+		p.PaidAt(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save loan payment: %w", err)
+	}
+
+	return nil
+}
+
+func (r *LoanPaymentRepository) FindByID(id string) (loan.Payment, error) {
+	query := `
+		SELECT id, loan_id, transaction_id, amount, currency, paid_at
+		FROM loan_payments
+		WHERE id = ?
+	`
+
+	row := r.db.QueryRow(query, id)
+	return r.scanPayment(row)
+}
+
+func (r *LoanPaymentRepository) FindByLoanID(loanID string) ([]loan.Payment, error) {
+	query := `
+		SELECT id, loan_id, transaction_id, amount, currency, paid_at
+		FROM loan_payments
+		WHERE loan_id = ?
+		ORDER BY paid_at
+	`
+
+	rows, err := r.db.Query(query, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query loan payments: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanPayments(rows)
+}
+
+func (r *LoanPaymentRepository) FindByTransactionID(transactionID string) (loan.Payment, error) {
+	query := `
+		SELECT id, loan_id, transaction_id, amount, currency, paid_at
+		FROM loan_payments
+		WHERE transaction_id = ?
+	`
+
+	row := r.db.QueryRow(query, transactionID)
+	return r.scanPayment(row)
+}
+
+func (r *LoanPaymentRepository) Update(p loan.Payment) error {
+	query := `
+		UPDATE loan_payments
+		SET amount = ?, currency = ?, paid_at = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.Exec(query, p.Amount().MinorUnits(), p.Amount().Currency(), p.PaidAt(), p.ID())
+	if err != nil {
+		return fmt.Errorf("failed to update loan payment: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *LoanPaymentRepository) Delete(id string) error {
+	query := `DELETE FROM loan_payments WHERE id = ?`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete loan payment: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *LoanPaymentRepository) scanPayment(row *sql.Row) (loan.Payment, error) {
+	var (
+		id            string
+		loanID        string
+		transactionID sql.NullString
+		amount        int64
+		currency      string
+		paidAt        time.Time
+	)
+
+	err := row.Scan(&id, &loanID, &transactionID, &amount, &currency, &paidAt)
+
+	if err == sql.ErrNoRows {
+		return loan.Payment{}, shared.ErrNotFound
+	}
+
+	if err != nil {
+		return loan.Payment{}, fmt.Errorf("failed to scan loan payment: %w", err)
+	}
+
+	return loan.NewPayment(id, loanID, transactionID.String, shared.UnsafeNewMoney(amount, currency), paidAt), nil
+}
+
+func (r *LoanPaymentRepository) scanPayments(rows *sql.Rows) ([]loan.Payment, error) {
+	var payments []loan.Payment
+
+	for rows.Next() {
+		var (
+			id            string
+			loanID        string
+			transactionID sql.NullString
+			amount        int64
+			currency      string
+			paidAt        time.Time
+		)
+
+		err := rows.Scan(&id, &loanID, &transactionID, &amount, &currency, &paidAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan loan payment: %w", err)
+		}
+
+		payments = append(payments, loan.NewPayment(id, loanID, transactionID.String, shared.UnsafeNewMoney(amount, currency), paidAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating loan payments: %w", err)
+	}
+
+	return payments, nil
+}
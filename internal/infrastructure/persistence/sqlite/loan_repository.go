@@ -9,7 +9,7 @@ import (
 )
 
 type LoanRepository struct {
-	db *DB
+	db querier
 }
 
 func NewLoanRepository(db *DB) *LoanRepository {
@@ -18,8 +18,8 @@ func NewLoanRepository(db *DB) *LoanRepository {
 
 func (r *LoanRepository) Save(l loan.Loan) error {
 	query := `
-		INSERT INTO loans (id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO loans (id, lender_name, direction, amount, amount_paid, currency, borrowed_at, paid_back_at, due_date, status, description)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	var paidBackAt *time.Time
@@ -31,11 +31,13 @@ func (r *LoanRepository) Save(l loan.Loan) error {
 		query,
 		l.ID(),
 		l.LenderName(),
-		l.Amount().Amount(),
-		l.AmountPaid().Amount(),
+		string(l.Direction()),
+		l.Amount().MinorUnits(),
+		l.AmountPaid().MinorUnits(),
 		l.Amount().Currency(),
 		l.BorrowedAt(),
 		paidBackAt,
+		l.DueDate(),
 		string(l.Status()),
 		l.Description(),
 	)
@@ -49,7 +51,7 @@ func (r *LoanRepository) Save(l loan.Loan) error {
 
 func (r *LoanRepository) FindByID(id string) (loan.Loan, error) {
 	query := `
-		SELECT id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description
+		SELECT id, lender_name, direction, amount, amount_paid, currency, borrowed_at, paid_back_at, due_date, status, description
 		FROM loans
 		WHERE id = ?
 	`
@@ -60,7 +62,7 @@ func (r *LoanRepository) FindByID(id string) (loan.Loan, error) {
 
 func (r *LoanRepository) FindAll() ([]loan.Loan, error) {
 	query := `
-		SELECT id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description
+		SELECT id, lender_name, direction, amount, amount_paid, currency, borrowed_at, paid_back_at, due_date, status, description
 		FROM loans
 		ORDER BY borrowed_at DESC
 	`
@@ -76,7 +78,7 @@ func (r *LoanRepository) FindAll() ([]loan.Loan, error) {
 
 func (r *LoanRepository) FindActive() ([]loan.Loan, error) {
 	query := `
-		SELECT id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description
+		SELECT id, lender_name, direction, amount, amount_paid, currency, borrowed_at, paid_back_at, due_date, status, description
 		FROM loans
 		WHERE status = 'active'
 		ORDER BY borrowed_at DESC
@@ -93,7 +95,7 @@ func (r *LoanRepository) FindActive() ([]loan.Loan, error) {
 
 func (r *LoanRepository) FindByStatus(status loan.LoanStatus) ([]loan.Loan, error) {
 	query := `
-		SELECT id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description
+		SELECT id, lender_name, direction, amount, amount_paid, currency, borrowed_at, paid_back_at, due_date, status, description
 		FROM loans
 		WHERE status = ?
 		ORDER BY borrowed_at DESC
@@ -111,7 +113,7 @@ func (r *LoanRepository) FindByStatus(status loan.LoanStatus) ([]loan.Loan, erro
 func (r *LoanRepository) Update(l loan.Loan) error {
 	query := `
 		UPDATE loans
-		SET lender_name = ?, amount = ?, amount_paid = ?, currency = ?, borrowed_at = ?, paid_back_at = ?, status = ?, description = ?
+		SET lender_name = ?, amount = ?, amount_paid = ?, currency = ?, borrowed_at = ?, paid_back_at = ?, due_date = ?, status = ?, description = ?
 		WHERE id = ?
 	`
 
@@ -123,11 +125,12 @@ func (r *LoanRepository) Update(l loan.Loan) error {
 	result, err := r.db.Exec(
 		query,
 		l.LenderName(),
-		l.Amount().Amount(),
-		l.AmountPaid().Amount(),
+		l.Amount().MinorUnits(),
+		l.AmountPaid().MinorUnits(),
 		l.Amount().Currency(),
 		l.BorrowedAt(),
 		paidBackAt,
+		l.DueDate(),
 		string(l.Status()),
 		l.Description(),
 		l.ID(),
@@ -173,11 +176,13 @@ func (r *LoanRepository) scanLoan(row *sql.Row) (loan.Loan, error) {
 	var (
 		id            string
 		lenderName    string
-		amount        float64
-		amountPaid    float64
+		direction     string
+		amount        int64
+		amountPaid    int64
 		currency      string
 		borrowedAt    time.Time
 		paidBackAt    sql.NullTime
+		dueDate       sql.NullTime
 		status        string
 		description   string
 	)
@@ -185,11 +190,13 @@ func (r *LoanRepository) scanLoan(row *sql.Row) (loan.Loan, error) {
 	err := row.Scan(
 		&id,
 		&lenderName,
+		&direction,
 		&amount,
 		&amountPaid,
 		&currency,
 		&borrowedAt,
 		&paidBackAt,
+		&dueDate,
 		&status,
 		&description,
 	)
@@ -202,21 +209,7 @@ func (r *LoanRepository) scanLoan(row *sql.Row) (loan.Loan, error) {
 		return loan.Loan{}, fmt.Errorf("failed to scan loan: %w", err)
 	}
 
-	amountMoney := shared.UnsafeNewMoney(amount)
-	amountPaidMoney := shared.UnsafeNewMoney(amountPaid)
-
-	l := loan.NewLoan(id, lenderName, amountMoney, borrowedAt, description)
-
-	if amountPaid > 0 {
-		paymentAmount := amountPaidMoney
-		paymentTime := borrowedAt
-		if paidBackAt.Valid {
-			paymentTime = paidBackAt.Time
-		}
-		l = l.RecordPayment(paymentAmount, paymentTime)
-	}
-
-	return l, nil
+	return r.buildLoan(id, lenderName, direction, amount, amountPaid, currency, borrowedAt, paidBackAt, dueDate, status, description), nil
 }
 
 func (r *LoanRepository) scanLoans(rows *sql.Rows) ([]loan.Loan, error) {
@@ -226,11 +219,13 @@ func (r *LoanRepository) scanLoans(rows *sql.Rows) ([]loan.Loan, error) {
 		var (
 			id            string
 			lenderName    string
-			amount        float64
-			amountPaid    float64
+			direction     string
+			amount        int64
+			amountPaid    int64
 			currency      string
 			borrowedAt    time.Time
 			paidBackAt    sql.NullTime
+			dueDate       sql.NullTime
 			status        string
 			description   string
 		)
@@ -238,11 +233,13 @@ func (r *LoanRepository) scanLoans(rows *sql.Rows) ([]loan.Loan, error) {
 		err := rows.Scan(
 			&id,
 			&lenderName,
+			&direction,
 			&amount,
 			&amountPaid,
 			&currency,
 			&borrowedAt,
 			&paidBackAt,
+			&dueDate,
 			&status,
 			&description,
 		)
@@ -251,20 +248,7 @@ func (r *LoanRepository) scanLoans(rows *sql.Rows) ([]loan.Loan, error) {
 			return nil, fmt.Errorf("failed to scan loan: %w", err)
 		}
 
-		amountMoney := shared.UnsafeNewMoney(amount)
-		amountPaidMoney := shared.UnsafeNewMoney(amountPaid)
-
-		l := loan.NewLoan(id, lenderName, amountMoney, borrowedAt, description)
-
-		if amountPaid > 0 {
-			paymentAmount := amountPaidMoney
-			paymentTime := borrowedAt
-			if paidBackAt.Valid {
-				paymentTime = paidBackAt.Time
-			}
-			l = l.RecordPayment(paymentAmount, paymentTime)
-		}
-
+		l := r.buildLoan(id, lenderName, direction, amount, amountPaid, currency, borrowedAt, paidBackAt, dueDate, status, description)
 		loans = append(loans, l)
 	}
 
@@ -274,3 +258,37 @@ func (r *LoanRepository) scanLoans(rows *sql.Rows) ([]loan.Loan, error) {
 
 	return loans, nil
 }
+
+func (r *LoanRepository) buildLoan(
+	id, lenderName, direction string,
+	amount, amountPaid int64,
+	currency string,
+	borrowedAt time.Time,
+	paidBackAt sql.NullTime,
+	dueDate sql.NullTime,
+	status string,
+	description string,
+) loan.Loan {
+	var paidBack *time.Time
+	if paidBackAt.Valid {
+		paidBack = &paidBackAt.Time
+	}
+
+	var due *time.Time
+	if dueDate.Valid {
+		due = &dueDate.Time
+	}
+
+	return loan.RestoreLoan(
+		id,
+		lenderName,
+		loan.LoanDirection(direction),
+		shared.UnsafeNewMoney(amount, currency),
+		shared.UnsafeNewMoney(amountPaid, currency),
+		borrowedAt,
+		paidBack,
+		due,
+		loan.LoanStatus(status),
+		description,
+	)
+}
@@ -0,0 +1,120 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+type ReconciliationRepository struct {
+	db querier
+}
+
+func NewReconciliationRepository(db *DB) *ReconciliationRepository {
+	return &ReconciliationRepository{db: db}
+}
+
+func (r *ReconciliationRepository) Save(rec account.Reconciliation) error {
+	query := `
+		INSERT INTO reconciliations (id, account_id, statement_date, statement_balance, currency, computed_balance, adjustment_transaction_id, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		rec.ID(),
+		rec.AccountID(),
+		rec.StatementDate(),
+		rec.StatementBalance().MinorUnits(),
+		rec.StatementBalance().Currency(),
+		rec.ComputedBalance().MinorUnits(),
+		nullableString(rec.AdjustmentTransactionID()),
+		rec.CreatedAt(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save reconciliation: %w", err)
+	}
+
+	return nil
+}
+
+func (r *ReconciliationRepository) FindLatest() ([]account.Reconciliation, error) {
+	query := `
+		SELECT id, account_id, statement_date, statement_balance, currency, computed_balance, adjustment_transaction_id, created_at
+		FROM reconciliations rec
+		WHERE id = (
+			SELECT id FROM reconciliations
+			WHERE account_id = rec.account_id
+			ORDER BY statement_date DESC, created_at DESC
+			LIMIT 1
+		)
+	`
+
+	return r.query(query)
+}
+
+func (r *ReconciliationRepository) FindAll() ([]account.Reconciliation, error) {
+	query := `
+		SELECT id, account_id, statement_date, statement_balance, currency, computed_balance, adjustment_transaction_id, created_at
+		FROM reconciliations
+		ORDER BY created_at
+	`
+
+	return r.query(query)
+}
+
+func (r *ReconciliationRepository) query(query string) ([]account.Reconciliation, error) {
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reconciliations: %w", err)
+	}
+	defer rows.Close()
+
+	var reconciliations []account.Reconciliation
+
+	for rows.Next() {
+		var (
+			id                      string
+			accountID               string
+			statementDate           time.Time
+			statementBalance        int64
+			currency                string
+			computedBalance         int64
+			adjustmentTransactionID sql.NullString
+			createdAt               time.Time
+		)
+
+		err := rows.Scan(
+			&id,
+			&accountID,
+			&statementDate,
+			&statementBalance,
+			&currency,
+			&computedBalance,
+			&adjustmentTransactionID,
+			&createdAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reconciliation: %w", err)
+		}
+
+		reconciliations = append(reconciliations, account.NewReconciliation(
+			id,
+			accountID,
+			statementDate,
+			shared.UnsafeNewMoney(statementBalance, currency),
+			shared.UnsafeNewMoney(computedBalance, currency),
+			adjustmentTransactionID.String,
+			createdAt,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reconciliations: %w", err)
+	}
+
+	return reconciliations, nil
+}
@@ -0,0 +1,88 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/recurring"
+	"time"
+)
+
+type RecurringOccurrenceRepository struct {
+	db querier
+}
+
+func NewRecurringOccurrenceRepository(db *DB) *RecurringOccurrenceRepository {
+	return &RecurringOccurrenceRepository{db: db}
+}
+
+func (r *RecurringOccurrenceRepository) Save(o recurring.Occurrence) error {
+	query := `
+		INSERT INTO recurring_occurrences (id, rule_id, due_date, transaction_id)
+		VALUES (?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(query, o.ID(), o.RuleID(), o.DueDate(), nullableString(o.TransactionID()))
+	if err != nil {
+		return fmt.Errorf("failed to save recurring occurrence: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecurringOccurrenceRepository) FindByRuleID(ruleID string) ([]recurring.Occurrence, error) {
+	query := `
+		SELECT id, rule_id, due_date, transaction_id
+		FROM recurring_occurrences
+		WHERE rule_id = ?
+		ORDER BY due_date
+	`
+
+	rows, err := r.db.Query(query, ruleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurring occurrences: %w", err)
+	}
+	defer rows.Close()
+
+	var occurrences []recurring.Occurrence
+
+	for rows.Next() {
+		var (
+			id            string
+			occRuleID     string
+			dueDate       time.Time
+			transactionID sql.NullString
+		)
+
+		if err := rows.Scan(&id, &occRuleID, &dueDate, &transactionID); err != nil {
+			return nil, fmt.Errorf("failed to scan recurring occurrence: %w", err)
+		}
+
+		occurrences = append(occurrences, recurring.NewOccurrence(id, occRuleID, dueDate, transactionID.String))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recurring occurrences: %w", err)
+	}
+
+	return occurrences, nil
+}
+
+func (r *RecurringOccurrenceRepository) DeleteByRuleID(ruleID string) error {
+	query := `DELETE FROM recurring_occurrences WHERE rule_id = ?`
+
+	if _, err := r.db.Exec(query, ruleID); err != nil {
+		return fmt.Errorf("failed to delete recurring occurrences: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecurringOccurrenceRepository) UnlinkTransaction(transactionID string) error {
+	query := `UPDATE recurring_occurrences SET transaction_id = NULL WHERE transaction_id = ?`
+
+	if _, err := r.db.Exec(query, transactionID); err != nil {
+		return fmt.Errorf("failed to unlink recurring occurrence: %w", err)
+	}
+
+	return nil
+}
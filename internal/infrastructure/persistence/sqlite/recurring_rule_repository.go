@@ -0,0 +1,260 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/recurring"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"time"
+)
+
+type RecurringRuleRepository struct {
+	db querier
+}
+
+func NewRecurringRuleRepository(db *DB) *RecurringRuleRepository {
+	return &RecurringRuleRepository{db: db}
+}
+
+func (r *RecurringRuleRepository) Save(rule recurring.Rule) error {
+	query := `
+		INSERT INTO recurring_rules (id, description, amount, currency, category_name, category_type, frequency, interval_count, day_of_month, start_date, end_date, is_active)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(
+		query,
+		rule.ID(),
+		rule.Description(),
+		rule.Amount().MinorUnits(),
+		rule.Amount().Currency(),
+		rule.Category().Name(),
+		string(rule.Category().Type()),
+		string(rule.Frequency()),
+		rule.Interval(),
+		rule.DayOfMonth(),
+		rule.StartDate(),
+		rule.EndDate(),
+		rule.IsActive(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to save recurring rule: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RecurringRuleRepository) FindByID(id string) (recurring.Rule, error) {
+	query := `
+		SELECT id, description, amount, currency, category_name, category_type, frequency, interval_count, day_of_month, start_date, end_date, is_active
+		FROM recurring_rules
+		WHERE id = ?
+	`
+
+	row := r.db.QueryRow(query, id)
+	return r.scanRule(row)
+}
+
+func (r *RecurringRuleRepository) FindAll() ([]recurring.Rule, error) {
+	query := `
+		SELECT id, description, amount, currency, category_name, category_type, frequency, interval_count, day_of_month, start_date, end_date, is_active
+		FROM recurring_rules
+		ORDER BY description
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recurring rules: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanRules(rows)
+}
+
+func (r *RecurringRuleRepository) FindActive() ([]recurring.Rule, error) {
+	query := `
+		SELECT id, description, amount, currency, category_name, category_type, frequency, interval_count, day_of_month, start_date, end_date, is_active
+		FROM recurring_rules
+		WHERE is_active = 1
+		ORDER BY description
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query active recurring rules: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanRules(rows)
+}
+
+func (r *RecurringRuleRepository) Update(rule recurring.Rule) error {
+	query := `
+		UPDATE recurring_rules
+		SET description = ?, amount = ?, currency = ?, category_name = ?, category_type = ?, frequency = ?,
+		    interval_count = ?, day_of_month = ?, start_date = ?, end_date = ?, is_active = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.Exec(
+		query,
+		rule.Description(),
+		rule.Amount().MinorUnits(),
+		rule.Amount().Currency(),
+		rule.Category().Name(),
+		string(rule.Category().Type()),
+		string(rule.Frequency()),
+		rule.Interval(),
+		rule.DayOfMonth(),
+		rule.StartDate(),
+		rule.EndDate(),
+		rule.IsActive(),
+		rule.ID(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update recurring rule: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RecurringRuleRepository) Delete(id string) error {
+	query := `DELETE FROM recurring_rules WHERE id = ?`
+
+	result, err := r.db.Exec(query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete recurring rule: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RecurringRuleRepository) scanRule(row *sql.Row) (recurring.Rule, error) {
+	var (
+		id           string
+		description  string
+		amount       int64
+		currency     string
+		categoryName string
+		categoryType string
+		frequency    string
+		interval     int
+		dayOfMonth   int
+		startDate    time.Time
+		endDate      sql.NullTime
+		isActive     bool
+	)
+
+	err := row.Scan(&id, &description, &amount, &currency, &categoryName, &categoryType, &frequency, &interval, &dayOfMonth, &startDate, &endDate, &isActive)
+
+	if err == sql.ErrNoRows {
+		return recurring.Rule{}, shared.ErrNotFound
+	}
+
+	if err != nil {
+		return recurring.Rule{}, fmt.Errorf("failed to scan recurring rule: %w", err)
+	}
+
+	return buildRule(id, description, amount, currency, categoryName, categoryType, frequency, interval, dayOfMonth, startDate, endDate, isActive)
+}
+
+func (r *RecurringRuleRepository) scanRules(rows *sql.Rows) ([]recurring.Rule, error) {
+	var rules []recurring.Rule
+
+	for rows.Next() {
+		var (
+			id           string
+			description  string
+			amount       int64
+			currency     string
+			categoryName string
+			categoryType string
+			frequency    string
+			interval     int
+			dayOfMonth   int
+			startDate    time.Time
+			endDate      sql.NullTime
+			isActive     bool
+		)
+
+		err := rows.Scan(&id, &description, &amount, &currency, &categoryName, &categoryType, &frequency, &interval, &dayOfMonth, &startDate, &endDate, &isActive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan recurring rule: %w", err)
+		}
+
+		rule, err := buildRule(id, description, amount, currency, categoryName, categoryType, frequency, interval, dayOfMonth, startDate, endDate, isActive)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recurring rules: %w", err)
+	}
+
+	return rules, nil
+}
+
+func buildRule(
+	id string,
+	description string,
+	amount int64,
+	currency string,
+	categoryName string,
+	categoryType string,
+	frequency string,
+	interval int,
+	dayOfMonth int,
+	startDate time.Time,
+	endDate sql.NullTime,
+	isActive bool,
+) (recurring.Rule, error) {
+	category, err := shared.NewCategory(categoryName, shared.CategoryType(categoryType))
+	if err != nil {
+		return recurring.Rule{}, fmt.Errorf("invalid category on recurring rule %s: %w", id, err)
+	}
+
+	var end *time.Time
+	if endDate.Valid {
+		end = &endDate.Time
+	}
+
+	rule, err := recurring.NewRule(
+		id,
+		description,
+		shared.UnsafeNewMoney(amount, currency),
+		category,
+		recurring.Frequency(frequency),
+		interval,
+		dayOfMonth,
+		startDate,
+		end,
+		isActive,
+	)
+	if err != nil {
+		return recurring.Rule{}, fmt.Errorf("invalid recurring rule %s: %w", id, err)
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,58 @@
+package sqlite
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+)
+
+type StatementAccountRepository struct {
+	db querier
+}
+
+func NewStatementAccountRepository(db *DB) *StatementAccountRepository {
+	return &StatementAccountRepository{db: db}
+}
+
+func (r *StatementAccountRepository) Save(s account.StatementAccount) error {
+	query := `
+		INSERT INTO statement_accounts (number, account_id)
+		VALUES (?, ?)
+		ON CONFLICT(number) DO UPDATE SET account_id = excluded.account_id
+	`
+
+	if _, err := r.db.Exec(query, s.Number(), s.AccountID()); err != nil {
+		return fmt.Errorf("failed to save statement account: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StatementAccountRepository) FindAll() ([]account.StatementAccount, error) {
+	query := `SELECT number, account_id FROM statement_accounts ORDER BY number`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query statement accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var statementAccounts []account.StatementAccount
+
+	for rows.Next() {
+		var number, accountID string
+		if err := rows.Scan(&number, &accountID); err != nil {
+			return nil, fmt.Errorf("failed to scan statement account: %w", err)
+		}
+		statementAccount, err := account.NewStatementAccount(number, accountID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load statement account: %w", err)
+		}
+		statementAccounts = append(statementAccounts, statementAccount)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating statement accounts: %w", err)
+	}
+
+	return statementAccounts, nil
+}
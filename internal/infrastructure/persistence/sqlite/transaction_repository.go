@@ -3,13 +3,14 @@ package sqlite
 import (
 	"database/sql"
 	"fmt"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
 	"github.com/aymaneelmaini/moka/internal/domain/transaction"
 	"github.com/aymaneelmaini/moka/internal/shared"
 	"time"
 )
 
 type TransactionRepository struct {
-	db *DB
+	db querier
 }
 
 func NewTransactionRepository(db *DB) *TransactionRepository {
@@ -18,20 +19,27 @@ func NewTransactionRepository(db *DB) *TransactionRepository {
 
 func (r *TransactionRepository) Save(tx transaction.Transaction) error {
 	query := `
-		INSERT INTO transactions (id, amount, currency, category_name, category_type, description, type, created_at)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+		INSERT INTO transactions (id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id, account_id, transfer_id, reconciliation_id, goal_id, fitid)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	_, err := r.db.Exec(
 		query,
 		tx.ID(),
-		tx.Amount().Amount(),
+		tx.Amount().MinorUnits(),
 		tx.Amount().Currency(),
 		tx.Category().Name(),
 		string(tx.Category().Type()),
 		tx.Description(),
 		string(tx.Type()),
 		tx.CreatedAt(),
+		nullableString(tx.LoanID()),
+		nullableString(tx.FixedChargeID()),
+		accountIDOrDefault(tx.AccountID()),
+		nullableString(tx.TransferID()),
+		nullableString(tx.ReconciliationID()),
+		nullableString(tx.GoalID()),
+		nullableString(tx.FITID()),
 	)
 
 	if err != nil {
@@ -43,7 +51,7 @@ func (r *TransactionRepository) Save(tx transaction.Transaction) error {
 
 func (r *TransactionRepository) FindByID(id string) (transaction.Transaction, error) {
 	query := `
-		SELECT id, amount, currency, category_name, category_type, description, type, created_at
+		SELECT id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id, account_id, transfer_id, reconciliation_id, goal_id, fitid
 		FROM transactions
 		WHERE id = ?
 	`
@@ -54,7 +62,7 @@ func (r *TransactionRepository) FindByID(id string) (transaction.Transaction, er
 
 func (r *TransactionRepository) FindAll() ([]transaction.Transaction, error) {
 	query := `
-		SELECT id, amount, currency, category_name, category_type, description, type, created_at
+		SELECT id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id, account_id, transfer_id, reconciliation_id, goal_id, fitid
 		FROM transactions
 		ORDER BY created_at DESC
 	`
@@ -70,7 +78,7 @@ func (r *TransactionRepository) FindAll() ([]transaction.Transaction, error) {
 
 func (r *TransactionRepository) FindByDateRange(start, end time.Time) ([]transaction.Transaction, error) {
 	query := `
-		SELECT id, amount, currency, category_name, category_type, description, type, created_at
+		SELECT id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id, account_id, transfer_id, reconciliation_id, goal_id, fitid
 		FROM transactions
 		WHERE created_at >= ? AND created_at <= ?
 		ORDER BY created_at DESC
@@ -92,6 +100,185 @@ func (r *TransactionRepository) FindByMonth(year int, month time.Month) ([]trans
 	return r.FindByDateRange(start, end)
 }
 
+func (r *TransactionRepository) FindByLoanID(loanID string) ([]transaction.Transaction, error) {
+	query := `
+		SELECT id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id, account_id, transfer_id, reconciliation_id, goal_id, fitid
+		FROM transactions
+		WHERE loan_id = ?
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, loanID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query loan transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransactions(rows)
+}
+
+func (r *TransactionRepository) FindByTransferID(transferID string) ([]transaction.Transaction, error) {
+	query := `
+		SELECT id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id, account_id, transfer_id, reconciliation_id, goal_id, fitid
+		FROM transactions
+		WHERE transfer_id = ?
+		ORDER BY type DESC
+	`
+
+	rows, err := r.db.Query(query, transferID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query transfer transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransactions(rows)
+}
+
+func (r *TransactionRepository) FindByAccountID(accountID string, until time.Time) ([]transaction.Transaction, error) {
+	query := `
+		SELECT id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id, account_id, transfer_id, reconciliation_id, goal_id, fitid
+		FROM transactions
+		WHERE account_id = ? AND created_at <= ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, accountIDOrDefault(accountID), until)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransactions(rows)
+}
+
+func (r *TransactionRepository) FindByGoalID(goalID string) ([]transaction.Transaction, error) {
+	query := `
+		SELECT id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id, account_id, transfer_id, reconciliation_id, goal_id, fitid
+		FROM transactions
+		WHERE goal_id = ?
+		ORDER BY created_at
+	`
+
+	rows, err := r.db.Query(query, goalID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query goal transactions: %w", err)
+	}
+	defer rows.Close()
+
+	return r.scanTransactions(rows)
+}
+
+func (r *TransactionRepository) FindByFITID(accountID, fitID string) (transaction.Transaction, error) {
+	query := `
+		SELECT id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id, account_id, transfer_id, reconciliation_id, goal_id, fitid
+		FROM transactions
+		WHERE account_id = ? AND fitid = ?
+	`
+
+	row := r.db.QueryRow(query, accountIDOrDefault(accountID), fitID)
+	return r.scanTransaction(row)
+}
+
+func (r *TransactionRepository) Update(tx transaction.Transaction) error {
+	query := `
+		UPDATE transactions
+		SET amount = ?, currency = ?, category_name = ?, category_type = ?, description = ?, created_at = ?, loan_id = ?, fixed_charge_id = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.Exec(
+		query,
+		tx.Amount().MinorUnits(),
+		tx.Amount().Currency(),
+		tx.Category().Name(),
+		string(tx.Category().Type()),
+		tx.Description(),
+		tx.CreatedAt(),
+		nullableString(tx.LoanID()),
+		nullableString(tx.FixedChargeID()),
+		tx.ID(),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to update transaction: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *TransactionRepository) UnlinkFixedCharge(fixedChargeID string) error {
+	query := `UPDATE transactions SET fixed_charge_id = NULL WHERE fixed_charge_id = ?`
+
+	if _, err := r.db.Exec(query, fixedChargeID); err != nil {
+		return fmt.Errorf("failed to unlink fixed charge transactions: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TransactionRepository) UnlinkGoal(goalID string) error {
+	query := `UPDATE transactions SET goal_id = NULL WHERE goal_id = ?`
+
+	if _, err := r.db.Exec(query, goalID); err != nil {
+		return fmt.Errorf("failed to unlink goal transactions: %w", err)
+	}
+
+	return nil
+}
+
+func (r *TransactionRepository) MarkReconciled(accountID string, until time.Time, reconciliationID string) (int, error) {
+	query := `
+		UPDATE transactions
+		SET reconciliation_id = ?
+		WHERE account_id = ? AND created_at <= ? AND reconciliation_id IS NULL
+	`
+
+	result, err := r.db.Exec(query, reconciliationID, accountIDOrDefault(accountID), until)
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock reconciled transactions: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rows), nil
+}
+
+func (r *TransactionRepository) Lock(id, reconciliationID string) error {
+	query := `
+		UPDATE transactions
+		SET reconciliation_id = ?
+		WHERE id = ?
+	`
+
+	result, err := r.db.Exec(query, reconciliationID, id)
+	if err != nil {
+		return fmt.Errorf("failed to lock transaction: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rows == 0 {
+		return shared.ErrNotFound
+	}
+
+	return nil
+}
+
 func (r *TransactionRepository) Delete(id string) error {
 	query := `DELETE FROM transactions WHERE id = ?`
 
@@ -112,16 +299,32 @@ func (r *TransactionRepository) Delete(id string) error {
 	return nil
 }
 
+// accountIDOrDefault books transactions recorded without an account on the
+// default account
+func accountIDOrDefault(accountID string) string {
+	if accountID == "" {
+		return account.DefaultAccountID
+	}
+	return accountID
+}
+
 func (r *TransactionRepository) scanTransaction(row *sql.Row) (transaction.Transaction, error) {
 	var (
-		id           string
-		amount       float64
-		currency     string
-		categoryName string
-		categoryType string
-		description  string
-		txType       string
-		createdAt    time.Time
+		id               string
+		amount           int64
+		currency         string
+		categoryName     string
+		categoryType     string
+		description      string
+		txType           string
+		createdAt        time.Time
+		loanID           sql.NullString
+		fixedChargeID    sql.NullString
+		accountID        string
+		transferID       sql.NullString
+		reconciliationID sql.NullString
+		goalID           sql.NullString
+		fitID            sql.NullString
 	)
 
 	err := row.Scan(
@@ -133,6 +336,13 @@ func (r *TransactionRepository) scanTransaction(row *sql.Row) (transaction.Trans
 		&description,
 		&txType,
 		&createdAt,
+		&loanID,
+		&fixedChargeID,
+		&accountID,
+		&transferID,
+		&reconciliationID,
+		&goalID,
+		&fitID,
 	)
 
 	if err == sql.ErrNoRows {
@@ -143,7 +353,7 @@ func (r *TransactionRepository) scanTransaction(row *sql.Row) (transaction.Trans
 		return transaction.Transaction{}, fmt.Errorf("failed to scan transaction: %w", err)
 	}
 
-	money := shared.UnsafeNewMoney(amount)
+	money := shared.UnsafeNewMoney(amount, currency)
 	category, _ := shared.NewCategory(categoryName, shared.CategoryType(categoryType))
 
 	return transaction.NewTransaction(
@@ -153,7 +363,13 @@ func (r *TransactionRepository) scanTransaction(row *sql.Row) (transaction.Trans
 		description,
 		transaction.TransactionType(txType),
 		createdAt,
-	), nil
+	).WithLoanID(loanID.String).
+		WithFixedChargeID(fixedChargeID.String).
+		WithAccountID(accountID).
+		WithTransferID(transferID.String).
+		WithReconciliationID(reconciliationID.String).
+		WithGoalID(goalID.String).
+		WithFITID(fitID.String), nil
 }
 
 func (r *TransactionRepository) scanTransactions(rows *sql.Rows) ([]transaction.Transaction, error) {
@@ -161,14 +377,21 @@ func (r *TransactionRepository) scanTransactions(rows *sql.Rows) ([]transaction.
 
 	for rows.Next() {
 		var (
-			id           string
-			amount       float64
-			currency     string
-			categoryName string
-			categoryType string
-			description  string
-			txType       string
-			createdAt    time.Time
+			id               string
+			amount           int64
+			currency         string
+			categoryName     string
+			categoryType     string
+			description      string
+			txType           string
+			createdAt        time.Time
+			loanID           sql.NullString
+			fixedChargeID    sql.NullString
+			accountID        string
+			transferID       sql.NullString
+			reconciliationID sql.NullString
+			goalID           sql.NullString
+			fitID            sql.NullString
 		)
 
 		err := rows.Scan(
@@ -180,13 +403,20 @@ func (r *TransactionRepository) scanTransactions(rows *sql.Rows) ([]transaction.
 			&description,
 			&txType,
 			&createdAt,
+			&loanID,
+			&fixedChargeID,
+			&accountID,
+			&transferID,
+			&reconciliationID,
+			&goalID,
+			&fitID,
 		)
 
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan transaction: %w", err)
 		}
 
-		money := shared.UnsafeNewMoney(amount)
+		money := shared.UnsafeNewMoney(amount, currency)
 		category, _ := shared.NewCategory(categoryName, shared.CategoryType(categoryType))
 
 		tx := transaction.NewTransaction(
@@ -196,7 +426,13 @@ func (r *TransactionRepository) scanTransactions(rows *sql.Rows) ([]transaction.
 			description,
 			transaction.TransactionType(txType),
 			createdAt,
-		)
+		).WithLoanID(loanID.String).
+			WithFixedChargeID(fixedChargeID.String).
+			WithAccountID(accountID).
+			WithTransferID(transferID.String).
+			WithReconciliationID(reconciliationID.String).
+			WithGoalID(goalID.String).
+			WithFITID(fitID.String)
 
 		transactions = append(transactions, tx)
 	}
@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/application"
+)
+
+type UnitOfWork struct {
+	db *DB
+}
+
+func NewUnitOfWork(db *DB) *UnitOfWork {
+	return &UnitOfWork{db: db}
+}
+
+// Do runs fn inside a single SQL transaction
+func (u *UnitOfWork) Do(fn func(repos application.Repositories) error) error {
+	tx, err := u.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	repos := application.Repositories{
+		Transactions:         &TransactionRepository{db: tx},
+		Budgets:              &BudgetRepository{db: tx},
+		FixedCharges:         &FixedChargeRepository{db: tx},
+		Loans:                &LoanRepository{db: tx},
+		LoanPayments:         &LoanPaymentRepository{db: tx},
+		LoanInstallments:     &LoanInstallmentRepository{db: tx},
+		RecurringRules:       &RecurringRuleRepository{db: tx},
+		RecurringOccurrences: &RecurringOccurrenceRepository{db: tx},
+		Accounts:             &AccountRepository{db: tx},
+		Reconciliations:      &ReconciliationRepository{db: tx},
+		Goals:                &GoalRepository{db: tx},
+		Categories:           &CategoryRepository{db: tx},
+		CategoryRules:        &CategoryRuleRepository{db: tx},
+		ExchangeRates:        &ExchangeRateRepository{db: tx},
+		Dismissals:           &DismissalRepository{db: tx},
+		StatementAccounts:    &StatementAccountRepository{db: tx},
+	}
+
+	if err := fn(repos); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package scheduler
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Job is run by the scheduler with the time of the run
+type Job func(at time.Time) error
+
+// Scheduler runs a job once at start-up, to catch up on anything missed
+// while the server was down, and then at a fixed interval
+type Scheduler struct {
+	name     string
+	interval time.Duration
+	job      Job
+}
+
+func NewScheduler(name string, interval time.Duration, job Job) *Scheduler {
+	return &Scheduler{
+		name:     name,
+		interval: interval,
+		job:      job,
+	}
+}
+
+// Run blocks until ctx is cancelled. Failed runs are logged and retried at
+// the next tick.
+func (s *Scheduler) Run(ctx context.Context) {
+	s.runOnce(time.Now())
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case at := <-ticker.C:
+			s.runOnce(at)
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(at time.Time) {
+	if err := s.job(at); err != nil {
+		log.Printf("Scheduled %s failed: %v", s.name, err)
+	}
+}
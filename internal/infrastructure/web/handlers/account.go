@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"html/template"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/domain/account"
+	"net/http"
+	"time"
+)
+
+// accountsChangedEvent tells the account selects on the page to reload
+const accountsChangedEvent = "accountsChanged"
+
+type AccountHandler struct {
+	createAccountUC *application.CreateAccountUseCase
+	updateAccountUC *application.UpdateAccountUseCase
+	transferMoneyUC *application.TransferMoneyUseCase
+	reconcileUC     *application.ReconcileAccountUseCase
+	accountRepo     account.Repository
+	reconciliations account.ReconciliationRepository
+	templates       *template.Template
+}
+
+func NewAccountHandler(
+	createAccountUC *application.CreateAccountUseCase,
+	updateAccountUC *application.UpdateAccountUseCase,
+	transferMoneyUC *application.TransferMoneyUseCase,
+	reconcileUC *application.ReconcileAccountUseCase,
+	accountRepo account.Repository,
+	reconciliations account.ReconciliationRepository,
+	templates *template.Template,
+) *AccountHandler {
+	return &AccountHandler{
+		createAccountUC: createAccountUC,
+		updateAccountUC: updateAccountUC,
+		transferMoneyUC: transferMoneyUC,
+		reconcileUC:     reconcileUC,
+		accountRepo:     accountRepo,
+		reconciliations: reconciliations,
+		templates:       templates,
+	}
+}
+
+func (h *AccountHandler) ListAccounts(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w)
+}
+
+// AccountOptions renders the <option> list of an account select
+func (h *AccountHandler) AccountOptions(w http.ResponseWriter, r *http.Request) {
+	accounts, err := h.accountRepo.FindAll()
+	if err != nil {
+		http.Error(w, "Failed to get accounts", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Accounts": accounts,
+		"Selected": r.URL.Query().Get("selected"),
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "account_options.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AccountHandler) AddAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.createAccountUC.Execute(application.CreateAccountInput{
+		Name: r.FormValue("name"),
+		Type: account.AccountType(r.FormValue("type")),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to add account: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", accountsChangedEvent)
+	h.renderList(w)
+}
+
+func (h *AccountHandler) UpdateAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.updateAccountUC.Execute(application.UpdateAccountInput{
+		AccountID: r.FormValue("account_id"),
+		Name:      r.FormValue("name"),
+		Type:      account.AccountType(r.FormValue("type")),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to update account: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", accountsChangedEvent)
+	h.renderList(w)
+}
+
+func (h *AccountHandler) TransferMoney(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	date := time.Now()
+	if value := r.FormValue("date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid date", http.StatusBadRequest)
+			return
+		}
+		date = parsed
+	}
+
+	output, err := h.transferMoneyUC.Execute(application.TransferMoneyInput{
+		FromAccountID: r.FormValue("from_account"),
+		ToAccountID:   r.FormValue("to_account"),
+		Amount:        r.FormValue("amount"),
+		Currency:      r.FormValue("currency"),
+		Description:   r.FormValue("description"),
+		Date:          date,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to transfer money: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Success": true,
+		"Output":  output,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "transfer_success.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AccountHandler) ReconcileAccount(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", r.FormValue("date"))
+	if err != nil {
+		http.Error(w, "Invalid statement date", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.reconcileUC.Execute(application.ReconcileAccountInput{
+		AccountID:      r.FormValue("account"),
+		Date:           date,
+		Balance:        r.FormValue("balance"),
+		Currency:       r.FormValue("currency"),
+		PostAdjustment: r.FormValue("post_adjustment") == "true",
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to reconcile account: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Success": true,
+		"Output":  output,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "reconcile_result.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *AccountHandler) renderList(w http.ResponseWriter) {
+	accounts, err := h.accountRepo.FindAll()
+	if err != nil {
+		http.Error(w, "Failed to get accounts", http.StatusInternalServerError)
+		return
+	}
+
+	latest, err := h.reconciliations.FindLatest()
+	if err != nil {
+		http.Error(w, "Failed to get reconciliations", http.StatusInternalServerError)
+		return
+	}
+	lastReconciled := make(map[string]*account.Reconciliation)
+	for i := range latest {
+		lastReconciled[latest[i].AccountID()] = &latest[i]
+	}
+
+	data := map[string]interface{}{
+		"Accounts":       accounts,
+		"Types":          account.AccountTypes,
+		"LastReconciled": lastReconciled,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "accounts_list.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
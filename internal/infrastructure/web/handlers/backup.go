@@ -0,0 +1,144 @@
+package handlers
+
+import (
+	"html/template"
+	"bytes"
+	"fmt"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"io"
+	"net/http"
+	"time"
+)
+
+type BackupHandler struct {
+	exportDataUC    *application.ExportDataUseCase
+	restoreBackupUC *application.RestoreBackupUseCase
+	templates       *template.Template
+}
+
+func NewBackupHandler(
+	exportDataUC *application.ExportDataUseCase,
+	restoreBackupUC *application.RestoreBackupUseCase,
+	templates *template.Template,
+) *BackupHandler {
+	return &BackupHandler{
+		exportDataUC:    exportDataUC,
+		restoreBackupUC: restoreBackupUC,
+		templates:       templates,
+	}
+}
+
+// Export downloads the data as the JSON document (the default), as one table
+// in CSV, or as an archive of both
+func (h *BackupHandler) Export(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	format := application.BackupFormat(r.URL.Query().Get("format"))
+	if format == "" {
+		format = application.BackupFormatJSON
+	}
+
+	var table application.BackupTable
+	switch format {
+	case application.BackupFormatJSON, application.BackupFormatArchive:
+	case application.BackupFormatCSV:
+		t, err := application.FindBackupTable(r.URL.Query().Get("table"))
+		if err != nil {
+			http.Error(w, "Invalid table: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		table = t
+	default:
+		http.Error(w, "Invalid format", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.exportDataUC.Execute(application.ExportDataInput{
+		At: time.Now(),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to export data: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// The file is built before anything is sent, so a failure still reaches
+	// the browser as an error rather than a truncated download
+	var body bytes.Buffer
+	var contentType, filename string
+	name := "moka-backup-" + output.Backup.ExportedAt.Format("2006-01-02")
+	switch format {
+	case application.BackupFormatJSON:
+		contentType, filename = "application/json", name+".json"
+		err = application.WriteBackupJSON(&body, output.Backup)
+	case application.BackupFormatCSV:
+		contentType, filename = "text/csv", name+"-"+table.Name+".csv"
+		err = table.WriteCSV(&body, output.Backup)
+	case application.BackupFormatArchive:
+		contentType, filename = "application/zip", name+".zip"
+		err = application.WriteBackupArchive(&body, output.Backup)
+	}
+
+	if err != nil {
+		http.Error(w, "Failed to export data: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Write(body.Bytes())
+}
+
+// Import restores a backup, as a JSON document or an archive, into an empty
+// database
+func (h *BackupHandler) Import(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing backup file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(file)
+	if err != nil {
+		http.Error(w, "Failed to read backup file", http.StatusBadRequest)
+		return
+	}
+
+	backup, err := application.ReadBackup(content)
+	if err != nil {
+		http.Error(w, "Failed to restore backup: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.restoreBackupUC.Execute(application.RestoreBackupInput{
+		Backup: backup,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to restore backup: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Backup": output.Backup,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "backup_restore_result.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,159 @@
+package handlers
+
+import (
+	"html/template"
+	"errors"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/domain/budget"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type BudgetHandler struct {
+	setBudgetUC    *application.SetBudgetUseCase
+	updateBudgetUC *application.UpdateBudgetUseCase
+	deleteBudgetUC *application.DeleteBudgetUseCase
+	budgetRepo     budget.Repository
+	templates      *template.Template
+}
+
+func NewBudgetHandler(
+	setBudgetUC *application.SetBudgetUseCase,
+	updateBudgetUC *application.UpdateBudgetUseCase,
+	deleteBudgetUC *application.DeleteBudgetUseCase,
+	budgetRepo budget.Repository,
+	templates *template.Template,
+) *BudgetHandler {
+	return &BudgetHandler{
+		setBudgetUC:    setBudgetUC,
+		updateBudgetUC: updateBudgetUC,
+		deleteBudgetUC: deleteBudgetUC,
+		budgetRepo:     budgetRepo,
+		templates:      templates,
+	}
+}
+
+func (h *BudgetHandler) ListBudgets(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	year := now.Year()
+	month := now.Month()
+
+	if yearStr := r.URL.Query().Get("year"); yearStr != "" {
+		if y, err := strconv.Atoi(yearStr); err == nil {
+			year = y
+		}
+	}
+
+	if monthStr := r.URL.Query().Get("month"); monthStr != "" {
+		if m, err := strconv.Atoi(monthStr); err == nil && m >= 1 && m <= 12 {
+			month = time.Month(m)
+		}
+	}
+
+	h.renderList(w, month, year)
+}
+
+func (h *BudgetHandler) SetBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	period, err := time.Parse("2006-01", r.FormValue("period"))
+	if err != nil {
+		http.Error(w, "Invalid month", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.setBudgetUC.Execute(application.SetBudgetInput{
+		CategoryName: r.FormValue("category"),
+		Limit:        r.FormValue("limit"),
+		Currency:     r.FormValue("currency"),
+		Month:        period.Month(),
+		Year:         period.Year(),
+	})
+
+	if errors.Is(err, shared.ErrInvalidInput) {
+		http.Error(w, "Failed to set budget: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to set budget: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, output.Budget.Month(), output.Budget.Year())
+}
+
+func (h *BudgetHandler) UpdateBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.updateBudgetUC.Execute(application.UpdateBudgetInput{
+		BudgetID: r.FormValue("budget_id"),
+		Limit:    r.FormValue("limit"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to update budget: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, output.Budget.Month(), output.Budget.Year())
+}
+
+func (h *BudgetHandler) DeleteBudget(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.deleteBudgetUC.Execute(application.DeleteBudgetInput{
+		BudgetID: r.FormValue("budget_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to delete budget: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, output.Budget.Month(), output.Budget.Year())
+}
+
+func (h *BudgetHandler) renderList(w http.ResponseWriter, month time.Month, year int) {
+	budgets, err := h.budgetRepo.FindByMonthAndYear(month, year)
+	if err != nil {
+		http.Error(w, "Failed to get budgets", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Budgets": budgets,
+		"Month":   month,
+		"Year":    year,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "budgets_list.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,211 @@
+package handlers
+
+import (
+	"html/template"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/domain/category"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"net/http"
+)
+
+// categoriesChangedEvent tells the category selects on the page to reload
+const categoriesChangedEvent = "categoriesChanged"
+
+type CategoryHandler struct {
+	createCategoryUC  *application.CreateCategoryUseCase
+	updateCategoryUC  *application.UpdateCategoryUseCase
+	archiveCategoryUC *application.ArchiveCategoryUseCase
+	deleteCategoryUC  *application.DeleteCategoryUseCase
+	categoryRepo      category.Repository
+	templates         *template.Template
+}
+
+func NewCategoryHandler(
+	createCategoryUC *application.CreateCategoryUseCase,
+	updateCategoryUC *application.UpdateCategoryUseCase,
+	archiveCategoryUC *application.ArchiveCategoryUseCase,
+	deleteCategoryUC *application.DeleteCategoryUseCase,
+	categoryRepo category.Repository,
+	templates *template.Template,
+) *CategoryHandler {
+	return &CategoryHandler{
+		createCategoryUC:  createCategoryUC,
+		updateCategoryUC:  updateCategoryUC,
+		archiveCategoryUC: archiveCategoryUC,
+		deleteCategoryUC:  deleteCategoryUC,
+		categoryRepo:      categoryRepo,
+		templates:         templates,
+	}
+}
+
+func (h *CategoryHandler) ListCategories(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w)
+}
+
+// CategoryOptions renders the <option> elements of the active categories of
+// the requested type (expense by default), sub-categories under their parent.
+// With parents=1 only top-level categories are listed, for picking a parent;
+// with auto=1 an empty option leaves the choice to the category rules.
+// The selected category is pre-selected, and kept as an option even when it
+// is archived or was never stored, so editing a transaction never changes it
+// silently.
+func (h *CategoryHandler) CategoryOptions(w http.ResponseWriter, r *http.Request) {
+	typ := shared.CategoryType(r.URL.Query().Get("type"))
+	if typ == "" {
+		typ = shared.CategoryTypeExpense
+	}
+	parentsOnly := r.URL.Query().Get("parents") == "1"
+	auto := r.URL.Query().Get("auto") == "1"
+
+	categories, err := h.categoryRepo.FindByType(typ)
+	if err != nil {
+		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
+		return
+	}
+
+	options := category.SortAsTree(category.FilterActive(categories))
+	if parentsOnly {
+		options = category.ChildrenOf(options, "")
+	}
+
+	selected := r.URL.Query().Get("selected")
+	selectedMissing := selected != ""
+	for _, c := range options {
+		if c.Name() == selected {
+			selectedMissing = false
+		}
+	}
+
+	data := map[string]interface{}{
+		"Categories":      options,
+		"ParentsOnly":     parentsOnly,
+		"Auto":            auto,
+		"Selected":        selected,
+		"SelectedMissing": selectedMissing,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "category_options.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *CategoryHandler) AddCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.createCategoryUC.Execute(application.CreateCategoryInput{
+		Name:       r.FormValue("name"),
+		Type:       shared.CategoryType(r.FormValue("type")),
+		ParentName: r.FormValue("parent"),
+		Color:      r.FormValue("color"),
+		Icon:       r.FormValue("icon"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to add category: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", categoriesChangedEvent)
+	h.renderList(w)
+}
+
+func (h *CategoryHandler) UpdateCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.updateCategoryUC.Execute(application.UpdateCategoryInput{
+		CategoryID: r.FormValue("category_id"),
+		Color:      r.FormValue("color"),
+		Icon:       r.FormValue("icon"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to update category: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", categoriesChangedEvent)
+	h.renderList(w)
+}
+
+func (h *CategoryHandler) ArchiveCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.archiveCategoryUC.Execute(application.ArchiveCategoryInput{
+		CategoryID: r.FormValue("category_id"),
+		Archived:   r.FormValue("archived") == "true",
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to archive category: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", categoriesChangedEvent)
+	h.renderList(w)
+}
+
+func (h *CategoryHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.deleteCategoryUC.Execute(application.DeleteCategoryInput{
+		CategoryID: r.FormValue("category_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to delete category: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Trigger", categoriesChangedEvent)
+	h.renderList(w)
+}
+
+func (h *CategoryHandler) renderList(w http.ResponseWriter) {
+	categories, err := h.categoryRepo.FindAll()
+	if err != nil {
+		http.Error(w, "Failed to get categories", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Categories": category.SortAsTree(categories),
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "categories_list.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,190 @@
+package handlers
+
+import (
+	"html/template"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/domain/categorization"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type CategoryRuleHandler struct {
+	createRuleUC      *application.CreateCategoryRuleUseCase
+	setRulePriorityUC *application.SetCategoryRulePriorityUseCase
+	deleteRuleUC      *application.DeleteCategoryRuleUseCase
+	applyRulesUC      *application.ApplyCategoryRulesUseCase
+	ruleRepo          categorization.Repository
+	templates         *template.Template
+}
+
+func NewCategoryRuleHandler(
+	createRuleUC *application.CreateCategoryRuleUseCase,
+	setRulePriorityUC *application.SetCategoryRulePriorityUseCase,
+	deleteRuleUC *application.DeleteCategoryRuleUseCase,
+	applyRulesUC *application.ApplyCategoryRulesUseCase,
+	ruleRepo categorization.Repository,
+	templates *template.Template,
+) *CategoryRuleHandler {
+	return &CategoryRuleHandler{
+		createRuleUC:      createRuleUC,
+		setRulePriorityUC: setRulePriorityUC,
+		deleteRuleUC:      deleteRuleUC,
+		applyRulesUC:      applyRulesUC,
+		ruleRepo:          ruleRepo,
+		templates:         templates,
+	}
+}
+
+func (h *CategoryRuleHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, nil)
+}
+
+func (h *CategoryRuleHandler) AddRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	priority := 0
+	if value := r.FormValue("priority"); value != "" {
+		p, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid priority", http.StatusBadRequest)
+			return
+		}
+		priority = p
+	}
+
+	_, err := h.createRuleUC.Execute(application.CreateCategoryRuleInput{
+		Priority:     priority,
+		Contains:     r.FormValue("contains"),
+		Pattern:      r.FormValue("pattern"),
+		Counterparty: r.FormValue("counterparty"),
+		MinAmount:    r.FormValue("min_amount"),
+		MaxAmount:    r.FormValue("max_amount"),
+		Currency:     r.FormValue("currency"),
+		Type:         r.FormValue("type"),
+		CategoryName: r.FormValue("category"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to add category rule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, nil)
+}
+
+func (h *CategoryRuleHandler) SetPriority(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	priority, err := strconv.Atoi(r.FormValue("priority"))
+	if err != nil {
+		http.Error(w, "Invalid priority", http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.setRulePriorityUC.Execute(application.SetCategoryRulePriorityInput{
+		RuleID:   r.FormValue("rule_id"),
+		Priority: priority,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to set rule priority: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, nil)
+}
+
+func (h *CategoryRuleHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.deleteRuleUC.Execute(application.DeleteCategoryRuleInput{
+		RuleID: r.FormValue("rule_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to delete category rule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, nil)
+}
+
+// ApplyRules re-categorizes the existing transactions, from the since date
+// when one is given
+func (h *CategoryRuleHandler) ApplyRules(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	var since *time.Time
+	if value := r.FormValue("since"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid date", http.StatusBadRequest)
+			return
+		}
+		since = &parsed
+	}
+
+	output, err := h.applyRulesUC.Execute(application.ApplyCategoryRulesInput{
+		Since: since,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to apply category rules: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, output)
+}
+
+// renderList lists the rules in evaluation order, with the outcome of a run
+// over the existing transactions when there was one
+func (h *CategoryRuleHandler) renderList(w http.ResponseWriter, applied *application.ApplyCategoryRulesOutput) {
+	rules, err := h.ruleRepo.FindAll()
+	if err != nil {
+		http.Error(w, "Failed to get category rules", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Rules":   rules,
+		"Applied": applied,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "category_rules_list.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
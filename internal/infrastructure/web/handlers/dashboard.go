@@ -3,6 +3,7 @@ package handlers
 import (
 	"html/template"
 	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/shared"
 	"net/http"
 	"strconv"
 	"time"
@@ -10,15 +11,18 @@ import (
 
 type DashboardHandler struct {
 	getMonthlySummaryUC *application.GetMonthlySummaryUseCase
+	getGoalProgressUC   *application.GetGoalProgressUseCase
 	templates           *template.Template
 }
 
 func NewDashboardHandler(
 	getMonthlySummaryUC *application.GetMonthlySummaryUseCase,
+	getGoalProgressUC *application.GetGoalProgressUseCase,
 	templates *template.Template,
 ) *DashboardHandler {
 	return &DashboardHandler{
 		getMonthlySummaryUC: getMonthlySummaryUC,
+		getGoalProgressUC:   getGoalProgressUC,
 		templates:           templates,
 	}
 }
@@ -46,7 +50,16 @@ func (h *DashboardHandler) ShowDashboard(w http.ResponseWriter, r *http.Request)
 	})
 
 	if err != nil {
-		http.Error(w, "Failed to get monthly summary", http.StatusInternalServerError)
+		http.Error(w, "Failed to get monthly summary: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	goals, err := h.getGoalProgressUC.Execute(application.GetGoalProgressInput{
+		Today: now,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to get goals: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -56,12 +69,15 @@ func (h *DashboardHandler) ShowDashboard(w http.ResponseWriter, r *http.Request)
 	nextDate := currentDate.AddDate(0, 1, 0)
 
 	data := map[string]interface{}{
-		"Title":     "Moka - Dashboard",
-		"Summary":   summary,
-		"PrevYear":  prevDate.Year(),
-		"PrevMonth": int(prevDate.Month()),
-		"NextYear":  nextDate.Year(),
-		"NextMonth": int(nextDate.Month()),
+		"Title":      "Moka - Dashboard",
+		"Summary":    summary,
+		"Goals":      goals,
+		"PrevYear":   prevDate.Year(),
+		"PrevMonth":  int(prevDate.Month()),
+		"NextYear":   nextDate.Year(),
+		"NextMonth":  int(nextDate.Month()),
+		"Currencies": shared.SupportedCurrencies,
+		"Now":        now,
 	}
 
 	if err := h.templates.ExecuteTemplate(w, "base.html", data); err != nil {
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"html/template"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type DuplicateHandler struct {
+	findDuplicatesUC   *application.FindDuplicatesUseCase
+	mergeDuplicateUC   *application.MergeDuplicateUseCase
+	dismissDuplicateUC *application.DismissDuplicateUseCase
+	templates          *template.Template
+}
+
+func NewDuplicateHandler(
+	findDuplicatesUC *application.FindDuplicatesUseCase,
+	mergeDuplicateUC *application.MergeDuplicateUseCase,
+	dismissDuplicateUC *application.DismissDuplicateUseCase,
+	templates *template.Template,
+) *DuplicateHandler {
+	return &DuplicateHandler{
+		findDuplicatesUC:   findDuplicatesUC,
+		mergeDuplicateUC:   mergeDuplicateUC,
+		dismissDuplicateUC: dismissDuplicateUC,
+		templates:          templates,
+	}
+}
+
+func (h *DuplicateHandler) ListDuplicates(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, r)
+}
+
+// MergeDuplicate keeps one of two look-alike transactions and deletes the
+// other
+func (h *DuplicateHandler) MergeDuplicate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.mergeDuplicateUC.Execute(application.MergeDuplicateInput{
+		KeepID:   r.FormValue("keep_id"),
+		RemoveID: r.FormValue("remove_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to merge duplicate: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, r)
+}
+
+// DismissDuplicate stops flagging two look-alike transactions
+func (h *DuplicateHandler) DismissDuplicate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.dismissDuplicateUC.Execute(application.DismissDuplicateInput{
+		TransactionID: r.FormValue("transaction_id"),
+		OtherID:       r.FormValue("other_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to dismiss duplicate: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, r)
+}
+
+// renderList lists the duplicates of the month given by the year and month
+// parameters, the current one by default
+func (h *DuplicateHandler) renderList(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	year := now.Year()
+	month := now.Month()
+
+	if y, err := strconv.Atoi(r.FormValue("year")); err == nil {
+		year = y
+	}
+	if m, err := strconv.Atoi(r.FormValue("month")); err == nil && m >= 1 && m <= 12 {
+		month = time.Month(m)
+	}
+
+	output, err := h.findDuplicatesUC.Execute(application.FindDuplicatesInput{
+		Year:  year,
+		Month: month,
+	})
+	if err != nil {
+		http.Error(w, "Failed to find duplicates: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Output": output,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "duplicates_list.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"html/template"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/domain/exchange_rate"
+	"net/http"
+	"time"
+)
+
+// maxImportSize caps uploaded files at 10 MB
+const maxImportSize = 10 << 20
+
+type ExchangeRateHandler struct {
+	addRateUC     *application.AddExchangeRateUseCase
+	importRatesUC *application.ImportExchangeRatesUseCase
+	deleteRateUC  *application.DeleteExchangeRateUseCase
+	rateRepo      exchange_rate.Repository
+	baseCurrency  string
+	templates     *template.Template
+}
+
+func NewExchangeRateHandler(
+	addRateUC *application.AddExchangeRateUseCase,
+	importRatesUC *application.ImportExchangeRatesUseCase,
+	deleteRateUC *application.DeleteExchangeRateUseCase,
+	rateRepo exchange_rate.Repository,
+	baseCurrency string,
+	templates *template.Template,
+) *ExchangeRateHandler {
+	return &ExchangeRateHandler{
+		addRateUC:     addRateUC,
+		importRatesUC: importRatesUC,
+		deleteRateUC:  deleteRateUC,
+		rateRepo:      rateRepo,
+		baseCurrency:  baseCurrency,
+		templates:     templates,
+	}
+}
+
+func (h *ExchangeRateHandler) ListRates(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w, nil)
+}
+
+func (h *ExchangeRateHandler) AddRate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	effectiveOn, err := time.Parse("2006-01-02", r.FormValue("effective_on"))
+	if err != nil {
+		http.Error(w, "Invalid date", http.StatusBadRequest)
+		return
+	}
+
+	quoteCurrency := r.FormValue("quote_currency")
+	if quoteCurrency == "" {
+		quoteCurrency = h.baseCurrency
+	}
+
+	_, err = h.addRateUC.Execute(application.AddExchangeRateInput{
+		Currency:      r.FormValue("currency"),
+		QuoteCurrency: quoteCurrency,
+		Rate:          r.FormValue("rate"),
+		EffectiveOn:   effectiveOn,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to add exchange rate: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, nil)
+}
+
+func (h *ExchangeRateHandler) ImportRates(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing CSV file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	output, err := h.importRatesUC.Execute(application.ImportExchangeRatesInput{
+		CSV:           file,
+		QuoteCurrency: h.baseCurrency,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to import exchange rates: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, output)
+}
+
+func (h *ExchangeRateHandler) DeleteRate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.deleteRateUC.Execute(application.DeleteExchangeRateInput{
+		RateID: r.FormValue("rate_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to delete exchange rate: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w, nil)
+}
+
+func (h *ExchangeRateHandler) renderList(w http.ResponseWriter, imported *application.ImportExchangeRatesOutput) {
+	rates, err := h.rateRepo.FindAll()
+	if err != nil {
+		http.Error(w, "Failed to get exchange rates", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Rates":  rates,
+		"Import": imported,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "exchange_rates_list.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
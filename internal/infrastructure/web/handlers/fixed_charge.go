@@ -2,26 +2,38 @@ package handlers
 
 import (
 	"html/template"
+	"errors"
+	"github.com/aymaneelmaini/moka/internal/application"
 	"github.com/aymaneelmaini/moka/internal/domain/fixed_charge"
-	"github.com/aymaneelmaini/moka/internal/shared"
 	"net/http"
 	"strconv"
-
-	"github.com/google/uuid"
+	"time"
 )
 
 type FixedChargeHandler struct {
-	fixedChargeRepo fixed_charge.Repository
-	templates       *template.Template
+	addFixedChargeUC    *application.AddFixedChargeUseCase
+	editFixedChargeUC   *application.EditFixedChargeUseCase
+	toggleFixedChargeUC *application.ToggleFixedChargeUseCase
+	deleteFixedChargeUC *application.DeleteFixedChargeUseCase
+	fixedChargeRepo     fixed_charge.Repository
+	templates           *template.Template
 }
 
 func NewFixedChargeHandler(
+	addFixedChargeUC *application.AddFixedChargeUseCase,
+	editFixedChargeUC *application.EditFixedChargeUseCase,
+	toggleFixedChargeUC *application.ToggleFixedChargeUseCase,
+	deleteFixedChargeUC *application.DeleteFixedChargeUseCase,
 	fixedChargeRepo fixed_charge.Repository,
 	templates *template.Template,
 ) *FixedChargeHandler {
 	return &FixedChargeHandler{
-		fixedChargeRepo: fixedChargeRepo,
-		templates:       templates,
+		addFixedChargeUC:    addFixedChargeUC,
+		editFixedChargeUC:   editFixedChargeUC,
+		toggleFixedChargeUC: toggleFixedChargeUC,
+		deleteFixedChargeUC: deleteFixedChargeUC,
+		fixedChargeRepo:     fixedChargeRepo,
+		templates:           templates,
 	}
 }
 
@@ -34,6 +46,7 @@ func (h *FixedChargeHandler) ListFixedCharges(w http.ResponseWriter, r *http.Req
 
 	data := map[string]interface{}{
 		"Charges": charges,
+		"Editing": r.URL.Query().Get("edit"),
 	}
 
 	if err := h.templates.ExecuteTemplate(w, "fixed_charges_list.html", data); err != nil {
@@ -53,33 +66,134 @@ func (h *FixedChargeHandler) AddFixedCharge(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	name := r.FormValue("name")
-	description := r.FormValue("description")
+	startMonth, endMonth, dueDay, err := parseChargeSchedule(r)
+	if err != nil {
+		http.Error(w, "Invalid schedule: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.addFixedChargeUC.Execute(application.AddFixedChargeInput{
+		Name:        r.FormValue("name"),
+		Amount:      r.FormValue("amount"),
+		Currency:    r.FormValue("currency"),
+		Description: r.FormValue("description"),
+		StartMonth:  startMonth,
+		EndMonth:    endMonth,
+		DueDay:      dueDay,
+	})
 
-	amount, err := strconv.ParseFloat(r.FormValue("amount"), 64)
 	if err != nil {
-		http.Error(w, "Invalid amount", http.StatusBadRequest)
+		http.Error(w, "Failed to save fixed charge: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.ListFixedCharges(w, r)
+}
+
+func (h *FixedChargeHandler) EditFixedCharge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
 	}
 
-	money, err := shared.NewMoney(amount)
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	startMonth, endMonth, dueDay, err := parseChargeSchedule(r)
 	if err != nil {
-		http.Error(w, "Invalid amount: "+err.Error(), http.StatusBadRequest)
+		http.Error(w, "Invalid schedule: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	charge := fixed_charge.NewFixedCharge(
-		uuid.New().String(),
-		name,
-		money,
-		description,
-		true,
-	)
+	_, err = h.editFixedChargeUC.Execute(application.EditFixedChargeInput{
+		FixedChargeID: r.FormValue("fixed_charge_id"),
+		Name:          r.FormValue("name"),
+		Amount:        r.FormValue("amount"),
+		Currency:      r.FormValue("currency"),
+		Description:   r.FormValue("description"),
+		StartMonth:    startMonth,
+		EndMonth:      endMonth,
+		DueDay:        dueDay,
+	})
 
-	if err := h.fixedChargeRepo.Save(charge); err != nil {
-		http.Error(w, "Failed to save fixed charge", http.StatusInternalServerError)
+	if err != nil {
+		http.Error(w, "Failed to edit fixed charge: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	h.ListFixedCharges(w, r)
 }
+
+func (h *FixedChargeHandler) ToggleFixedCharge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.toggleFixedChargeUC.Execute(application.ToggleFixedChargeInput{
+		FixedChargeID: r.FormValue("fixed_charge_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to update fixed charge: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.ListFixedCharges(w, r)
+}
+
+func (h *FixedChargeHandler) DeleteFixedCharge(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.deleteFixedChargeUC.Execute(application.DeleteFixedChargeInput{
+		FixedChargeID: r.FormValue("fixed_charge_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to delete fixed charge: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.ListFixedCharges(w, r)
+}
+
+// parseChargeSchedule reads the optional start_month, end_month (YYYY-MM)
+// and due_day form fields
+func parseChargeSchedule(r *http.Request) (*time.Time, *time.Time, int, error) {
+	months := make([]*time.Time, 2)
+	for i, field := range []string{"start_month", "end_month"} {
+		if value := r.FormValue(field); value != "" {
+			parsed, err := time.Parse("2006-01", value)
+			if err != nil {
+				return nil, nil, 0, errors.New("months must look like 2025-06")
+			}
+			months[i] = &parsed
+		}
+	}
+
+	dueDay := 0
+	if value := r.FormValue("due_day"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, nil, 0, errors.New("due day must be a whole number")
+		}
+		dueDay = parsed
+	}
+
+	return months[0], months[1], dueDay, nil
+}
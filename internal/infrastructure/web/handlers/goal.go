@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"html/template"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"net/http"
+	"time"
+)
+
+type GoalHandler struct {
+	createGoalUC      *application.CreateGoalUseCase
+	contributeUC      *application.ContributeToGoalUseCase
+	deleteGoalUC      *application.DeleteGoalUseCase
+	getGoalProgressUC *application.GetGoalProgressUseCase
+	templates         *template.Template
+}
+
+func NewGoalHandler(
+	createGoalUC *application.CreateGoalUseCase,
+	contributeUC *application.ContributeToGoalUseCase,
+	deleteGoalUC *application.DeleteGoalUseCase,
+	getGoalProgressUC *application.GetGoalProgressUseCase,
+	templates *template.Template,
+) *GoalHandler {
+	return &GoalHandler{
+		createGoalUC:      createGoalUC,
+		contributeUC:      contributeUC,
+		deleteGoalUC:      deleteGoalUC,
+		getGoalProgressUC: getGoalProgressUC,
+		templates:         templates,
+	}
+}
+
+func (h *GoalHandler) ListGoals(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w)
+}
+
+func (h *GoalHandler) AddGoal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	var deadline *time.Time
+	if value := r.FormValue("deadline"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid deadline", http.StatusBadRequest)
+			return
+		}
+		deadline = &parsed
+	}
+
+	_, err := h.createGoalUC.Execute(application.CreateGoalInput{
+		Name:         r.FormValue("name"),
+		TargetAmount: r.FormValue("target"),
+		Currency:     r.FormValue("currency"),
+		Deadline:     deadline,
+		AccountID:    r.FormValue("account"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to add goal: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w)
+}
+
+func (h *GoalHandler) Contribute(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	date := time.Now()
+	if value := r.FormValue("date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid date", http.StatusBadRequest)
+			return
+		}
+		date = parsed
+	}
+
+	_, err := h.contributeUC.Execute(application.ContributeToGoalInput{
+		GoalID:        r.FormValue("goal_id"),
+		FromAccountID: r.FormValue("from_account"),
+		Amount:        r.FormValue("amount"),
+		Currency:      r.FormValue("currency"),
+		Date:          date,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to contribute to goal: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w)
+}
+
+func (h *GoalHandler) DeleteGoal(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.deleteGoalUC.Execute(application.DeleteGoalInput{
+		GoalID: r.FormValue("goal_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to delete goal: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w)
+}
+
+func (h *GoalHandler) renderList(w http.ResponseWriter) {
+	progress, err := h.getGoalProgressUC.Execute(application.GetGoalProgressInput{
+		Today: time.Now(),
+	})
+	if err != nil {
+		http.Error(w, "Failed to get goals: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Progress": progress,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "goals_list.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"html/template"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+type ImportHandler struct {
+	importStatementUC     *application.ImportStatementUseCase
+	importStatementFileUC *application.ImportStatementFileUseCase
+	templates             *template.Template
+}
+
+func NewImportHandler(
+	importStatementUC *application.ImportStatementUseCase,
+	importStatementFileUC *application.ImportStatementFileUseCase,
+	templates *template.Template,
+) *ImportHandler {
+	return &ImportHandler{
+		importStatementUC:     importStatementUC,
+		importStatementFileUC: importStatementFileUC,
+		templates:             templates,
+	}
+}
+
+// ImportStatement previews a CSV bank statement, or imports it when the
+// form is submitted with commit=true
+func (h *ImportHandler) ImportStatement(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	file, _, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing CSV file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	columns := make(map[string]int)
+	for _, field := range []string{"date_column", "description_column", "amount_column", "debit_column", "credit_column"} {
+		value := r.FormValue(field)
+		if value == "" {
+			continue
+		}
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid column number: "+value, http.StatusBadRequest)
+			return
+		}
+		columns[field] = parsed
+	}
+
+	output, err := h.importStatementUC.Execute(application.ImportStatementInput{
+		CSV: file,
+		Mapping: application.StatementMapping{
+			DateColumn:        columns["date_column"],
+			DescriptionColumn: columns["description_column"],
+			AmountColumn:      columns["amount_column"],
+			DebitColumn:       columns["debit_column"],
+			CreditColumn:      columns["credit_column"],
+			SignConvention:    application.SignConvention(r.FormValue("sign_convention")),
+			DateFormat:        r.FormValue("date_format"),
+			DecimalComma:      r.FormValue("decimal_comma") == "true",
+			Delimiter:         r.FormValue("delimiter"),
+			SkipHeader:        r.FormValue("skip_header") == "true",
+		},
+		AccountID:       r.FormValue("account"),
+		Currency:        r.FormValue("currency"),
+		ExpenseCategory: r.FormValue("expense_category"),
+		IncomeCategory:  r.FormValue("income_category"),
+		SkipDuplicates:  r.FormValue("skip_duplicates") == "true",
+		Commit:          r.FormValue("commit") == "true",
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to import statement: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Output": output,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "import_statement_result.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
+
+// statementAccountField prefixes the form fields linking a statement account,
+// whose number follows, to an account
+const statementAccountField = "statement_account:"
+
+// ImportStatementFile previews an OFX or QIF bank statement, or imports it
+// when the form is submitted with commit=true
+func (h *ImportHandler) ImportStatementFile(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseMultipartForm(maxImportSize); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		http.Error(w, "Missing statement file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	format, err := application.StatementFileFormatOf(header.Filename)
+	if err != nil {
+		http.Error(w, "Failed to import statement: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	accounts := make(map[string]string)
+	for field, values := range r.MultipartForm.Value {
+		if number, ok := strings.CutPrefix(field, statementAccountField); ok && len(values) > 0 {
+			accounts[number] = values[0]
+		}
+	}
+
+	output, err := h.importStatementFileUC.Execute(application.ImportStatementFileInput{
+		File:            file,
+		Format:          format,
+		DateFormat:      r.FormValue("date_format"),
+		DecimalComma:    r.FormValue("decimal_comma") == "true",
+		Currency:        r.FormValue("currency"),
+		ExpenseCategory: r.FormValue("expense_category"),
+		IncomeCategory:  r.FormValue("income_category"),
+		Accounts:        accounts,
+		AccountID:       r.FormValue("account"),
+		SkipDuplicates:  r.FormValue("skip_duplicates") == "true",
+		Commit:          r.FormValue("commit") == "true",
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to import statement: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Output":                output,
+		"StatementAccountField": statementAccountField,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "import_statement_file_result.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
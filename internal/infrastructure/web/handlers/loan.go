@@ -1,28 +1,54 @@
 package handlers
 
 import (
+	"errors"
 	"html/template"
 	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/domain/loan"
 	"net/http"
 	"strconv"
 	"time"
 )
 
 type LoanHandler struct {
-	borrowMoneyUC *application.BorrowMoneyUseCase
-	payLoanUC     *application.PayLoanUseCase
-	templates     *template.Template
+	borrowMoneyUC      *application.BorrowMoneyUseCase
+	payLoanUC          *application.PayLoanUseCase
+	lendMoneyUC        *application.LendMoneyUseCase
+	collectRepaymentUC *application.CollectRepaymentUseCase
+	getLoanHistoryUC   *application.GetLoanHistoryUseCase
+	editLoanUC         *application.EditLoanUseCase
+	cancelLoanUC       *application.CancelLoanUseCase
+	deleteLoanUC       *application.DeleteLoanUseCase
+	undoLoanPaymentUC  *application.UndoLoanPaymentUseCase
+	loanRepo           loan.Repository
+	templates          *template.Template
 }
 
 func NewLoanHandler(
 	borrowMoneyUC *application.BorrowMoneyUseCase,
 	payLoanUC *application.PayLoanUseCase,
+	lendMoneyUC *application.LendMoneyUseCase,
+	collectRepaymentUC *application.CollectRepaymentUseCase,
+	getLoanHistoryUC *application.GetLoanHistoryUseCase,
+	editLoanUC *application.EditLoanUseCase,
+	cancelLoanUC *application.CancelLoanUseCase,
+	deleteLoanUC *application.DeleteLoanUseCase,
+	undoLoanPaymentUC *application.UndoLoanPaymentUseCase,
+	loanRepo loan.Repository,
 	templates *template.Template,
 ) *LoanHandler {
 	return &LoanHandler{
-		borrowMoneyUC: borrowMoneyUC,
-		payLoanUC:     payLoanUC,
-		templates:     templates,
+		borrowMoneyUC:      borrowMoneyUC,
+		payLoanUC:          payLoanUC,
+		lendMoneyUC:        lendMoneyUC,
+		collectRepaymentUC: collectRepaymentUC,
+		getLoanHistoryUC:   getLoanHistoryUC,
+		editLoanUC:         editLoanUC,
+		cancelLoanUC:       cancelLoanUC,
+		deleteLoanUC:       deleteLoanUC,
+		undoLoanPaymentUC:  undoLoanPaymentUC,
+		loanRepo:           loanRepo,
+		templates:          templates,
 	}
 }
 
@@ -37,24 +63,28 @@ func (h *LoanHandler) BorrowMoney(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	amount, err := strconv.ParseFloat(r.FormValue("amount"), 64)
+	amount := r.FormValue("amount")
+	lenderName := r.FormValue("lender_name")
+	description := r.FormValue("description")
+
+	dueDate, installments, err := parseRepaymentPlan(r)
 	if err != nil {
-		http.Error(w, "Invalid amount", http.StatusBadRequest)
+		http.Error(w, "Invalid repayment plan: "+err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	lenderName := r.FormValue("lender_name")
-	description := r.FormValue("description")
-
 	output, err := h.borrowMoneyUC.Execute(application.BorrowMoneyInput{
-		LenderName:  lenderName,
-		Amount:      amount,
-		Description: description,
-		Date:        time.Now(),
+		LenderName:   lenderName,
+		Amount:       amount,
+		Currency:     r.FormValue("currency"),
+		Description:  description,
+		Date:         time.Now(),
+		DueDate:      dueDate,
+		Installments: installments,
 	})
 
 	if err != nil {
-		http.Error(w, "Failed to borrow money", http.StatusInternalServerError)
+		http.Error(w, "Failed to borrow money: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -80,25 +110,262 @@ func (h *LoanHandler) PayLoan(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	amount, err := strconv.ParseFloat(r.FormValue("amount"), 64)
+	amount := r.FormValue("amount")
+	loanID := r.FormValue("loan_id")
+
+	_, err := h.payLoanUC.Execute(application.PayLoanInput{
+		LoanID:        loanID,
+		Amount:        amount,
+		Date:          time.Now(),
+		RefundSurplus: r.FormValue("refund_surplus") == "true",
+	})
+
 	if err != nil {
-		http.Error(w, "Invalid amount", http.StatusBadRequest)
+		http.Error(w, "Failed to pay loan: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
-	loanID := r.FormValue("loan_id")
+	w.Header().Set("HX-Redirect", "/")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *LoanHandler) LendMoney(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	dueDate, installments, err := parseRepaymentPlan(r)
+	if err != nil {
+		http.Error(w, "Invalid repayment plan: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.lendMoneyUC.Execute(application.LendMoneyInput{
+		BorrowerName: r.FormValue("borrower_name"),
+		Amount:       r.FormValue("amount"),
+		Currency:     r.FormValue("currency"),
+		Description:  r.FormValue("description"),
+		Date:         time.Now(),
+		DueDate:      dueDate,
+		Installments: installments,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to lend money: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Success": true,
+		"Output":  output,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "lend_success.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *LoanHandler) CollectRepayment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
 
-	_, err = h.payLoanUC.Execute(application.PayLoanInput{
-		LoanID: loanID,
-		Amount: amount,
+	_, err := h.collectRepaymentUC.Execute(application.CollectRepaymentInput{
+		LoanID: r.FormValue("loan_id"),
+		Amount: r.FormValue("amount"),
 		Date:   time.Now(),
 	})
 
 	if err != nil {
-		http.Error(w, "Failed to pay loan: "+err.Error(), http.StatusInternalServerError)
+		http.Error(w, "Failed to collect repayment: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/")
+	w.WriteHeader(http.StatusOK)
+}
+
+// ListLoans renders every loan, whatever its status
+func (h *LoanHandler) ListLoans(w http.ResponseWriter, r *http.Request) {
+	loans, err := h.loanRepo.FindAll()
+	if err != nil {
+		http.Error(w, "Failed to get loans", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Loans": loans,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "loans_list.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
+
+// LoanHistory renders a loan with its payments
+func (h *LoanHandler) LoanHistory(w http.ResponseWriter, r *http.Request) {
+	output, err := h.getLoanHistoryUC.Execute(application.GetLoanHistoryInput{
+		LoanID: r.URL.Query().Get("id"),
+		At:     time.Now(),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to get loan history: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Loan":     output.Loan,
+		"Payments": output.Payments,
+		"Schedule": output.Schedule,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "loan_history.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
+
+func (h *LoanHandler) EditLoan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	borrowedAt, err := time.Parse("2006-01-02", r.FormValue("borrowed_at"))
+	if err != nil {
+		http.Error(w, "Invalid date", http.StatusBadRequest)
+		return
+	}
+
+	_, err = h.editLoanUC.Execute(application.EditLoanInput{
+		LoanID:      r.FormValue("loan_id"),
+		LenderName:  r.FormValue("lender_name"),
+		Amount:      r.FormValue("amount"),
+		Description: r.FormValue("description"),
+		BorrowedAt:  borrowedAt,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to edit loan: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *LoanHandler) CancelLoan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.cancelLoanUC.Execute(application.CancelLoanInput{
+		LoanID: r.FormValue("loan_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to cancel loan: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/")
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *LoanHandler) DeleteLoan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.deleteLoanUC.Execute(application.DeleteLoanInput{
+		LoanID: r.FormValue("loan_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to delete loan: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("HX-Redirect", "/")
 	w.WriteHeader(http.StatusOK)
 }
+
+func (h *LoanHandler) UndoPayment(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.undoLoanPaymentUC.Execute(application.UndoLoanPaymentInput{
+		PaymentID: r.FormValue("payment_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to undo payment: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", "/")
+	w.WriteHeader(http.StatusOK)
+}
+
+// parseRepaymentPlan reads the optional due date and number of monthly
+// installments of a new loan
+func parseRepaymentPlan(r *http.Request) (*time.Time, int, error) {
+	var dueDate *time.Time
+	if value := r.FormValue("due_date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			return nil, 0, errors.New("due date must be a date")
+		}
+		dueDate = &parsed
+	}
+
+	installments := 0
+	if value := r.FormValue("installments"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, 0, errors.New("installments must be a whole number")
+		}
+		installments = parsed
+	}
+
+	return dueDate, installments, nil
+}
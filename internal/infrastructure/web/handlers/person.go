@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"html/template"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/shared"
+	"net/http"
+	"time"
+)
+
+type PersonHandler struct {
+	getPersonLedgerUC *application.GetPersonLedgerUseCase
+	templates         *template.Template
+}
+
+func NewPersonHandler(
+	getPersonLedgerUC *application.GetPersonLedgerUseCase,
+	templates *template.Template,
+) *PersonHandler {
+	return &PersonHandler{
+		getPersonLedgerUC: getPersonLedgerUC,
+		templates:         templates,
+	}
+}
+
+// ShowPerson renders the ledger of every loan with the person in the path
+func (h *PersonHandler) ShowPerson(w http.ResponseWriter, r *http.Request) {
+	ledger, err := h.getPersonLedgerUC.Execute(application.GetPersonLedgerInput{
+		Name: r.PathValue("name"),
+		At:   time.Now(),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to get ledger: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Title":      "Moka - " + ledger.Name,
+		"Ledger":     ledger,
+		"Currencies": shared.SupportedCurrencies,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "base.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"html/template"
+	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/domain/recurring"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+type RecurringHandler struct {
+	createRuleUC *application.CreateRecurringRuleUseCase
+	toggleRuleUC *application.ToggleRecurringRuleUseCase
+	deleteRuleUC *application.DeleteRecurringRuleUseCase
+	runRulesUC   *application.RunRecurringRulesUseCase
+	ruleRepo     recurring.Repository
+	templates    *template.Template
+}
+
+func NewRecurringHandler(
+	createRuleUC *application.CreateRecurringRuleUseCase,
+	toggleRuleUC *application.ToggleRecurringRuleUseCase,
+	deleteRuleUC *application.DeleteRecurringRuleUseCase,
+	runRulesUC *application.RunRecurringRulesUseCase,
+	ruleRepo recurring.Repository,
+	templates *template.Template,
+) *RecurringHandler {
+	return &RecurringHandler{
+		createRuleUC: createRuleUC,
+		toggleRuleUC: toggleRuleUC,
+		deleteRuleUC: deleteRuleUC,
+		runRulesUC:   runRulesUC,
+		ruleRepo:     ruleRepo,
+		templates:    templates,
+	}
+}
+
+func (h *RecurringHandler) ListRules(w http.ResponseWriter, r *http.Request) {
+	h.renderList(w)
+}
+
+func (h *RecurringHandler) AddRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	startDate := time.Now()
+	if value := r.FormValue("start_date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid start date", http.StatusBadRequest)
+			return
+		}
+		startDate = parsed
+	}
+
+	var endDate *time.Time
+	if value := r.FormValue("end_date"); value != "" {
+		parsed, err := time.Parse("2006-01-02", value)
+		if err != nil {
+			http.Error(w, "Invalid end date", http.StatusBadRequest)
+			return
+		}
+		endDate = &parsed
+	}
+
+	interval := 0
+	if value := r.FormValue("interval"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid interval", http.StatusBadRequest)
+			return
+		}
+		interval = parsed
+	}
+
+	dayOfMonth := 0
+	if value := r.FormValue("day_of_month"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid day of month", http.StatusBadRequest)
+			return
+		}
+		dayOfMonth = parsed
+	}
+
+	_, err := h.createRuleUC.Execute(application.CreateRecurringRuleInput{
+		Description:  r.FormValue("description"),
+		Amount:       r.FormValue("amount"),
+		Currency:     r.FormValue("currency"),
+		Type:         r.FormValue("type"),
+		CategoryName: r.FormValue("category"),
+		Frequency:    r.FormValue("frequency"),
+		Interval:     interval,
+		DayOfMonth:   dayOfMonth,
+		StartDate:    startDate,
+		EndDate:      endDate,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to add recurring rule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.runAndRenderList(w)
+}
+
+func (h *RecurringHandler) ToggleRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.toggleRuleUC.Execute(application.ToggleRecurringRuleInput{
+		RuleID: r.FormValue("rule_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to update recurring rule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.runAndRenderList(w)
+}
+
+func (h *RecurringHandler) DeleteRule(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	_, err := h.deleteRuleUC.Execute(application.DeleteRecurringRuleInput{
+		RuleID: r.FormValue("rule_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to delete recurring rule: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w)
+}
+
+// runAndRenderList applies rules that are already due, such as one starting
+// today, without waiting for the next scheduled run
+func (h *RecurringHandler) runAndRenderList(w http.ResponseWriter) {
+	if _, err := h.runRulesUC.Execute(application.RunRecurringRulesInput{At: time.Now()}); err != nil {
+		http.Error(w, "Failed to run recurring rules: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.renderList(w)
+}
+
+func (h *RecurringHandler) renderList(w http.ResponseWriter) {
+	rules, err := h.ruleRepo.FindAll()
+	if err != nil {
+		http.Error(w, "Failed to get recurring rules", http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Rules": rules,
+		"Now":   time.Now(),
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "recurring_rules_list.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
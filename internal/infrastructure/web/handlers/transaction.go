@@ -1,28 +1,42 @@
 package handlers
 
 import (
+	"fmt"
 	"html/template"
 	"github.com/aymaneelmaini/moka/internal/application"
+	"github.com/aymaneelmaini/moka/internal/domain/transaction"
+	"github.com/aymaneelmaini/moka/internal/shared"
 	"net/http"
-	"strconv"
 	"time"
 )
 
 type TransactionHandler struct {
-	addSalaryUC       *application.AddSalaryUseCase
-	recordExpenseUC   *application.RecordExpenseUseCase
-	templates         *template.Template
+	addSalaryUC         *application.AddSalaryUseCase
+	recordIncomeUC      *application.RecordIncomeUseCase
+	recordExpenseUC     *application.RecordExpenseUseCase
+	editTransactionUC   *application.EditTransactionUseCase
+	deleteTransactionUC *application.DeleteTransactionUseCase
+	transactionRepo     transaction.Repository
+	templates           *template.Template
 }
 
 func NewTransactionHandler(
 	addSalaryUC *application.AddSalaryUseCase,
+	recordIncomeUC *application.RecordIncomeUseCase,
 	recordExpenseUC *application.RecordExpenseUseCase,
+	editTransactionUC *application.EditTransactionUseCase,
+	deleteTransactionUC *application.DeleteTransactionUseCase,
+	transactionRepo transaction.Repository,
 	templates *template.Template,
 ) *TransactionHandler {
 	return &TransactionHandler{
-		addSalaryUC:     addSalaryUC,
-		recordExpenseUC: recordExpenseUC,
-		templates:       templates,
+		addSalaryUC:         addSalaryUC,
+		recordIncomeUC:      recordIncomeUC,
+		recordExpenseUC:     recordExpenseUC,
+		editTransactionUC:   editTransactionUC,
+		deleteTransactionUC: deleteTransactionUC,
+		transactionRepo:     transactionRepo,
+		templates:           templates,
 	}
 }
 
@@ -37,18 +51,16 @@ func (h *TransactionHandler) AddSalary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	amount, err := strconv.ParseFloat(r.FormValue("amount"), 64)
-	if err != nil {
-		http.Error(w, "Invalid amount", http.StatusBadRequest)
-		return
-	}
-
+	amount := r.FormValue("amount")
 	description := r.FormValue("description")
 
 	output, err := h.addSalaryUC.Execute(application.AddSalaryInput{
-		Amount:      amount,
-		Description: description,
-		Date:        time.Now(),
+		Amount:            amount,
+		Currency:          r.FormValue("currency"),
+		Description:       description,
+		Date:              time.Now(),
+		ApplyFixedCharges: r.FormValue("skip_fixed_charges") != "true",
+		AccountID:         r.FormValue("account"),
 	})
 
 	if err != nil {
@@ -67,7 +79,7 @@ func (h *TransactionHandler) AddSalary(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
-func (h *TransactionHandler) RecordExpense(w http.ResponseWriter, r *http.Request) {
+func (h *TransactionHandler) RecordIncome(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		return
@@ -78,24 +90,58 @@ func (h *TransactionHandler) RecordExpense(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	amount, err := strconv.ParseFloat(r.FormValue("amount"), 64)
+	output, err := h.recordIncomeUC.Execute(application.RecordIncomeInput{
+		Amount:            r.FormValue("amount"),
+		Currency:          r.FormValue("currency"),
+		CategoryName:      r.FormValue("category"),
+		Description:       r.FormValue("description"),
+		Date:              time.Now(),
+		ApplyFixedCharges: r.FormValue("apply_fixed_charges") == "true",
+		AccountID:         r.FormValue("account"),
+	})
+
 	if err != nil {
-		http.Error(w, "Invalid amount", http.StatusBadRequest)
+		http.Error(w, "Failed to record income: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Success": true,
+		"Output":  output,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, "income_success.html", data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
 		return
 	}
+}
 
+func (h *TransactionHandler) RecordExpense(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	amount := r.FormValue("amount")
 	categoryName := r.FormValue("category")
 	description := r.FormValue("description")
 
 	output, err := h.recordExpenseUC.Execute(application.RecordExpenseInput{
 		Amount:       amount,
+		Currency:     r.FormValue("currency"),
 		CategoryName: categoryName,
 		Description:  description,
 		Date:         time.Now(),
+		AccountID:    r.FormValue("account"),
 	})
 
 	if err != nil {
-		http.Error(w, "Failed to record expense", http.StatusInternalServerError)
+		http.Error(w, "Failed to record expense: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
 
@@ -109,3 +155,96 @@ func (h *TransactionHandler) RecordExpense(w http.ResponseWriter, r *http.Reques
 		return
 	}
 }
+
+// TransactionRow renders a single dashboard row, e.g. when an edit is cancelled
+func (h *TransactionHandler) TransactionRow(w http.ResponseWriter, r *http.Request) {
+	h.renderRow(w, r.URL.Query().Get("id"), "transaction_row.html")
+}
+
+// EditTransactionRow renders the inline edit form for a dashboard row
+func (h *TransactionHandler) EditTransactionRow(w http.ResponseWriter, r *http.Request) {
+	h.renderRow(w, r.URL.Query().Get("id"), "transaction_edit_row.html")
+}
+
+func (h *TransactionHandler) EditTransaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", r.FormValue("date"))
+	if err != nil {
+		http.Error(w, "Invalid date", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.editTransactionUC.Execute(application.EditTransactionInput{
+		TransactionID: r.FormValue("transaction_id"),
+		Amount:        r.FormValue("amount"),
+		Currency:      r.FormValue("currency"),
+		CategoryName:  r.FormValue("category"),
+		Description:   r.FormValue("description"),
+		Date:          date,
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to edit transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Totals, budgets and loans may all have changed
+	w.Header().Set("HX-Redirect", dashboardURL(output.Transaction.CreatedAt()))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *TransactionHandler) DeleteTransaction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	output, err := h.deleteTransactionUC.Execute(application.DeleteTransactionInput{
+		TransactionID: r.FormValue("transaction_id"),
+	})
+
+	if err != nil {
+		http.Error(w, "Failed to delete transaction: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("HX-Redirect", dashboardURL(output.Transaction.CreatedAt()))
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *TransactionHandler) renderRow(w http.ResponseWriter, id string, name string) {
+	tx, err := h.transactionRepo.FindByID(id)
+	if err != nil {
+		http.Error(w, "Failed to get transaction", http.StatusNotFound)
+		return
+	}
+
+	data := map[string]interface{}{
+		"Transaction": tx,
+		"Currencies":  shared.SupportedCurrencies,
+	}
+
+	if err := h.templates.ExecuteTemplate(w, name, data); err != nil {
+		http.Error(w, "Failed to render template", http.StatusInternalServerError)
+		return
+	}
+}
+
+// dashboardURL points at the dashboard month containing date
+func dashboardURL(date time.Time) string {
+	return fmt.Sprintf("/?year=%d&month=%d", date.Year(), int(date.Month()))
+}
@@ -17,6 +17,11 @@ const (
 var (
 	CategorySalary        = Category{name: "Salary", typ: CategoryTypeIncome}
 	CategoryBorrowed      = Category{name: "Borrowed (Salaf)", typ: CategoryTypeIncome}
+	CategoryLent          = Category{name: "Lent (Salaf)", typ: CategoryTypeExpense}
+	CategoryTransferIn    = Category{name: "Transfer", typ: CategoryTypeIncome}
+	CategoryTransferOut   = Category{name: "Transfer", typ: CategoryTypeExpense}
+	CategoryAdjustmentIn  = Category{name: "Balance adjustment", typ: CategoryTypeIncome}
+	CategoryAdjustmentOut = Category{name: "Balance adjustment", typ: CategoryTypeExpense}
 	CategoryFood          = Category{name: "Food", typ: CategoryTypeExpense}
 	CategoryTransport     = Category{name: "Transport", typ: CategoryTypeExpense}
 	CategoryEntertainment = Category{name: "Entertainment", typ: CategoryTypeExpense}
@@ -3,50 +3,253 @@ package shared
 import (
 	"errors"
 	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
 )
 
 const (
 	CurrencyMAD = "MAD"
+	CurrencyEUR = "EUR"
+	CurrencyUSD = "USD"
+
+	// DefaultCurrency is used when no currency is given
+	DefaultCurrency = CurrencyMAD
 )
 
+// SupportedCurrencies lists the currencies offered in the UI
+var SupportedCurrencies = []string{CurrencyMAD, CurrencyEUR, CurrencyUSD}
+
+// minorUnitDigits is the number of decimals of a minor unit (centimes, cents)
+const minorUnitDigits = 2
+
+// rateDigits is the number of decimals kept for conversion rates
+const rateDigits = 6
+
 var (
-	ErrNegativeAmount = errors.New("amount cannot be negative")
-	ErrZeroAmount     = errors.New("amount cannot be zero")
-	ErrInvalidAmount  = errors.New("amount is invalid")
+	ErrNegativeAmount    = errors.New("amount cannot be negative")
+	ErrZeroAmount        = errors.New("amount cannot be zero")
+	ErrInvalidAmount     = errors.New("amount is invalid")
+	ErrInvalidCurrency   = errors.New("currency is invalid")
+	ErrCurrencyMismatch  = errors.New("currency mismatch")
+	ErrInvalidConversion = errors.New("conversion rate is invalid")
 )
 
+// Money is an exact amount stored as an integer number of minor units
+// (centimes), so repeated additions and subtractions never drift.
 type Money struct {
-	amount float64
+	amount   int64
+	currency string
 }
 
-func NewMoney(amount float64) (Money, error) {
-	if amount <= 0 {
-		if amount == 0 {
+// NewMoney creates a strictly positive amount from minor units
+func NewMoney(minorUnits int64, currency string) (Money, error) {
+	code, err := NormalizeCurrency(currency)
+	if err != nil {
+		return Money{}, err
+	}
+
+	if minorUnits <= 0 {
+		if minorUnits == 0 {
 			return Money{}, ErrZeroAmount
 		}
 		return Money{}, ErrNegativeAmount
 	}
-	return Money{amount: amount}, nil
+	return Money{amount: minorUnits, currency: code}, nil
+}
+
+func UnsafeNewMoney(minorUnits int64, currency string) Money {
+	return Money{amount: minorUnits, currency: currency}
+}
+
+// ParseMoney parses a decimal string such as "120", "33.33" or "1 250,5"
+// into a strictly positive amount. See ParseMinorUnits for rounding rules.
+func ParseMoney(s string, currency string) (Money, error) {
+	minorUnits, err := ParseMinorUnits(s)
+	if err != nil {
+		return Money{}, err
+	}
+	return NewMoney(minorUnits, currency)
+}
+
+// ParseMinorUnits parses a signed decimal string into minor units. Spaces and
+// underscores are ignored and a comma is accepted as the decimal separator.
+// Digits beyond the second decimal place are rounded half away from zero,
+// so "0.005" becomes 1 and "-0.005" becomes -1.
+func ParseMinorUnits(s string) (int64, error) {
+	value, err := parseFixedPoint(s, minorUnitDigits)
+	if err != nil {
+		return 0, ErrInvalidAmount
+	}
+	return value, nil
+}
+
+// parseFixedPoint parses a signed decimal string into an integer scaled by
+// 10^digits, rounding half away from zero
+func parseFixedPoint(s string, digits int) (int64, error) {
+	cleaned := strings.NewReplacer(" ", "", "_", "", "\u00a0", "").Replace(strings.TrimSpace(s))
+	cleaned = strings.Replace(cleaned, ",", ".", 1)
+
+	if cleaned == "" {
+		return 0, strconv.ErrSyntax
+	}
+
+	negative := false
+	switch cleaned[0] {
+	case '-':
+		negative = true
+		cleaned = cleaned[1:]
+	case '+':
+		cleaned = cleaned[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(cleaned, ".")
+	if intPart == "" && fracPart == "" {
+		return 0, strconv.ErrSyntax
+	}
+	if intPart == "" {
+		intPart = "0"
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, strconv.ErrSyntax
+	}
+
+	scale := int64(1)
+	for i := 0; i < digits; i++ {
+		scale *= 10
+	}
+
+	units, err := strconv.ParseInt(intPart, 10, 64)
+	if err != nil || units > (1<<63-1)/scale-1 {
+		return 0, strconv.ErrRange
+	}
+
+	fracPart += strings.Repeat("0", digits+1)
+	fraction, _ := strconv.ParseInt(fracPart[:digits], 10, 64)
+
+	value := units*scale + fraction
+	if fracPart[digits] >= '5' {
+		value++
+	}
+
+	if negative {
+		value = -value
+	}
+
+	return value, nil
 }
 
-func UnsafeNewMoney(amount float64) Money {
-	return Money{amount: amount}
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
 
-func (m Money) Amount() float64 {
+// NormalizeCurrency upper-cases and validates an ISO 4217 style code
+func NormalizeCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 {
+		return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return "", fmt.Errorf("%w: %q", ErrInvalidCurrency, code)
+		}
+	}
+	return code, nil
+}
+
+// MinorUnits returns the amount in centimes
+func (m Money) MinorUnits() int64 {
 	return m.amount
 }
 
+// Decimal formats the amount with exactly two decimals, e.g. "-12.05"
+func (m Money) Decimal() string {
+	return formatFixedPoint(m.amount, minorUnitDigits)
+}
+
+func formatFixedPoint(value int64, digits int) string {
+	sign := ""
+	if value < 0 {
+		sign = "-"
+		value = -value
+	}
+
+	scale := int64(1)
+	for i := 0; i < digits; i++ {
+		scale *= 10
+	}
+
+	return fmt.Sprintf("%s%d.%0*d", sign, value/scale, digits, value%scale)
+}
+
+// Currency returns the ISO code of the amount. The neutral Zero() value has
+// no currency.
 func (m Money) Currency() string {
-	return CurrencyMAD
+	return m.currency
+}
+
+// Add sums two amounts of the same currency. The neutral Zero() value can be
+// combined with any currency.
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount + other.amount, currency: currency}, nil
+}
+
+// Subtract subtracts an amount of the same currency
+func (m Money) Subtract(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{amount: m.amount - other.amount, currency: currency}, nil
 }
 
-func (m Money) Add(other Money) Money {
-	return Money{amount: m.amount + other.amount}
+func (m Money) commonCurrency(other Money) (string, error) {
+	switch {
+	case m.currency == other.currency:
+		return m.currency, nil
+	case m.currency == "":
+		return other.currency, nil
+	case other.currency == "":
+		return m.currency, nil
+	default:
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, other.currency)
+	}
 }
 
-func (m Money) Subtract(other Money) Money {
-	return Money{amount: m.amount - other.amount}
+// Convert converts the amount into another currency using the given rate,
+// rounding half away from zero to the nearest minor unit. It fails when the
+// converted amount is too large to be held.
+func (m Money) Convert(rate ConversionRate, to string) (Money, error) {
+	if m.currency == to {
+		return m, nil
+	}
+
+	product := new(big.Int).Mul(big.NewInt(m.amount), big.NewInt(rate.micros))
+	divisor := big.NewInt(rateScale)
+	quotient, remainder := new(big.Int).QuoRem(product, divisor, new(big.Int))
+
+	if new(big.Int).Abs(remainder).Cmp(big.NewInt(rateScale/2)) >= 0 {
+		if product.Sign() < 0 {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	if !quotient.IsInt64() {
+		return Money{}, fmt.Errorf("%w: %s at %s is out of range", ErrInvalidAmount, m, rate)
+	}
+
+	return Money{amount: quotient.Int64(), currency: to}, nil
 }
 
 func (m Money) IsPositive() bool {
@@ -61,18 +264,89 @@ func (m Money) IsZero() bool {
 	return m.amount == 0
 }
 
-func (m Money) GreaterThan(other Money) bool {
-	return m.amount > other.amount
+// GreaterThan compares two amounts of the same currency
+func (m Money) GreaterThan(other Money) (bool, error) {
+	if _, err := m.commonCurrency(other); err != nil {
+		return false, err
+	}
+	return m.amount > other.amount, nil
 }
 
-func (m Money) LessThan(other Money) bool {
-	return m.amount < other.amount
+// LessThan compares two amounts of the same currency
+func (m Money) LessThan(other Money) (bool, error) {
+	if _, err := m.commonCurrency(other); err != nil {
+		return false, err
+	}
+	return m.amount < other.amount, nil
 }
 
 func (m Money) String() string {
-	return fmt.Sprintf("%.2f %s", m.amount, CurrencyMAD)
+	if m.currency == "" {
+		return m.Decimal()
+	}
+	return fmt.Sprintf("%s %s", m.Decimal(), m.currency)
 }
 
+// Zero returns a neutral zero amount that adopts the currency of whatever it
+// is added to
 func Zero() Money {
 	return Money{amount: 0}
 }
+
+// ZeroOf returns a zero amount in the given currency
+func ZeroOf(currency string) Money {
+	return Money{amount: 0, currency: currency}
+}
+
+// rateScale is 10^rateDigits
+const rateScale = 1_000_000
+
+// ConversionRate is how many units of a target currency one unit of a source
+// currency buys, kept with six decimals
+type ConversionRate struct {
+	micros int64
+}
+
+// ParseConversionRate parses a strictly positive rate such as "10.85"
+func ParseConversionRate(s string) (ConversionRate, error) {
+	micros, err := parseFixedPoint(s, rateDigits)
+	if err != nil {
+		return ConversionRate{}, ErrInvalidConversion
+	}
+	return NewConversionRate(micros)
+}
+
+// NewConversionRate creates a rate from millionths
+func NewConversionRate(micros int64) (ConversionRate, error) {
+	if micros <= 0 {
+		return ConversionRate{}, ErrInvalidConversion
+	}
+	return ConversionRate{micros: micros}, nil
+}
+
+// IdentityRate converts a currency into itself
+func IdentityRate() ConversionRate {
+	return ConversionRate{micros: rateScale}
+}
+
+// Micros returns the rate in millionths
+func (r ConversionRate) Micros() int64 {
+	return r.micros
+}
+
+// Inverse returns the rate for the opposite direction. It fails when the
+// inverse is too small to be kept with six decimals.
+func (r ConversionRate) Inverse() (ConversionRate, error) {
+	if r.micros == 0 {
+		return ConversionRate{}, ErrInvalidConversion
+	}
+	inverse := (int64(rateScale)*rateScale + r.micros/2) / r.micros
+	if inverse == 0 {
+		return ConversionRate{}, fmt.Errorf("%w: the inverse of %s rounds to zero", ErrInvalidConversion, r)
+	}
+	return ConversionRate{micros: inverse}, nil
+}
+
+func (r ConversionRate) String() string {
+	return formatFixedPoint(r.micros, rateDigits)
+}
@@ -0,0 +1,77 @@
+-- Amounts go back to REAL major units.
+
+CREATE TABLE transactions_new (
+    id TEXT PRIMARY KEY,
+    amount REAL NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    category_name TEXT NOT NULL,
+    category_type TEXT NOT NULL,
+    description TEXT,
+    type TEXT NOT NULL CHECK(type IN ('income', 'expense')),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO transactions_new (id, amount, currency, category_name, category_type, description, type, created_at)
+SELECT id, amount / 100.0, currency, category_name, category_type, description, type, created_at
+FROM transactions;
+
+DROP TABLE transactions;
+ALTER TABLE transactions_new RENAME TO transactions;
+
+CREATE TABLE budgets_new (
+    id TEXT PRIMARY KEY,
+    category_name TEXT NOT NULL,
+    limit_amount REAL NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    month INTEGER NOT NULL CHECK(month >= 1 AND month <= 12),
+    year INTEGER NOT NULL,
+    UNIQUE(category_name, month, year)
+);
+
+INSERT INTO budgets_new (id, category_name, limit_amount, currency, month, year)
+SELECT id, category_name, limit_amount / 100.0, currency, month, year
+FROM budgets;
+
+DROP TABLE budgets;
+ALTER TABLE budgets_new RENAME TO budgets;
+
+CREATE TABLE fixed_charges_new (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    amount REAL NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT 1
+);
+
+INSERT INTO fixed_charges_new (id, name, amount, currency, description, is_active)
+SELECT id, name, amount / 100.0, currency, description, is_active
+FROM fixed_charges;
+
+DROP TABLE fixed_charges;
+ALTER TABLE fixed_charges_new RENAME TO fixed_charges;
+
+CREATE TABLE loans_new (
+    id TEXT PRIMARY KEY,
+    lender_name TEXT NOT NULL,
+    amount REAL NOT NULL,
+    amount_paid REAL NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    borrowed_at DATETIME NOT NULL,
+    paid_back_at DATETIME,
+    status TEXT NOT NULL CHECK(status IN ('active', 'paid_back')),
+    description TEXT
+);
+
+INSERT INTO loans_new (id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description)
+SELECT id, lender_name, amount / 100.0, amount_paid / 100.0, currency, borrowed_at, paid_back_at, status, description
+FROM loans;
+
+DROP TABLE loans;
+ALTER TABLE loans_new RENAME TO loans;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_date ON transactions(created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_category ON transactions(category_name);
+CREATE INDEX IF NOT EXISTS idx_transactions_type ON transactions(type);
+CREATE INDEX IF NOT EXISTS idx_budgets_month_year ON budgets(month, year);
+CREATE INDEX IF NOT EXISTS idx_loans_status ON loans(status);
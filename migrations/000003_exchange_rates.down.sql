@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_exchange_rates_pair_date;
+
+DROP TABLE IF EXISTS exchange_rates;
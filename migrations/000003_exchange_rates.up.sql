@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS exchange_rates (
+    id TEXT PRIMARY KEY,
+    currency TEXT NOT NULL,
+    quote_currency TEXT NOT NULL,
+    rate_micros INTEGER NOT NULL CHECK(rate_micros > 0),
+    effective_on TEXT NOT NULL,
+    UNIQUE(currency, quote_currency, effective_on)
+);
+
+CREATE INDEX IF NOT EXISTS idx_exchange_rates_pair_date ON exchange_rates(currency, quote_currency, effective_on);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_categories_type;
+
+DROP TABLE IF EXISTS categories;
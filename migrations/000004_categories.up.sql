@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    type TEXT NOT NULL CHECK(type IN ('income', 'expense')),
+    color TEXT NOT NULL DEFAULT '',
+    icon TEXT NOT NULL DEFAULT '',
+    is_archived BOOLEAN NOT NULL DEFAULT 0,
+    is_builtin BOOLEAN NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_type ON categories(type);
+
+INSERT OR IGNORE INTO categories (id, name, type, color, icon, is_builtin) VALUES
+    ('builtin-salary', 'Salary', 'income', '#28a745', '💼', 1),
+    ('builtin-borrowed', 'Borrowed (Salaf)', 'income', '#fd7e14', '🤝', 1),
+    ('builtin-food', 'Food', 'expense', '#e76f51', '🍽️', 1),
+    ('builtin-transport', 'Transport', 'expense', '#457b9d', '🚌', 1),
+    ('builtin-entertainment', 'Entertainment', 'expense', '#9b5de5', '🎬', 1),
+    ('builtin-shopping', 'Shopping', 'expense', '#f15bb5', '🛍️', 1),
+    ('builtin-health', 'Health', 'expense', '#2a9d8f', '💊', 1),
+    ('builtin-other', 'Other', 'expense', '#6c757d', '📦', 1);
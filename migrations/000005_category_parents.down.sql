@@ -0,0 +1,22 @@
+-- Sub-categories become top-level categories again.
+
+DROP INDEX IF EXISTS idx_categories_parent;
+
+CREATE TABLE categories_new (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    type TEXT NOT NULL CHECK(type IN ('income', 'expense')),
+    color TEXT NOT NULL DEFAULT '',
+    icon TEXT NOT NULL DEFAULT '',
+    is_archived BOOLEAN NOT NULL DEFAULT 0,
+    is_builtin BOOLEAN NOT NULL DEFAULT 0
+);
+
+INSERT INTO categories_new (id, name, type, color, icon, is_archived, is_builtin)
+SELECT id, name, type, color, icon, is_archived, is_builtin
+FROM categories;
+
+DROP TABLE categories;
+ALTER TABLE categories_new RENAME TO categories;
+
+CREATE INDEX IF NOT EXISTS idx_categories_type ON categories(type);
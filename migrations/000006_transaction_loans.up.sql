@@ -0,0 +1,27 @@
+ALTER TABLE transactions ADD COLUMN loan_id TEXT REFERENCES loans(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_loan ON transactions(loan_id);
+
+-- Link existing borrowings to the loan recorded with the same amount and date
+UPDATE transactions
+SET loan_id = (
+    SELECT l.id FROM loans l
+    WHERE l.amount = transactions.amount
+      AND l.currency = transactions.currency
+      AND l.borrowed_at = transactions.created_at
+    LIMIT 1
+)
+WHERE type = 'income' AND category_name = 'Borrowed (Salaf)';
+
+-- Link existing repayments to the latest loan from the same lender taken
+-- before the payment
+UPDATE transactions
+SET loan_id = (
+    SELECT l.id FROM loans l
+    WHERE transactions.category_name = 'Loan Payment - ' || l.lender_name
+      AND l.currency = transactions.currency
+      AND l.borrowed_at <= transactions.created_at
+    ORDER BY l.borrowed_at DESC
+    LIMIT 1
+)
+WHERE type = 'expense' AND category_name LIKE 'Loan Payment - %';
@@ -0,0 +1,27 @@
+DROP INDEX IF EXISTS idx_loan_payments_transaction;
+DROP INDEX IF EXISTS idx_loan_payments_loan;
+DROP TABLE IF EXISTS loan_payments;
+
+-- Cancelled loans are kept as active ones.
+
+CREATE TABLE loans_new (
+    id TEXT PRIMARY KEY,
+    lender_name TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    amount_paid INTEGER NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    borrowed_at DATETIME NOT NULL,
+    paid_back_at DATETIME,
+    status TEXT NOT NULL CHECK(status IN ('active', 'paid_back')),
+    description TEXT
+);
+
+INSERT INTO loans_new (id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description)
+SELECT id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at,
+       CASE status WHEN 'cancelled' THEN 'active' ELSE status END, description
+FROM loans;
+
+DROP TABLE loans;
+ALTER TABLE loans_new RENAME TO loans;
+
+CREATE INDEX IF NOT EXISTS idx_loans_status ON loans(status);
@@ -0,0 +1,49 @@
+-- Loans can now be cancelled, which needs a wider status CHECK.
+
+CREATE TABLE loans_new (
+    id TEXT PRIMARY KEY,
+    lender_name TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    amount_paid INTEGER NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    borrowed_at DATETIME NOT NULL,
+    paid_back_at DATETIME,
+    status TEXT NOT NULL CHECK(status IN ('active', 'paid_back', 'cancelled')),
+    description TEXT
+);
+
+INSERT INTO loans_new (id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description)
+SELECT id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description
+FROM loans;
+
+DROP TABLE loans;
+ALTER TABLE loans_new RENAME TO loans;
+
+CREATE INDEX IF NOT EXISTS idx_loans_status ON loans(status);
+
+CREATE TABLE IF NOT EXISTS loan_payments (
+    id TEXT PRIMARY KEY,
+    loan_id TEXT NOT NULL REFERENCES loans(id),
+    transaction_id TEXT REFERENCES transactions(id),
+    amount INTEGER NOT NULL CHECK(amount > 0),
+    currency TEXT NOT NULL,
+    paid_at DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_loan_payments_loan ON loan_payments(loan_id);
+CREATE INDEX IF NOT EXISTS idx_loan_payments_transaction ON loan_payments(transaction_id);
+
+-- Every repayment transaction becomes a payment
+INSERT INTO loan_payments (id, loan_id, transaction_id, amount, currency, paid_at)
+SELECT lower(hex(randomblob(16))), loan_id, id, amount, currency, created_at
+FROM transactions
+WHERE loan_id IS NOT NULL AND type = 'expense';
+
+-- Whatever was paid without a matching transaction is kept as one payment,
+-- so the history always adds up to amount_paid
+INSERT INTO loan_payments (id, loan_id, transaction_id, amount, currency, paid_at)
+SELECT lower(hex(randomblob(16))), l.id, NULL,
+       l.amount_paid - COALESCE((SELECT SUM(p.amount) FROM loan_payments p WHERE p.loan_id = l.id), 0),
+       l.currency, COALESCE(l.paid_back_at, l.borrowed_at)
+FROM loans l
+WHERE l.amount_paid > COALESCE((SELECT SUM(p.amount) FROM loan_payments p WHERE p.loan_id = l.id), 0);
@@ -0,0 +1,30 @@
+DELETE FROM categories WHERE id = 'builtin-lent';
+
+-- Receivables have no place without a direction, so they are removed with
+-- their payments; their transactions are kept as plain ones.
+DELETE FROM loan_payments WHERE loan_id IN (SELECT id FROM loans WHERE direction = 'lent');
+UPDATE transactions SET loan_id = NULL WHERE loan_id IN (SELECT id FROM loans WHERE direction = 'lent');
+DELETE FROM loans WHERE direction = 'lent';
+
+DROP INDEX IF EXISTS idx_loans_direction;
+
+CREATE TABLE loans_new (
+    id TEXT PRIMARY KEY,
+    lender_name TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    amount_paid INTEGER NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    borrowed_at DATETIME NOT NULL,
+    paid_back_at DATETIME,
+    status TEXT NOT NULL CHECK(status IN ('active', 'paid_back', 'cancelled')),
+    description TEXT
+);
+
+INSERT INTO loans_new (id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description)
+SELECT id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description
+FROM loans;
+
+DROP TABLE loans;
+ALTER TABLE loans_new RENAME TO loans;
+
+CREATE INDEX IF NOT EXISTS idx_loans_status ON loans(status);
@@ -0,0 +1,7 @@
+-- Loans can now be money we lent (receivables) as well as money we borrowed.
+ALTER TABLE loans ADD COLUMN direction TEXT NOT NULL DEFAULT 'borrowed' CHECK(direction IN ('borrowed', 'lent'));
+
+CREATE INDEX IF NOT EXISTS idx_loans_direction ON loans(direction);
+
+INSERT OR IGNORE INTO categories (id, name, type, color, icon, is_builtin) VALUES
+    ('builtin-lent', 'Lent (Salaf)', 'expense', '#0077b6', '🤝', 1);
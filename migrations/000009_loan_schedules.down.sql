@@ -0,0 +1,25 @@
+DROP INDEX IF EXISTS idx_loan_installments_due_date;
+DROP TABLE IF EXISTS loan_installments;
+
+CREATE TABLE loans_new (
+    id TEXT PRIMARY KEY,
+    lender_name TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    amount_paid INTEGER NOT NULL DEFAULT 0,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    borrowed_at DATETIME NOT NULL,
+    paid_back_at DATETIME,
+    status TEXT NOT NULL CHECK(status IN ('active', 'paid_back', 'cancelled')),
+    description TEXT,
+    direction TEXT NOT NULL DEFAULT 'borrowed' CHECK(direction IN ('borrowed', 'lent'))
+);
+
+INSERT INTO loans_new (id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description, direction)
+SELECT id, lender_name, amount, amount_paid, currency, borrowed_at, paid_back_at, status, description, direction
+FROM loans;
+
+DROP TABLE loans;
+ALTER TABLE loans_new RENAME TO loans;
+
+CREATE INDEX IF NOT EXISTS idx_loans_status ON loans(status);
+CREATE INDEX IF NOT EXISTS idx_loans_direction ON loans(direction);
@@ -0,0 +1,14 @@
+-- Loans can have a due date and a schedule of installments.
+ALTER TABLE loans ADD COLUMN due_date DATETIME;
+
+CREATE TABLE IF NOT EXISTS loan_installments (
+    id TEXT PRIMARY KEY,
+    loan_id TEXT NOT NULL REFERENCES loans(id),
+    number INTEGER NOT NULL CHECK(number > 0),
+    due_date DATETIME NOT NULL,
+    amount INTEGER NOT NULL CHECK(amount > 0),
+    currency TEXT NOT NULL,
+    UNIQUE(loan_id, number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_loan_installments_due_date ON loan_installments(due_date);
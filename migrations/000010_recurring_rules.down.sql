@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_recurring_occurrences_transaction_id;
+DROP TABLE IF EXISTS recurring_occurrences;
+DROP TABLE IF EXISTS recurring_rules;
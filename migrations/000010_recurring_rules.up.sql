@@ -0,0 +1,26 @@
+-- Recurring rules generate transactions on their due dates. Each applied due
+-- date is recorded once so missed runs can be caught up without duplicates.
+CREATE TABLE IF NOT EXISTS recurring_rules (
+    id TEXT PRIMARY KEY,
+    description TEXT NOT NULL,
+    amount INTEGER NOT NULL CHECK(amount > 0),
+    currency TEXT NOT NULL,
+    category_name TEXT NOT NULL,
+    category_type TEXT NOT NULL CHECK(category_type IN ('income', 'expense')),
+    frequency TEXT NOT NULL CHECK(frequency IN ('daily', 'weekly', 'monthly', 'yearly')),
+    interval_count INTEGER NOT NULL DEFAULT 1 CHECK(interval_count > 0),
+    day_of_month INTEGER NOT NULL DEFAULT 0 CHECK(day_of_month BETWEEN 0 AND 31),
+    start_date DATETIME NOT NULL,
+    end_date DATETIME,
+    is_active BOOLEAN NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS recurring_occurrences (
+    id TEXT PRIMARY KEY,
+    rule_id TEXT NOT NULL REFERENCES recurring_rules(id),
+    due_date DATETIME NOT NULL,
+    transaction_id TEXT REFERENCES transactions(id),
+    UNIQUE(rule_id, due_date)
+);
+
+CREATE INDEX IF NOT EXISTS idx_recurring_occurrences_transaction_id ON recurring_occurrences(transaction_id);
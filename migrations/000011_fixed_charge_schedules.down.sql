@@ -0,0 +1,15 @@
+CREATE TABLE fixed_charges_new (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    amount INTEGER NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    description TEXT,
+    is_active BOOLEAN NOT NULL DEFAULT 1
+);
+
+INSERT INTO fixed_charges_new (id, name, amount, currency, description, is_active)
+SELECT id, name, amount, currency, description, is_active
+FROM fixed_charges;
+
+DROP TABLE fixed_charges;
+ALTER TABLE fixed_charges_new RENAME TO fixed_charges;
@@ -0,0 +1,4 @@
+-- Fixed charges can be limited to a range of months and due on a given day.
+ALTER TABLE fixed_charges ADD COLUMN start_month DATETIME;
+ALTER TABLE fixed_charges ADD COLUMN end_month DATETIME;
+ALTER TABLE fixed_charges ADD COLUMN due_day INTEGER NOT NULL DEFAULT 0 CHECK(due_day BETWEEN 0 AND 31);
@@ -0,0 +1,25 @@
+DROP INDEX IF EXISTS idx_transactions_fixed_charge;
+
+CREATE TABLE transactions_new (
+    id TEXT PRIMARY KEY,
+    amount INTEGER NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    category_name TEXT NOT NULL,
+    category_type TEXT NOT NULL,
+    description TEXT,
+    type TEXT NOT NULL CHECK(type IN ('income', 'expense')),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    loan_id TEXT REFERENCES loans(id)
+);
+
+INSERT INTO transactions_new (id, amount, currency, category_name, category_type, description, type, created_at, loan_id)
+SELECT id, amount, currency, category_name, category_type, description, type, created_at, loan_id
+FROM transactions;
+
+DROP TABLE transactions;
+ALTER TABLE transactions_new RENAME TO transactions;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_date ON transactions(created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_category ON transactions(category_name);
+CREATE INDEX IF NOT EXISTS idx_transactions_type ON transactions(type);
+CREATE INDEX IF NOT EXISTS idx_transactions_loan ON transactions(loan_id);
@@ -0,0 +1,14 @@
+ALTER TABLE transactions ADD COLUMN fixed_charge_id TEXT REFERENCES fixed_charges(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_fixed_charge ON transactions(fixed_charge_id);
+
+-- Link existing deductions to the fixed charge they were recorded from: the
+-- salary used the charge name as category and its description in the text
+UPDATE transactions
+SET fixed_charge_id = (
+    SELECT fc.id FROM fixed_charges fc
+    WHERE fc.name = transactions.category_name
+      AND 'Fixed charge: ' || COALESCE(fc.description, '') = transactions.description
+    LIMIT 1
+)
+WHERE type = 'expense' AND description LIKE 'Fixed charge: %';
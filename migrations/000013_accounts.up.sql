@@ -0,0 +1,41 @@
+-- Accounts are where money is kept. Transactions recorded before accounts
+-- existed all belong to the default account.
+CREATE TABLE IF NOT EXISTS accounts (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL UNIQUE COLLATE NOCASE,
+    type TEXT NOT NULL CHECK(type IN ('cash', 'bank_card', 'savings', 'mobile_wallet'))
+);
+
+INSERT OR IGNORE INTO accounts (id, name, type) VALUES ('default', 'Main', 'cash');
+
+-- A transfer is a pair of entries sharing a transfer_id: money leaving one
+-- account (transfer_out) and arriving in another (transfer_in)
+CREATE TABLE transactions_new (
+    id TEXT PRIMARY KEY,
+    amount INTEGER NOT NULL,
+    currency TEXT NOT NULL DEFAULT 'MAD',
+    category_name TEXT NOT NULL,
+    category_type TEXT NOT NULL,
+    description TEXT,
+    type TEXT NOT NULL CHECK(type IN ('income', 'expense', 'transfer_in', 'transfer_out')),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    loan_id TEXT REFERENCES loans(id),
+    fixed_charge_id TEXT REFERENCES fixed_charges(id),
+    account_id TEXT NOT NULL DEFAULT 'default' REFERENCES accounts(id),
+    transfer_id TEXT
+);
+
+INSERT INTO transactions_new (id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id)
+SELECT id, amount, currency, category_name, category_type, description, type, created_at, loan_id, fixed_charge_id
+FROM transactions;
+
+DROP TABLE transactions;
+ALTER TABLE transactions_new RENAME TO transactions;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_date ON transactions(created_at);
+CREATE INDEX IF NOT EXISTS idx_transactions_category ON transactions(category_name);
+CREATE INDEX IF NOT EXISTS idx_transactions_type ON transactions(type);
+CREATE INDEX IF NOT EXISTS idx_transactions_loan ON transactions(loan_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_fixed_charge ON transactions(fixed_charge_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_account ON transactions(account_id);
+CREATE INDEX IF NOT EXISTS idx_transactions_transfer ON transactions(transfer_id);
@@ -0,0 +1,18 @@
+-- A reconciliation records the balance the bank reported for an account on a
+-- date. Transactions it covers point to it and are locked from then on.
+CREATE TABLE IF NOT EXISTS reconciliations (
+    id TEXT PRIMARY KEY,
+    account_id TEXT NOT NULL REFERENCES accounts(id),
+    statement_date DATETIME NOT NULL,
+    statement_balance INTEGER NOT NULL,
+    currency TEXT NOT NULL,
+    computed_balance INTEGER NOT NULL,
+    adjustment_transaction_id TEXT REFERENCES transactions(id),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_reconciliations_account ON reconciliations(account_id, statement_date);
+
+ALTER TABLE transactions ADD COLUMN reconciliation_id TEXT REFERENCES reconciliations(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_reconciliation ON transactions(reconciliation_id);
@@ -0,0 +1,15 @@
+-- A savings goal is an amount to put aside on an account by an optional
+-- deadline. Both entries of a transfer that contributes to it point to it.
+CREATE TABLE IF NOT EXISTS goals (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    target_amount INTEGER NOT NULL CHECK(target_amount > 0),
+    currency TEXT NOT NULL,
+    deadline DATETIME,
+    account_id TEXT NOT NULL REFERENCES accounts(id),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE transactions ADD COLUMN goal_id TEXT REFERENCES goals(id);
+
+CREATE INDEX IF NOT EXISTS idx_transactions_goal ON transactions(goal_id);
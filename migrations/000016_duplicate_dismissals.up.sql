@@ -0,0 +1,8 @@
+-- Pairs of transactions that look alike but that the user confirmed are both
+-- genuine, stored with the smaller ID first
+CREATE TABLE IF NOT EXISTS duplicate_dismissals (
+    transaction_id TEXT NOT NULL REFERENCES transactions(id),
+    other_id TEXT NOT NULL REFERENCES transactions(id),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (transaction_id, other_id)
+);
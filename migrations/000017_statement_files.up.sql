@@ -0,0 +1,12 @@
+-- Transactions imported from an OFX statement keep the id the bank gave
+-- them, unique within an account, so a statement can be imported again
+ALTER TABLE transactions ADD COLUMN fitid TEXT;
+
+CREATE INDEX IF NOT EXISTS idx_transactions_fitid ON transactions(account_id, fitid);
+
+-- The account each bank account found in a statement file is imported into
+CREATE TABLE IF NOT EXISTS statement_accounts (
+    number TEXT PRIMARY KEY,
+    account_id TEXT NOT NULL REFERENCES accounts(id),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,18 @@
+-- Rules that categorize transactions from their description and amount,
+-- evaluated by ascending priority. Unset conditions are NULL; the amount
+-- bounds share amount_currency.
+CREATE TABLE IF NOT EXISTS category_rules (
+    id TEXT PRIMARY KEY,
+    priority INTEGER NOT NULL CHECK(priority > 0),
+    contains TEXT,
+    pattern TEXT,
+    counterparty TEXT,
+    min_amount INTEGER,
+    max_amount INTEGER,
+    amount_currency TEXT,
+    category_name TEXT NOT NULL,
+    category_type TEXT NOT NULL CHECK(category_type IN ('income', 'expense')),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_rules_priority ON category_rules(priority);